@@ -0,0 +1,305 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DSTPolicy determines when an Alarm fires on a day on which its time of day
+// does not occur (it falls in the gap when clocks go forward) or occurs twice
+// (when clocks go back) due to a daylight saving transition.
+type DSTPolicy int
+
+const (
+	// DSTNormalise fires at the time given by time.Date for a time of day that
+	// does not occur, i.e. offset by the length of the gap (a time of day 30
+	// minutes into a one hour gap fires 30 minutes after the clocks go
+	// forward), and only at the first occurrence of a time of day that occurs
+	// twice.
+	DSTNormalise DSTPolicy = iota
+
+	// DSTSkip does not fire on a day on which the time of day does not occur,
+	// and fires only at the first occurrence of a time of day that occurs
+	// twice.
+	DSTSkip
+
+	// DSTNextValid fires at the first valid instant after a time of day that
+	// does not occur (the instant at which the clocks go forward), and only at
+	// the first occurrence of a time of day that occurs twice.
+	DSTNextValid
+
+	// DSTRunBoth fires at both occurrences of a time of day that occurs twice
+	// and, as for DSTNextValid, at the instant at which the clocks go forward
+	// for a time of day that does not occur.
+	DSTRunBoth
+)
+
+// String returns the name of the policy.
+func (p DSTPolicy) String() string {
+	switch p {
+	case DSTNormalise:
+		return "DSTNormalise"
+	case DSTSkip:
+		return "DSTSkip"
+	case DSTNextValid:
+		return "DSTNextValid"
+	case DSTRunBoth:
+		return "DSTRunBoth"
+	}
+	return "<invalid DSTPolicy(" + strconv.Itoa(int(p)) + ")>"
+}
+
+// Alarm sends the time on a channel every day at a given time of day in a
+// given location, as an alarm clock.
+//
+// Unlike a Ticker, an Alarm is anchored to civil (wall-clock) time rather than
+// elapsed time, so it fires at the same local time of day across changes in
+// daylight saving time.  By default (DSTNormalise):
+//
+//   - if the time of day does not occur on a day (it falls in the gap when
+//     clocks go forward) the alarm fires at the time given by time.Date for
+//     that time of day, i.e. after the clocks have gone forward;
+//
+//   - if the time of day occurs twice on a day (when clocks go back) the
+//     alarm fires only at the first occurrence.
+//
+// Other behaviours may be specified using WithDSTPolicy.  An alarm may be
+// restricted to fire only on certain days of the week (e.g. for a weekly job)
+// using On.
+//
+// An Alarm fires no more than once for any occurrence of its time of day (and,
+// unless the DSTRunBoth policy applies, no more than once for any day), so that
+// it does not fire again if the clock is set back after it has fired.  Timers
+// are armed using a Schedule, so that an alarm fires at the correct time if the
+// clock is set forward.
+type Alarm struct {
+	// C is the channel on which the time at which the alarm was due is sent
+	// each time it fires.  As for a Ticker, the channel is buffered for one
+	// value; if the previous value has not been received the value is
+	// dropped.
+	C <-chan time.Time
+
+	c        chan time.Time
+	mu       sync.Mutex
+	schedule Schedule
+	h, m, s  int
+	loc      *time.Location
+	policy   DSTPolicy
+	days     map[time.Weekday]bool
+	next     time.Time
+	lastDay  time.Time
+	lastAt   time.Time
+	pending  *ScheduledFunc
+	armed    int
+	stopped  bool
+}
+
+// NewAlarm returns an Alarm firing every day at a given time of day in a given
+// location (or UTC, if nil), according to a clock (or the system clock, if
+// nil).  The alarm fires first at the next occurrence of the time of day,
+// which may be immediately if it is the current time.
+//
+// The time of day may be specified in any of the forms accepted by
+// ParseRelative, e.g. "6am", "06:00", "17:30:15", "noon" or "midnight".  If
+// the time of day is not valid an error wrapping ErrInvalidTimeOfDay is
+// returned.
+func NewAlarm(clock Clock, timeOfDay string, loc *time.Location) (*Alarm, error) {
+	h, m, s, err := parseTimeOfDay(timeOfDay)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTimeOfDay, err)
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	c := make(chan time.Time, 1)
+	a := &Alarm{
+		C:        c,
+		c:        c,
+		schedule: Schedule{Clock: clock},
+		h:        h,
+		m:        m,
+		s:        s,
+		loc:      loc,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.arm()
+
+	return a, nil
+}
+
+// Next returns the time at which the alarm is next due to fire.  If the alarm
+// has been stopped, the zero time is returned.
+func (a *Alarm) Next() time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.stopped {
+		return time.Time{}
+	}
+	return a.next
+}
+
+// On restricts the alarm to fire only on the given days of the week (in the
+// location of the alarm), e.g. for a weekly job, returning the alarm.  If no
+// days are specified the alarm fires every day.  The alarm is re-armed for the
+// next occurrence on those days.
+func (a *Alarm) On(days ...time.Weekday) *Alarm {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.days = nil
+	if len(days) > 0 {
+		a.days = map[time.Weekday]bool{}
+		for _, d := range days {
+			a.days[d] = true
+		}
+	}
+	a.rearm()
+
+	return a
+}
+
+// WithDSTPolicy sets the policy determining when the alarm fires on a day on
+// which its time of day does not occur, or occurs twice, returning the alarm.
+// The alarm is re-armed for the next occurrence according to the policy.
+//
+// Any policy other than those defined by this package is treated as
+// DSTNormalise.
+func (a *Alarm) WithDSTPolicy(p DSTPolicy) *Alarm {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.policy = p
+	a.rearm()
+
+	return a
+}
+
+// Stop stops the alarm; no further times are sent on the channel.  The
+// channel is not closed.
+func (a *Alarm) Stop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.stopped = true
+	a.pending.Cancel()
+}
+
+// rearm cancels the pending occurrence of the alarm (if not stopped) and arms
+// the alarm for its next occurrence.
+//
+// This method is not thread-safe and should only be called while the alarm
+// is locked.
+func (a *Alarm) rearm() {
+	if a.stopped {
+		return
+	}
+	a.pending.Cancel()
+	a.arm()
+}
+
+// arm schedules the alarm for the next occurrence of the time of day that has
+// not yet passed, on a day after the last day on which it fired (or, on that
+// day, after the last occurrence at which it fired).
+//
+// This method is not thread-safe and should only be called while the alarm
+// is locked.
+func (a *Alarm) arm() {
+	now := a.schedule.Clock.Now().In(a.loc)
+	armed := 0
+
+	y, mo, d := now.Date()
+	day := time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+	for {
+		if a.days == nil || a.days[day.Weekday()] {
+			for _, at := range a.occurrences(day) {
+				if !at.Before(now) && (day.After(a.lastDay) || day.Equal(a.lastDay) && at.After(a.lastAt)) {
+					a.armed++
+					a.next, armed = at, a.armed
+					a.pending = a.schedule.At(at, func() { a.fire(day, at, armed) })
+					return
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+}
+
+// fire sends the time at which the alarm was due on the channel (unless the
+// previous time has not been received), recording the day and occurrence at
+// which the alarm fired and re-arming the alarm for the next occurrence.
+//
+// If the alarm was re-armed (see: rearm) after the occurrence was scheduled,
+// the occurrence is ignored.
+func (a *Alarm) fire(day, at time.Time, armed int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.stopped || armed != a.armed {
+		return
+	}
+
+	select {
+	case a.c <- a.next:
+	default:
+	}
+	a.lastDay, a.lastAt = day, at
+	a.arm()
+}
+
+// occurrences returns the times at which the alarm is due on a given day (a
+// date in UTC), in order, according to the DST policy of the alarm: the times
+// at which the wall clock in the location of the alarm reads the time of day
+// of the alarm or, if the time of day does not occur on that day, the time
+// normalised by time.Date, the instant at which the clocks go forward or no
+// time at all.
+func (a *Alarm) occurrences(day time.Time) []time.Time {
+	at := time.Date(day.Year(), day.Month(), day.Day(), a.h, a.m, a.s, 0, a.loc)
+
+	if !a.isTimeOfDay(at, day) {
+		// the time of day falls in the gap when the clocks go forward, so
+		// time.Date normalised it into the zone in effect after the gap
+		switch a.policy {
+		case DSTSkip:
+			return nil
+		case DSTNextValid, DSTRunBoth:
+			start, _ := at.ZoneBounds()
+			return []time.Time{start}
+		}
+		return []time.Time{at}
+	}
+
+	// if the clocks go back around this time the time of day may occur twice;
+	// time.Date does not guarantee which is returned, so the other time is
+	// identified if it has the same wall-clock time
+	result := []time.Time{at}
+	_, before := at.Add(-12 * time.Hour).Zone()
+	_, after := at.Add(12 * time.Hour).Zone()
+	if before > after {
+		diff := time.Duration(before-after) * time.Second
+		if earlier := at.Add(-diff); a.isTimeOfDay(earlier, day) {
+			result = []time.Time{earlier, at}
+		} else if later := at.Add(diff); a.isTimeOfDay(later, day) {
+			result = append(result, later)
+		}
+	}
+
+	if a.policy != DSTRunBoth {
+		return result[:1]
+	}
+	return result
+}
+
+// isTimeOfDay returns true if the wall clock in the location of the alarm
+// reads the time of day of the alarm, on a given day, at a given time.
+func (a *Alarm) isTimeOfDay(t time.Time, day time.Time) bool {
+	return t.Hour() == a.h && t.Minute() == a.m && t.Second() == a.s && t.Day() == day.Day()
+}
@@ -0,0 +1,231 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// advanceAlarm advances a mock clock by a number of hours, one hour at a time,
+// waiting for the timer re-armed by an alarm after each hour and returning the
+// times received from the alarm.
+func advanceAlarm(clock MockClock, a *Alarm, hours int) []time.Time {
+	result := []time.Time{}
+	for range hours {
+		n := created(clock)
+		clock.AdvanceBy(time.Hour)
+		waitFor(func() bool { return created(clock) > n })
+		select {
+		case t := <-a.C:
+			result = append(result, t)
+		default:
+		}
+	}
+	return result
+}
+
+func TestNewAlarm(t *testing.T) {
+	// arrange
+	london, err := LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+	clock := NewMockClock(AtTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	// act
+	sut, err := NewAlarm(clock, "6am", london)
+	test.Error(t, err).IsNil()
+	defer sut.Stop()
+	result := advanceAlarm(clock, sut, 48)
+
+	// assert
+	test.That(t, result).Equals([]time.Time{
+		time.Date(2024, 1, 1, 6, 0, 0, 0, london),
+		time.Date(2024, 1, 2, 6, 0, 0, 0, london),
+	})
+	test.That(t, sut.Next()).Equals(time.Date(2024, 1, 3, 6, 0, 0, 0, london))
+}
+
+// Tests that an alarm fires once on a day on which the time of day does not
+// occur (clocks go forward) or occurs twice (clocks go back).
+func TestNewAlarm_DaylightSaving(t *testing.T) {
+	london, err := LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+
+	testcases := []struct {
+		scenario string
+		start    time.Time
+		result   []time.Time
+		next     time.Time
+	}{
+		{scenario: "clocks go forward",
+			start:  time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			result: []time.Time{time.Date(2024, 3, 31, 1, 30, 0, 0, time.UTC)},
+			next:   time.Date(2024, 4, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{scenario: "clocks go back",
+			start:  time.Date(2024, 10, 26, 23, 0, 0, 0, time.UTC),
+			result: []time.Time{time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC)},
+			next:   time.Date(2024, 10, 28, 1, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(tc.start))
+			sut, err := NewAlarm(clock, "01:30", london)
+			test.Error(t, err).IsNil()
+			defer sut.Stop()
+
+			// act
+			result := advanceAlarm(clock, sut, 4)
+
+			// assert
+			test.That(t, len(result), "fired").Equals(len(tc.result))
+			for i := range result {
+				test.IsTrue(t, result[i].Equal(tc.result[i]), "fired at")
+			}
+			test.IsTrue(t, sut.Next().Equal(tc.next), "next")
+		})
+	}
+}
+
+// Tests when an alarm fires on a day on which the time of day does not occur
+// (clocks go forward) or occurs twice (clocks go back), for each DST policy.
+func TestAlarm_WithDSTPolicy(t *testing.T) {
+	london, err := LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+
+	var (
+		forward = time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+		back    = time.Date(2024, 10, 26, 23, 0, 0, 0, time.UTC)
+	)
+
+	testcases := []struct {
+		scenario string
+		policy   DSTPolicy
+		start    time.Time
+		result   []time.Time
+		next     time.Time
+	}{
+		{scenario: "DSTNormalise/clocks go forward",
+			policy: DSTNormalise,
+			start:  forward,
+			result: []time.Time{time.Date(2024, 3, 31, 1, 30, 0, 0, time.UTC)},
+			next:   time.Date(2024, 4, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{scenario: "DSTSkip/clocks go forward",
+			policy: DSTSkip,
+			start:  forward,
+			result: []time.Time{},
+			next:   time.Date(2024, 4, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{scenario: "DSTNextValid/clocks go forward",
+			policy: DSTNextValid,
+			start:  forward,
+			result: []time.Time{time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC)},
+			next:   time.Date(2024, 4, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{scenario: "DSTRunBoth/clocks go forward",
+			policy: DSTRunBoth,
+			start:  forward,
+			result: []time.Time{time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC)},
+			next:   time.Date(2024, 4, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{scenario: "DSTSkip/clocks go back",
+			policy: DSTSkip,
+			start:  back,
+			result: []time.Time{time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC)},
+			next:   time.Date(2024, 10, 28, 1, 30, 0, 0, time.UTC),
+		},
+		{scenario: "DSTNextValid/clocks go back",
+			policy: DSTNextValid,
+			start:  back,
+			result: []time.Time{time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC)},
+			next:   time.Date(2024, 10, 28, 1, 30, 0, 0, time.UTC),
+		},
+		{scenario: "DSTRunBoth/clocks go back",
+			policy: DSTRunBoth,
+			start:  back,
+			result: []time.Time{
+				time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC),
+				time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC),
+			},
+			next: time.Date(2024, 10, 28, 1, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(tc.start))
+			sut, err := NewAlarm(clock, "01:30", london)
+			test.Error(t, err).IsNil()
+			defer sut.Stop()
+
+			// act
+			sut = sut.WithDSTPolicy(tc.policy)
+			result := advanceAlarm(clock, sut, 4)
+
+			// assert
+			test.That(t, len(result), "fired").Equals(len(tc.result))
+			for i := range result {
+				test.IsTrue(t, result[i].Equal(tc.result[i]), "fired at")
+			}
+			test.IsTrue(t, sut.Next().Equal(tc.next), "next")
+		})
+	}
+}
+
+func TestAlarm_On(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))) // a Monday
+	sut, err := NewAlarm(clock, "6am", nil)
+	test.Error(t, err).IsNil()
+	defer sut.Stop()
+
+	// act
+	sut = sut.On(time.Wednesday, time.Friday)
+	result := advanceAlarm(clock, sut, 5*24)
+
+	// assert
+	test.That(t, result).Equals([]time.Time{
+		time.Date(2024, 1, 3, 6, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 5, 6, 0, 0, 0, time.UTC),
+	})
+	test.That(t, sut.Next()).Equals(time.Date(2024, 1, 10, 6, 0, 0, 0, time.UTC))
+}
+
+func TestAlarm_Stop(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut, err := NewAlarm(clock, "00:30", nil)
+	test.Error(t, err).IsNil()
+
+	// act
+	sut.Stop()
+	clock.AdvanceBy(time.Hour)
+
+	// assert
+	test.That(t, sut.Next()).Equals(time.Time{})
+	select {
+	case <-sut.C:
+		t.Error("alarm fired after being stopped")
+	default:
+	}
+}
+
+func TestDSTPolicy_String(t *testing.T) {
+	test.That(t, DSTNormalise.String()).Equals("DSTNormalise")
+	test.That(t, DSTSkip.String()).Equals("DSTSkip")
+	test.That(t, DSTNextValid.String()).Equals("DSTNextValid")
+	test.That(t, DSTRunBoth.String()).Equals("DSTRunBoth")
+	test.That(t, DSTPolicy(99).String()).Equals("<invalid DSTPolicy(99)>")
+}
+
+func TestNewAlarm_InvalidTimeOfDay(t *testing.T) {
+	// act
+	result, err := NewAlarm(nil, "25:00", nil)
+
+	// assert
+	test.Error(t, err).Is(ErrInvalidTimeOfDay)
+	test.IsTrue(t, result == nil)
+}
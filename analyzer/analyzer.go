@@ -0,0 +1,98 @@
+// Package analyzer provides a go/analysis Analyzer reporting direct use of
+// clock-dependent functions of the standard library time and context packages
+// in packages that import github.com/blugnu/time.
+//
+// Mixing use of the standard library clock with a Clock provided by
+// github.com/blugnu/time results in unpredictable behaviour in tests using a
+// mock clock; the analyzer identifies such use, suggesting the clock-based
+// equivalent.
+//
+// The analyzer may be run using go vet:
+//
+//	go install github.com/blugnu/time/analyzer/cmd/clockcheck@latest
+//	go vet -vettool=$(which clockcheck) ./...
+//
+// or added to any driver supporting go/analysis analyzers.
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// blugnuTime is the import path of the package providing clock-aware
+// alternatives to the standard library functions reported by the analyzer.
+const blugnuTime = "github.com/blugnu/time"
+
+// Analyzer reports calls to clock-dependent functions of the standard library
+// time and context packages in packages that import github.com/blugnu/time.
+var Analyzer = &analysis.Analyzer{
+	Name:     "clockcheck",
+	Doc:      "reports direct use of the standard library clock in packages using github.com/blugnu/time",
+	URL:      "https://pkg.go.dev/github.com/blugnu/time/analyzer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// replacements identifies the functions reported by the analyzer, mapping
+// the package path and name of each function to the suggested alternative.
+var replacements = map[string]map[string]string{
+	"time": {
+		"After":     "Clock.After",
+		"AfterFunc": "time.AfterFunc(ctx, ...) or Clock.AfterFunc",
+		"NewTicker": "time.NewTicker(ctx, ...) or Clock.NewTicker",
+		"NewTimer":  "time.NewTimer(ctx, ...) or Clock.NewTimer",
+		"Now":       "time.Now(ctx) or Clock.Now",
+		"Since":     "Clock.Since",
+		"Sleep":     "time.Sleep(ctx, ...) or Clock.Sleep",
+		"Tick":      "time.Tick(ctx, ...) or Clock.Tick",
+		"Until":     "Clock.Until",
+	},
+	"context": {
+		"WithDeadline":      "time.ContextWithDeadline",
+		"WithDeadlineCause": "time.ContextWithDeadlineCause",
+		"WithTimeout":       "time.ContextWithTimeout",
+		"WithTimeoutCause":  "time.ContextWithTimeoutCause",
+	},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !importsBlugnuTime(pass.Pkg) {
+		return nil, nil
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+
+		// only calls to package-level functions are of interest; methods
+		// such as time.Time.After are ignored
+		fn := typeutil.StaticCallee(pass.TypesInfo, call)
+		if fn == nil || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+			return
+		}
+
+		if alt, ok := replacements[fn.Pkg().Path()][fn.Name()]; ok {
+			pass.Reportf(call.Pos(), "%s.%s uses the system clock: use %s from %s",
+				fn.Pkg().Name(), fn.Name(), alt, strconv.Quote(blugnuTime))
+		}
+	})
+
+	return nil, nil
+}
+
+// importsBlugnuTime returns true if the given package imports github.com/blugnu/time.
+func importsBlugnuTime(pkg *types.Package) bool {
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == blugnuTime {
+			return true
+		}
+	}
+	return false
+}
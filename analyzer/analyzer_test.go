@@ -0,0 +1,20 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/blugnu/time/analyzer"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// Tests that the analyzer reports use of the standard library clock in a
+// package importing github.com/blugnu/time.
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.Analyzer, "a")
+}
+
+// Tests that the analyzer does not report use of the standard library clock
+// in a package that does not import github.com/blugnu/time.
+func TestAnalyzer_NotImported(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.Analyzer, "b")
+}
@@ -0,0 +1,16 @@
+// Command clockcheck runs the clockcheck analyzer, reporting direct use of the
+// standard library clock in packages that import github.com/blugnu/time.
+//
+// It may be used stand-alone or with go vet:
+//
+//	go vet -vettool=$(which clockcheck) ./...
+package main
+
+import (
+	"github.com/blugnu/time/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,26 @@
+package a
+
+import (
+	"context"
+	stdtime "time"
+
+	"github.com/blugnu/time"
+)
+
+func uses(ctx context.Context, clock time.Clock) {
+	_ = stdtime.Now()                                // want `time.Now uses the system clock: use time.Now\(ctx\) or Clock.Now from "github.com/blugnu/time"`
+	stdtime.Sleep(stdtime.Second)                    // want `time.Sleep uses the system clock`
+	<-stdtime.After(stdtime.Second)                  // want `time.After uses the system clock`
+	_ = stdtime.Since(stdtime.Time{})                // want `time.Since uses the system clock`
+	_, _ = context.WithTimeout(ctx, stdtime.Second)  // want `context.WithTimeout uses the system clock: use time.ContextWithTimeout`
+	_, _ = context.WithDeadline(ctx, stdtime.Time{}) // want `context.WithDeadline uses the system clock`
+
+	// clock-independent functions and methods are not reported
+	_ = stdtime.Unix(0, 0).After(stdtime.Time{})
+	_ = stdtime.Duration(0).String()
+	_, _ = context.WithCancel(ctx)
+
+	// clock-aware alternatives are not reported
+	_ = time.Now(ctx)
+	_ = clock.Now()
+}
@@ -0,0 +1,12 @@
+// Package b does not import github.com/blugnu/time and so is not reported.
+package b
+
+import (
+	"context"
+	"time"
+)
+
+func uses(ctx context.Context) {
+	_ = time.Now()
+	_, _ = context.WithTimeout(ctx, time.Second)
+}
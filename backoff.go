@@ -0,0 +1,179 @@
+package time
+
+import (
+	"context"
+	"iter"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff is a policy determining the delay between successive attempts of
+// an operation, increasing exponentially from an initial delay up to an
+// optional maximum.
+//
+// A Backoff may also honour a delay requested by a server using a Retry-After
+// header (see DelayWithRetryAfter).
+//
+// The zero value is a policy with no delay.
+type Backoff struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+
+	// Max is the maximum delay computed by the policy.  If zero, the delay
+	// is not limited.
+	Max time.Duration
+
+	// Multiplier is the factor by which the delay increases with each
+	// attempt.  If less than 1, a multiplier of 2 is used.
+	Multiplier float64
+
+	// Jitter is the fraction (from 0 to 1) of each delay that is randomised;
+	// a delay d with jitter j is chosen at random from the range d*(1-j) to d.
+	// Jitter spreads the retries of many clients failing at the same time.
+	Jitter float64
+
+	// MaxRetryAfter is the maximum delay that will be honoured when requested
+	// by a server.  If zero, a requested delay is not limited; this is not
+	// recommended unless the server is trusted.
+	MaxRetryAfter time.Duration
+}
+
+// Delay returns the delay to be observed before a given retry attempt, where
+// the first retry is attempt 0.
+func (b Backoff) Delay(attempt int) time.Duration {
+	if b.Initial <= 0 {
+		return 0
+	}
+
+	mul := b.Multiplier
+	if mul < 1 {
+		mul = 2
+	}
+
+	// the delay is limited to the maximum duration before jitter is applied,
+	// since the delay of an extreme attempt may be infinite
+	d := float64(b.Initial) * math.Pow(mul, float64(max(attempt, 0)))
+	if b.Max > 0 && d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	d = min(d, math.MaxInt64)
+	if j := min(max(b.Jitter, 0), 1); j > 0 {
+		d -= d * j * rand.Float64()
+	}
+	if d >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
+
+// DelayWithRetryAfter returns the delay to be observed before a given retry
+// attempt, honouring any delay requested by a server in a Retry-After header.
+//
+// If the header specifies a valid delay (or date, resolved against the clock)
+// the greater of the requested delay (limited by MaxRetryAfter) and the delay
+// of the policy is returned.  If the header is empty or invalid, the delay of
+// the policy is returned.
+func (b Backoff) DelayWithRetryAfter(clock Clock, attempt int, header string) time.Duration {
+	d := b.Delay(attempt)
+
+	requested, err := ParseRetryAfter(header, clock)
+	if err != nil {
+		return d
+	}
+	if b.MaxRetryAfter > 0 {
+		requested = min(requested, b.MaxRetryAfter)
+	}
+	return max(d, requested)
+}
+
+// BackoffDelays returns an iterator yielding the delays of a policy for each
+// successive retry attempt, starting with the first (attempt 0).
+//
+// The sequence is unbounded; a retry loop must stop iterating when the
+// operation succeeds or is abandoned:
+//
+//	for d := range time.BackoffDelays(policy) {
+//		if err = op(); err == nil {
+//			break
+//		}
+//		clock.Sleep(d)
+//	}
+func BackoffDelays(policy Backoff) iter.Seq[time.Duration] {
+	return func(yield func(time.Duration) bool) {
+		for attempt := 0; ; attempt++ {
+			if !yield(policy.Delay(attempt)) {
+				return
+			}
+		}
+	}
+}
+
+// BackoffTimer waits for the successive delays of a Backoff policy using a
+// clock, so that a retry loop may be tested using a mock clock.
+type BackoffTimer struct {
+	clock  Clock
+	policy Backoff
+}
+
+// NewBackoffTimer returns a BackoffTimer waiting for the delays of a policy
+// using a given clock.  If the clock is nil, the system clock is used.
+func NewBackoffTimer(clock Clock, policy Backoff) *BackoffTimer {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &BackoffTimer{clock: clock, policy: policy}
+}
+
+// Delays returns an iterator yielding for each successive attempt of an
+// operation: immediately for the first attempt and, for each retry, once the
+// delay of the policy for that retry has elapsed.  The value yielded is the
+// delay that was waited for (zero for the first attempt):
+//
+//	for range timer.Delays(ctx) {
+//		if err = op(ctx); err == nil {
+//			break
+//		}
+//	}
+//
+// The sequence ends when the context is done or if a delay would not end
+// before the deadline (if any) of the context, since a retry at (or beyond)
+// the deadline cannot succeed; the loop above then ends without waiting for
+// the deadline and the caller should return the error of the last attempt.
+func (b *BackoffTimer) Delays(ctx context.Context) iter.Seq[time.Duration] {
+	return func(yield func(time.Duration) bool) {
+		deadline, hasDeadline := ctx.Deadline()
+
+		// the first attempt is made without delay
+		if ctx.Err() != nil || !yield(0) {
+			return
+		}
+
+		for attempt := 0; ctx.Err() == nil; attempt++ {
+			d := b.policy.Delay(attempt)
+			if hasDeadline && d >= b.clock.Until(deadline) {
+				return
+			}
+			if d > 0 && !b.wait(ctx, d) {
+				return
+			}
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// wait waits for a given duration on the clock, returning true when the
+// duration has elapsed or false if the context is done first.
+func (b *BackoffTimer) wait(ctx context.Context, d time.Duration) bool {
+	timer := b.clock.NewTimerNamed(d, "Backoff")
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,179 @@
+package time
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      Backoff
+		attempt  int
+		want     time.Duration
+	}{
+		{scenario: "zero value", sut: Backoff{}, attempt: 3, want: 0},
+		{scenario: "first attempt", sut: Backoff{Initial: time.Second}, attempt: 0, want: time.Second},
+		{scenario: "default multiplier", sut: Backoff{Initial: time.Second}, attempt: 3, want: 8 * time.Second},
+		{scenario: "multiplier", sut: Backoff{Initial: time.Second, Multiplier: 1.5}, attempt: 2, want: 2250 * time.Millisecond},
+		{scenario: "capped", sut: Backoff{Initial: time.Second, Max: 5 * time.Second}, attempt: 3, want: 5 * time.Second},
+		{scenario: "negative attempt", sut: Backoff{Initial: time.Second}, attempt: -1, want: time.Second},
+		{scenario: "overflow", sut: Backoff{Initial: time.Second}, attempt: 1000, want: time.Duration(1<<63 - 1)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := tc.sut.Delay(tc.attempt)
+
+			// assert
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestBackoff_Delay_Jitter(t *testing.T) {
+	// arrange
+	sut := Backoff{Initial: time.Second, Jitter: 0.5}
+
+	for range 100 {
+		// act
+		result := sut.Delay(1)
+
+		// assert
+		test.IsTrue(t, result > time.Second && result <= 2*time.Second)
+	}
+}
+
+// Tests that the delay of an extreme attempt with jitter is a valid duration
+// (the delay before jitter is applied is infinite).
+func TestBackoff_Delay_JitterOverflow(t *testing.T) {
+	// arrange
+	sut := Backoff{Initial: time.Second, Jitter: 1}
+
+	for range 100 {
+		// act
+		result := sut.Delay(math.MaxInt32)
+
+		// assert
+		test.IsTrue(t, result >= 0, "delay is not negative")
+	}
+}
+
+func TestBackoff_DelayWithRetryAfter(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	testcases := []struct {
+		scenario string
+		sut      Backoff
+		header   string
+		want     time.Duration
+	}{
+		{scenario: "no header", sut: Backoff{Initial: time.Second}, header: "", want: time.Second},
+		{scenario: "invalid header", sut: Backoff{Initial: time.Second}, header: "soon", want: time.Second},
+		{scenario: "longer requested delay", sut: Backoff{Initial: time.Second}, header: "10", want: 10 * time.Second},
+		{scenario: "shorter requested delay", sut: Backoff{Initial: time.Second}, header: "0", want: time.Second},
+		{scenario: "requested delay capped",
+			sut:    Backoff{Initial: time.Second, MaxRetryAfter: 5 * time.Second},
+			header: "3600",
+			want:   5 * time.Second,
+		},
+		{scenario: "requested date",
+			sut:    Backoff{Initial: time.Second},
+			header: FormatHTTPDate(clock.Now().Add(time.Minute)),
+			want:   time.Minute,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := tc.sut.DelayWithRetryAfter(clock, 0, tc.header)
+
+			// assert
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestBackoffDelays(t *testing.T) {
+	// arrange
+	sut := Backoff{Initial: time.Second, Max: 5 * time.Second}
+
+	// act
+	result := []time.Duration{}
+	for d := range BackoffDelays(sut) {
+		if result = append(result, d); len(result) == 5 {
+			break
+		}
+	}
+
+	// assert
+	test.That(t, result).Equals([]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second})
+}
+
+// Tests that the first attempt of a BackoffTimer is made immediately, that the
+// delays of each retry are waited for on the clock and that the sequence ends
+// when a delay would not end before the deadline.
+func TestBackoffTimer_Delays(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	ctx, cancel := clock.ContextWithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sut := NewBackoffTimer(clock, Backoff{Initial: time.Second})
+
+	// act
+	delays := make(chan time.Duration)
+	go func() {
+		defer close(delays)
+		for d := range sut.Delays(ctx) {
+			delays <- d
+		}
+	}()
+
+	// assert
+	test.That(t, <-delays).Equals(time.Duration(0))
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		waitFor(func() bool { return created(clock) > i+1 })
+		clock.AdvanceBy(want)
+		test.That(t, <-delays).Equals(want)
+	}
+	_, ok := <-delays
+	test.IsFalse(t, ok)
+	test.That(t, clock.Now()).Equals(time.Unix(7, 0).UTC())
+}
+
+// Tests that the sequence of a BackoffTimer ends when the context is done.
+func TestBackoffTimer_Delays_ContextDone(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	ctx, cancel := context.WithCancel(context.Background())
+	sut := NewBackoffTimer(clock, Backoff{Initial: time.Second})
+
+	// act
+	delays := make(chan time.Duration)
+	go func() {
+		defer close(delays)
+		for d := range sut.Delays(ctx) {
+			delays <- d
+		}
+	}()
+	test.That(t, <-delays).Equals(time.Duration(0))
+	waitFor(func() bool { return created(clock) > 0 })
+	cancel()
+
+	// assert
+	_, ok := <-delays
+	test.IsFalse(t, ok)
+}
+
+func TestNewBackoffTimer_NilClock(t *testing.T) {
+	// act
+	result := NewBackoffTimer(nil, Backoff{})
+
+	// assert
+	test.That(t, result.clock).Equals(SystemClock())
+}
@@ -30,6 +30,29 @@ const (
 	Second      = time.Second
 	Week        = time.Hour * 24 * 7
 
+	// months
+	January   = time.January
+	February  = time.February
+	March     = time.March
+	April     = time.April
+	May       = time.May
+	June      = time.June
+	July      = time.July
+	August    = time.August
+	September = time.September
+	October   = time.October
+	November  = time.November
+	December  = time.December
+
+	// weekdays
+	Sunday    = time.Sunday
+	Monday    = time.Monday
+	Tuesday   = time.Tuesday
+	Wednesday = time.Wednesday
+	Thursday  = time.Thursday
+	Friday    = time.Friday
+	Saturday  = time.Saturday
+
 	// date/time formats
 	Layout = time.Layout // The reference time, in numerical order
 
@@ -55,13 +78,24 @@ const (
 
 var (
 	// the following functions are aliases for the corresponding functions in the standard time package
-	Date            = time.Date
-	Parse           = time.Parse
-	ParseDuration   = time.ParseDuration
-	ParseInLocation = time.ParseInLocation
-	Unix            = time.Unix
-	UnixMicro       = time.UnixMicro
-	UnixMilli       = time.UnixMilli
+	Date                   = time.Date
+	FixedZone              = time.FixedZone
+	LoadLocation           = time.LoadLocation
+	LoadLocationFromTZData = time.LoadLocationFromTZData
+	Parse                  = time.Parse
+	ParseDuration          = time.ParseDuration
+	ParseInLocation        = time.ParseInLocation
+	Unix                   = time.Unix
+	UnixMicro              = time.UnixMicro
+	UnixMilli              = time.UnixMilli
+
+	// locations
+	//
+	// Local is initialised with the value of time.Local; if time.Local is
+	// subsequently modified, Local will continue to refer to the original
+	// location.  Local is not affected by WithTZ.
+	Local = time.Local
+	UTC   = time.UTC
 
 	// the following functions have no direct equivalent in this package; equivalent functions
 	// are provided as methods of a Clock implementation.  Package-level functions are provided
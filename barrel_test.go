@@ -0,0 +1,29 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that locations and location functions are aliases of those in the
+// standard library.
+func TestBarrel_Locations(t *testing.T) {
+	// act
+	loc := FixedZone("UTC+1", 3600)
+	london, err := LoadLocation("Europe/London")
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, london.String()).Equals("Europe/London")
+	test.That(t, Date(2024, January, 1, 0, 0, 0, 0, loc).Sub(Date(2024, January, 1, 0, 0, 0, 0, UTC))).Equals(-Hour)
+	test.IsTrue(t, UTC == time.UTC)
+	test.IsTrue(t, Local == time.Local)
+}
+
+// Tests that month and weekday constants are aliases of those in the standard library.
+func TestBarrel_MonthsAndWeekdays(t *testing.T) {
+	test.That(t, December).Equals(time.December)
+	test.That(t, Date(2024, April, 10, 0, 0, 0, 0, UTC).Weekday()).Equals(Wednesday)
+}
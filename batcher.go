@@ -0,0 +1,138 @@
+package time
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Batcher accumulates items and passes them in batches to a flush function,
+// flushing a batch when it reaches a maximum size or when a maximum delay
+// has elapsed since the first item in the batch was added (as determined by
+// a timer of a clock), whichever is first.
+//
+// Batches are flushed in the order in which they were accumulated; only one
+// call to the flush function is made at any time.  A batch flushed because
+// it reached the maximum size is flushed on the goroutine adding the item
+// that completed the batch; a batch flushed because of the delay is flushed
+// on the goroutine of the timer.  The flush function must not add items to
+// the batcher.
+//
+// A Batcher must be closed when no longer required, flushing any remaining
+// items.
+type Batcher[T any] struct {
+	clock    Clock
+	size     int
+	delay    time.Duration
+	flush    func([]T)
+	mu       sync.Mutex
+	flushing sync.Mutex
+	items    []T
+	timer    *Timer
+	batch    int
+	closed   bool
+}
+
+// NewBatcher returns a Batcher using a given clock (or the system clock, if
+// nil) that calls a function with each batch of up to size items, flushing a
+// batch no later than delay after the first item in it was added.
+//
+// The size and delay must be greater than zero; otherwise NewBatcher will
+// panic.
+func NewBatcher[T any](clock Clock, size int, delay time.Duration, flush func(batch []T)) *Batcher[T] {
+	if size <= 0 || delay <= 0 {
+		panic(fmt.Errorf("%w for NewBatcher: size %d, delay %s", errNonPositiveInterval, size, delay))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &Batcher[T]{clock: clock, size: size, delay: delay, flush: flush}
+}
+
+// Add adds an item to the current batch, flushing the batch if it has reached
+// the maximum size.  It returns ErrBatcherClosed if the batcher is closed.
+func (b *Batcher[T]) Add(item T) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+
+	b.items = append(b.items, item)
+	switch len(b.items) {
+	case b.size:
+		b.flushLocked()
+		return nil
+	case 1:
+		batch := b.batch
+		b.timer = b.clock.AfterFunc(b.delay, func() { b.expired(batch) })
+	}
+	b.mu.Unlock()
+	return nil
+}
+
+// Flush flushes the current batch immediately, if not empty.
+func (b *Batcher[T]) Flush() {
+	b.mu.Lock()
+	b.flushLocked()
+}
+
+// Close flushes any remaining items and closes the batcher; any subsequent
+// items added are rejected.  Close returns when the final batch (and any
+// batch being flushed) has been flushed.
+func (b *Batcher[T]) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	b.flushLocked()
+
+	// wait for any batch being flushed by a timer
+	b.flushing.Lock()
+	defer b.flushing.Unlock()
+	return nil
+}
+
+// Len returns the number of items in the current batch.
+func (b *Batcher[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.items)
+}
+
+// expired flushes a batch when its delay has elapsed, if it has not already
+// been flushed.
+func (b *Batcher[T]) expired(batch int) {
+	b.mu.Lock()
+	if b.batch != batch {
+		b.mu.Unlock()
+		return
+	}
+	b.flushLocked()
+}
+
+// flushLocked takes the current batch (if not empty) and flushes it, unlocking
+// the batcher before calling the flush function.  The flush lock is obtained
+// before the batcher is unlocked so that batches are flushed in order.
+//
+// This method must be called while the batcher is locked; the batcher is
+// unlocked when it returns.
+func (b *Batcher[T]) flushLocked() {
+	if len(b.items) == 0 {
+		b.mu.Unlock()
+		return
+	}
+
+	items := b.items
+	b.items = nil
+	b.batch++
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	b.flushing.Lock()
+	defer b.flushing.Unlock()
+	b.mu.Unlock()
+
+	b.flush(items)
+}
@@ -0,0 +1,94 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a batch is flushed when it reaches the maximum size.
+func TestBatcher_Size(t *testing.T) {
+	// arrange
+	var (
+		batches [][]int
+		sut     = NewBatcher(NewMockClock(), 2, time.Second, func(b []int) { batches = append(batches, b) })
+	)
+
+	// act
+	for i := range 5 {
+		test.Error(t, sut.Add(i)).IsNil()
+	}
+
+	// assert
+	test.That(t, batches).Equals([][]int{{0, 1}, {2, 3}})
+	test.That(t, sut.Len()).Equals(1)
+}
+
+// Tests that a batch is flushed when the delay has elapsed since the first
+// item was added.
+func TestBatcher_Delay(t *testing.T) {
+	// arrange
+	var (
+		clock   = NewMockClock()
+		flushed = make(chan []int, 1)
+		sut     = NewBatcher(clock, 10, time.Second, func(b []int) { flushed <- b })
+	)
+	_ = sut.Add(1)
+	clock.AdvanceBy(500 * time.Millisecond)
+	_ = sut.Add(2)
+
+	// act
+	clock.AdvanceBy(499 * time.Millisecond)
+	early := len(flushed)
+	clock.AdvanceBy(time.Millisecond)
+	result := <-flushed
+
+	// assert
+	test.That(t, early).Equals(0)
+	test.That(t, result).Equals([]int{1, 2})
+}
+
+// Tests that the delay of a batch flushed because of its size does not flush
+// the next batch.
+func TestBatcher_DelayAfterSizeFlush(t *testing.T) {
+	// arrange
+	var (
+		clock   = NewMockClock()
+		flushed = make(chan []int, 2)
+		sut     = NewBatcher(clock, 2, time.Second, func(b []int) { flushed <- b })
+	)
+	_ = sut.Add(1)
+	_ = sut.Add(2)
+	<-flushed
+	clock.AdvanceBy(500 * time.Millisecond)
+	_ = sut.Add(3)
+
+	// act
+	clock.AdvanceBy(500 * time.Millisecond)
+	early := len(flushed)
+	clock.AdvanceBy(500 * time.Millisecond)
+	result := <-flushed
+
+	// assert
+	test.That(t, early).Equals(0)
+	test.That(t, result).Equals([]int{3})
+}
+
+// Tests that closing a batcher flushes remaining items and rejects further items.
+func TestBatcher_Close(t *testing.T) {
+	// arrange
+	var (
+		batches [][]int
+		sut     = NewBatcher(NewMockClock(), 10, time.Second, func(b []int) { batches = append(batches, b) })
+	)
+	_ = sut.Add(1)
+
+	// act
+	err := sut.Close()
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, batches).Equals([][]int{{1}})
+	test.Error(t, sut.Add(2)).Is(ErrBatcherClosed)
+}
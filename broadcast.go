@@ -0,0 +1,208 @@
+package time
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Broadcast reads the times sent on a single channel, such as the channel of
+// a Ticker or Timer, and sends each time to any number of subscribers, so that
+// multiple consumers do not each need their own ticker.
+//
+// Each subscriber has its own channel, with a TickerPolicy determining what
+// happens when a time is sent while a previous time has not been received
+// from that channel, so that a slow subscriber does not delay the others
+// (unless BlockWhenFull applies).  Since the channel is read as any other,
+// a Broadcast may be used with a ticker of any clock, including a mock clock.
+type Broadcast struct {
+	mu   sync.Mutex
+	subs []*Subscription
+	done chan struct{}
+	once sync.Once
+}
+
+// Subscription is a subscription to a Broadcast, on the channel of which each
+// time sent by the broadcast is received.
+type Subscription struct {
+	// C is the channel on which the times are received.  The channel is
+	// buffered for one time; what happens when a time cannot be buffered
+	// is determined by the policy of the subscription.
+	C <-chan time.Time
+
+	c      chan time.Time
+	b      *Broadcast
+	policy TickerPolicy
+	done   chan struct{}
+	once   sync.Once
+
+	// queue holds the times that could not be buffered by the channel, sent
+	// by the goroutine of the subscription (signalled by ready)
+	mu    sync.Mutex
+	queue []time.Time
+	ready chan struct{}
+
+	// dropped is the number of times dropped since the count was last reset
+	// by Dropped (accessed atomically)
+	dropped int32
+}
+
+// NewBroadcast returns a Broadcast of the times sent on a given channel,
+// which is read until the channel is closed or the broadcast is stopped.
+func NewBroadcast(c <-chan time.Time) *Broadcast {
+	b := &Broadcast{done: make(chan struct{})}
+	go func() {
+		for {
+			select {
+			case t, ok := <-c:
+				if !ok {
+					return
+				}
+				b.send(t)
+			case <-b.done:
+				return
+			}
+		}
+	}()
+	return b
+}
+
+// Subscribe returns a new subscription to the broadcast, with a policy that
+// determines what happens when a time is sent while a previous time has not
+// been received from the channel of the subscription (see: TickerPolicy).
+// With BlockWhenFull, the broadcast to all subscribers is blocked until the
+// time is received.
+func (b *Broadcast) Subscribe(p TickerPolicy) *Subscription {
+	c := make(chan time.Time, 1)
+	s := &Subscription{
+		C:      c,
+		c:      c,
+		b:      b,
+		policy: p,
+		done:   make(chan struct{}),
+		ready:  make(chan struct{}, 1),
+	}
+	if !p.blocks {
+		go s.deliver()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subs = append(b.subs, s)
+	return s
+}
+
+// Stop stops the broadcast; the channel of the broadcast is no longer read and
+// no further times are sent to subscribers.  The channels of the subscribers
+// are not closed and the source of the channel (such as a Ticker) is not
+// stopped.
+func (b *Broadcast) Stop() {
+	b.once.Do(func() { close(b.done) })
+}
+
+// send sends a time to each subscriber, in the order in which they subscribed.
+func (b *Broadcast) send(t time.Time) {
+	b.mu.Lock()
+	subs := slices.Clone(b.subs)
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.send(t)
+	}
+}
+
+// Dropped returns the number of times that have been dropped since Dropped
+// was last called (or since the subscription was created), and resets the
+// count.  Times are dropped only with a policy that limits the number of
+// times that may be queued.
+func (s *Subscription) Dropped() int {
+	return int(atomic.SwapInt32(&s.dropped, 0))
+}
+
+// Unsubscribe ends the subscription; no further times are sent to the channel
+// of the subscription.  The channel is not closed.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.b.mu.Lock()
+		defer s.b.mu.Unlock()
+
+		s.b.subs = slices.DeleteFunc(s.b.subs, func(sub *Subscription) bool { return sub == s })
+	})
+}
+
+// send sends a time to the subscriber, according to its policy.
+func (s *Subscription) send(t time.Time) {
+	if s.policy.blocks {
+		select {
+		case s.c <- t:
+		case <-s.done:
+		case <-s.b.done:
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the time is buffered by the channel only if no times are queued,
+	// so that times are received in order
+	if len(s.queue) == 0 {
+		select {
+		case s.c <- t:
+			return
+		default:
+		}
+	}
+
+	if s.policy.bounded && len(s.queue) >= int(s.policy.limit) {
+		atomic.AddInt32(&s.dropped, 1)
+		return
+	}
+	s.queue = append(s.queue, t)
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the queued times of the subscriber, in order, until the
+// subscription ends or the broadcast is stopped.  A time remains queued
+// until it has been sent.
+func (s *Subscription) deliver() {
+	for {
+		select {
+		case <-s.ready:
+		case <-s.done:
+			return
+		case <-s.b.done:
+			return
+		}
+
+		for {
+			s.mu.Lock()
+			if len(s.queue) == 0 {
+				s.mu.Unlock()
+				break
+			}
+			t := s.queue[0]
+			s.mu.Unlock()
+
+			select {
+			case s.c <- t:
+			case <-s.done:
+				return
+			case <-s.b.done:
+				return
+			}
+
+			s.mu.Lock()
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// receive receives n times from a channel.
+func receive(c <-chan time.Time, n int) []time.Time {
+	result := make([]time.Time, n)
+	for i := range result {
+		result[i] = <-c
+	}
+	return result
+}
+
+// Tests that each tick of a mock ticker is sent to each subscriber, according
+// to the policy of each subscription.
+func TestBroadcast(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	sut := NewBroadcast(ticker.C)
+	defer sut.Stop()
+	drops := sut.Subscribe(DropWhenFull)
+	queues := sut.Subscribe(QueueWhenFull(1))
+	all := sut.Subscribe(TickerPolicy{})
+
+	// act
+	clock.AdvanceBy(3 * time.Second)
+	ticks := receive(all.C, 3)
+
+	// assert
+	test.That(t, ticks).Equals([]time.Time{
+		time.Unix(1, 0).UTC(),
+		time.Unix(2, 0).UTC(),
+		time.Unix(3, 0).UTC(),
+	})
+	test.That(t, receive(drops.C, 1)).Equals(ticks[:1])
+	test.That(t, drops.Dropped()).Equals(2)
+	test.That(t, receive(queues.C, 2)).Equals(ticks[:2])
+	test.That(t, queues.Dropped()).Equals(1)
+	test.That(t, all.Dropped()).Equals(0)
+}
+
+// Tests that a subscriber with the BlockWhenFull policy receives every tick.
+func TestBroadcast_BlockWhenFull(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	sut := NewBroadcast(ticker.C)
+	defer sut.Stop()
+	sub := sut.Subscribe(BlockWhenFull)
+
+	// act
+	clock.AdvanceBy(3 * time.Second)
+	result := receive(sub.C, 3)
+
+	// assert
+	test.That(t, result[2]).Equals(time.Unix(3, 0).UTC())
+}
+
+// Tests that no further ticks are sent to a subscriber that has unsubscribed.
+func TestSubscription_Unsubscribe(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	sut := NewBroadcast(ticker.C)
+	defer sut.Stop()
+	gone := sut.Subscribe(TickerPolicy{})
+	other := sut.Subscribe(TickerPolicy{})
+
+	// act
+	gone.Unsubscribe()
+	clock.AdvanceBy(time.Second)
+	_ = receive(other.C, 1)
+
+	// assert
+	select {
+	case <-gone.C:
+		t.Error("tick received after unsubscribing")
+	default:
+	}
+}
+
+// Tests that the ticks of a system ticker are sent to each subscriber.
+func TestBroadcast_SystemTicker(t *testing.T) {
+	// arrange
+	ticker := SystemClock().NewTicker(time.Millisecond)
+	defer ticker.Stop()
+
+	sut := NewBroadcast(ticker.C)
+	defer sut.Stop()
+	a := sut.Subscribe(DropWhenFull)
+	b := sut.Subscribe(DropWhenFull)
+
+	// act
+	ta, tb := <-a.C, <-b.C
+
+	// assert
+	test.IsFalse(t, ta.IsZero())
+	test.IsFalse(t, tb.IsZero())
+}
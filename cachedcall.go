@@ -0,0 +1,153 @@
+package time
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CachedCall caches the result of a function for a time-to-live (TTL)
+// according to a clock, deduplicating concurrent calls: while a call to the
+// function is in flight, other callers wait for (and share) its result rather
+// than making calls of their own.
+//
+// Once the TTL of a cached result has elapsed the result may optionally
+// continue to be served for a further window while it is refreshed in the
+// background ("stale-while-revalidate").
+//
+// Since the expiry of a result is determined by the clock, cache refresh
+// behaviour may be verified by advancing a mock clock.
+//
+// A CachedCall must not be copied after first use.
+type CachedCall[T any] struct {
+	// Clock is the clock determining the expiry of cached results.  If nil,
+	// the system clock is used.
+	Clock Clock
+
+	// TTL is the duration for which a result is cached.  If zero, results
+	// are not cached but concurrent calls are still deduplicated.
+	TTL time.Duration
+
+	// Stale is the duration after the expiry of a result for which the
+	// (stale) result continues to be returned, while a call to refresh the
+	// result is made in the background.  If zero, no stale results are
+	// returned.
+	Stale time.Duration
+
+	// Func is the function whose result is cached.  Errors are not cached.
+	Func func(context.Context) (T, error)
+
+	mu       sync.Mutex
+	cached   bool
+	value    T
+	expires  time.Time
+	inflight *Future[T]
+}
+
+// Get returns the cached result of the function if it has not expired.
+//
+// If the result has expired but remains within the stale window, the stale
+// result is returned and the function is called in the background (if it is
+// not already in flight) to refresh the result; the background call uses a
+// context that is not cancelled when the given context is cancelled.
+//
+// Otherwise the caller waits for the result of a call to the function, which
+// is made by the caller (using the given context) unless another call is
+// already in flight.  An error returned by the function is returned to all
+// callers waiting for its result.
+func (c *CachedCall[T]) Get(ctx context.Context) (T, error) {
+	c.mu.Lock()
+
+	now := c.clock().Now()
+	switch {
+	case c.cached && now.Before(c.expires):
+		defer c.mu.Unlock()
+		return c.value, nil
+
+	case c.cached && now.Before(c.expires.Add(c.Stale)):
+		defer c.mu.Unlock()
+		if c.inflight == nil {
+			f := c.begin()
+			go c.call(context.WithoutCancel(ctx), f)
+		}
+		return c.value, nil
+	}
+
+	if f := c.inflight; f != nil {
+		c.mu.Unlock()
+		return f.Await(ctx)
+	}
+
+	f := c.begin()
+	c.mu.Unlock()
+
+	c.call(ctx, f)
+	return f.Await(ctx)
+}
+
+// Invalidate discards any cached result; the next call to Get will call the
+// function.  A call that is in flight is not affected.
+func (c *CachedCall[T]) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero T
+	c.cached, c.value = false, zero
+}
+
+// clock returns the clock of the CachedCall or the system clock if none is
+// specified.
+func (c *CachedCall[T]) clock() Clock {
+	if c.Clock == nil {
+		return SystemClock()
+	}
+	return c.Clock
+}
+
+// begin establishes a new in-flight call.
+//
+// This method is not thread-safe and should only be called while the
+// CachedCall is locked.
+func (c *CachedCall[T]) begin() *Future[T] {
+	c.inflight = NewFuture[T]()
+	return c.inflight
+}
+
+// call calls the function, caching a successful result and resolving the
+// in-flight call with the outcome.
+func (c *CachedCall[T]) call(ctx context.Context, f *Future[T]) {
+	v, err := c.Func(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		c.cached, c.value, c.expires = true, v, c.clock().Now().Add(c.TTL)
+	}
+	c.inflight = nil
+
+	if err != nil {
+		f.Fail(err)
+		return
+	}
+	f.Complete(v)
+}
+
+// MemoizeFor returns a function that returns the result of a given function,
+// caching the result for a TTL according to a given clock, together with a
+// function that invalidates any cached result.  Concurrent calls while the
+// result is being obtained are deduplicated (see: CachedCall).
+//
+// Since the expiry of the result is determined by the clock, refresh behaviour
+// may be verified by advancing a mock clock.
+func MemoizeFor[T any](clock Clock, ttl Duration, fn func() T) (memoized func() T, invalidate func()) {
+	c := &CachedCall[T]{
+		Clock: clock,
+		TTL:   ttl,
+		Func:  func(context.Context) (T, error) { return fn(), nil },
+	}
+	return func() T {
+		v, _ := c.Get(context.Background())
+		return v
+	}, c.Invalidate
+}
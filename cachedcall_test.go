@@ -0,0 +1,162 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// counter returns a function returning the number of times it has been called.
+func counter(calls *atomic.Int32) func(context.Context) (int, error) {
+	return func(context.Context) (int, error) {
+		return int(calls.Add(1)), nil
+	}
+}
+
+// Tests that a result is cached until its TTL has elapsed.
+func TestCachedCall_TTL(t *testing.T) {
+	// arrange
+	var (
+		calls atomic.Int32
+		clock = NewMockClock()
+		sut   = &CachedCall[int]{Clock: clock, TTL: time.Minute, Func: counter(&calls)}
+		ctx   = context.Background()
+	)
+
+	// act
+	first, _ := sut.Get(ctx)
+	clock.AdvanceBy(59 * time.Second)
+	cached, _ := sut.Get(ctx)
+	clock.AdvanceBy(time.Second)
+	refreshed, _ := sut.Get(ctx)
+
+	// assert
+	test.That(t, first).Equals(1)
+	test.That(t, cached).Equals(1)
+	test.That(t, refreshed).Equals(2)
+}
+
+// Tests that concurrent calls are deduplicated.
+func TestCachedCall_Deduplicates(t *testing.T) {
+	// arrange
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+		sut     = &CachedCall[int]{Clock: NewMockClock(), TTL: time.Minute, Func: func(context.Context) (int, error) {
+			calls.Add(1)
+			<-release
+			return 42, nil
+		}}
+		results = make(chan int, 3)
+		callers WaitFuncs
+	)
+
+	// act
+	for range 3 {
+		callers.Go(func() {
+			v, _ := sut.Get(context.Background())
+			results <- v
+		})
+	}
+	waitFor(func() bool { return calls.Load() > 0 })
+	time.Sleep(5 * time.Millisecond)
+	close(release)
+	callers.Wait()
+	close(results)
+
+	// assert
+	test.That(t, calls.Load()).Equals(int32(1))
+	for v := range results {
+		test.That(t, v).Equals(42)
+	}
+}
+
+// Tests that an expired result is served within the stale window while it is
+// refreshed in the background.
+func TestCachedCall_Stale(t *testing.T) {
+	// arrange
+	var (
+		calls atomic.Int32
+		clock = NewMockClock()
+		sut   = &CachedCall[int]{Clock: clock, TTL: time.Minute, Stale: time.Minute, Func: counter(&calls)}
+		ctx   = context.Background()
+	)
+	_, _ = sut.Get(ctx)
+
+	// act
+	clock.AdvanceBy(90 * time.Second)
+	stale, _ := sut.Get(ctx)
+	refreshed := waitFor(func() bool { v, _ := sut.Get(ctx); return v == 2 })
+
+	// assert
+	test.That(t, stale).Equals(1)
+	test.IsTrue(t, refreshed)
+	test.That(t, calls.Load()).Equals(int32(2))
+}
+
+// Tests that an error is returned and not cached.
+func TestCachedCall_Error(t *testing.T) {
+	// arrange
+	var (
+		errFailed = errors.New("failed")
+		calls     atomic.Int32
+		sut       = &CachedCall[int]{TTL: time.Minute, Func: func(context.Context) (int, error) {
+			if calls.Add(1) == 1 {
+				return 0, errFailed
+			}
+			return 42, nil
+		}}
+	)
+
+	// act
+	_, err := sut.Get(context.Background())
+	result, _ := sut.Get(context.Background())
+
+	// assert
+	test.Error(t, err).Is(errFailed)
+	test.That(t, result).Equals(42)
+}
+
+// Tests that Invalidate discards a cached result.
+func TestCachedCall_Invalidate(t *testing.T) {
+	// arrange
+	var (
+		calls atomic.Int32
+		sut   = &CachedCall[int]{Clock: NewMockClock(), TTL: time.Minute, Func: counter(&calls)}
+		ctx   = context.Background()
+	)
+	_, _ = sut.Get(ctx)
+
+	// act
+	sut.Invalidate()
+	result, _ := sut.Get(ctx)
+
+	// assert
+	test.That(t, result).Equals(2)
+}
+
+// Tests that a memoized function caches its result for a TTL and that the
+// result may be invalidated.
+func TestMemoizeFor(t *testing.T) {
+	// arrange
+	var (
+		calls atomic.Int32
+		clock = NewMockClock()
+	)
+	fn, invalidate := MemoizeFor(clock, time.Minute, func() int { return int(calls.Add(1)) })
+
+	// act
+	first := fn()
+	cached := fn()
+	clock.AdvanceBy(time.Minute)
+	expired := fn()
+	invalidate()
+	invalidated := fn()
+
+	// assert
+	test.That(t, []int{first, cached, expired, invalidated}).Equals([]int{1, 1, 2, 3})
+}
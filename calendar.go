@@ -0,0 +1,153 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MonthEndPolicy determines the result of calendar arithmetic (AddMonths and
+// AddYears) when the day of the month does not exist in the resulting month,
+// e.g. adding 1 month to 31st January.
+type MonthEndPolicy int
+
+const (
+	// ClampToMonthEnd clamps the day to the last day of the resulting month:
+	// 31st January + 1 month is 28th (or 29th) February; 29th February + 1 year
+	// is 28th February.
+	ClampToMonthEnd MonthEndPolicy = iota
+
+	// OverflowMonthEnd normalises the day into the following month, consistent
+	// with the AddDate method of time.Time: 31st January + 1 month is 3rd (or 2nd)
+	// March; 29th February + 1 year is 1st March.
+	OverflowMonthEnd
+
+	// PreserveMonthEnd preserves the last day of the month: if the day is the last
+	// day of its month the result is the last day of the resulting month;
+	// otherwise the day is clamped as for ClampToMonthEnd.  e.g. 28th February
+	// (in a non-leap year) + 1 month is 31st March.
+	PreserveMonthEnd
+)
+
+// String returns the name of the policy.
+func (p MonthEndPolicy) String() string {
+	switch p {
+	case ClampToMonthEnd:
+		return "ClampToMonthEnd"
+	case OverflowMonthEnd:
+		return "OverflowMonthEnd"
+	case PreserveMonthEnd:
+		return "PreserveMonthEnd"
+	}
+	return "<invalid MonthEndPolicy(" + strconv.Itoa(int(p)) + ")>"
+}
+
+// AddMonths returns the time t with n months added (n may be negative),
+// applying the given policy if the day of the month of t does not exist in
+// the resulting month.  The time of day and location of t are preserved.
+//
+// Any policy other than those defined by this package is treated as
+// ClampToMonthEnd.
+func AddMonths(t time.Time, n int, policy MonthEndPolicy) time.Time {
+	if policy == OverflowMonthEnd {
+		return t.AddDate(0, n, 0)
+	}
+
+	y, m, d := t.Date()
+	hh, mm, ss := t.Clock()
+
+	// the first of the month avoids any normalisation when determining the
+	// resulting year and month
+	ty, tm, _ := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC).Date()
+	last := daysIn(tm, ty)
+
+	if policy == PreserveMonthEnd && d == daysIn(m, y) {
+		d = last
+	}
+
+	return time.Date(ty, tm, min(d, last), hh, mm, ss, t.Nanosecond(), t.Location())
+}
+
+// AddYears returns the time t with n years added (n may be negative),
+// applying the given policy if t is the 29th February and the resulting
+// year is not a leap year.  The time of day and location of t are preserved.
+func AddYears(t time.Time, n int, policy MonthEndPolicy) time.Time {
+	return AddMonths(t, n*12, policy)
+}
+
+// daysIn returns the number of days in a given month of a given year.
+func daysIn(m time.Month, year int) int {
+	// day 0 of the following month is the last day of the month
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Quarter returns the quarter of the year (1-4) in which t occurs.
+func Quarter(t time.Time) int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+// StartOfQuarter returns midnight on the first day of the quarter in which
+// t occurs, in the location of t.
+func StartOfQuarter(t time.Time) time.Time {
+	m := time.Month((Quarter(t)-1)*3 + 1)
+	return time.Date(t.Year(), m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// ISOWeekday returns the ISO 8601 number of the day of the week of t, from
+// 1 (Monday) to 7 (Sunday).
+func ISOWeekday(t time.Time) int {
+	if wd := t.Weekday(); wd != time.Sunday {
+		return int(wd)
+	}
+	return 7
+}
+
+// StartOfISOWeek returns midnight on the Monday of the ISO 8601 week in which
+// t occurs, in the location of t.
+func StartOfISOWeek(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d-(ISOWeekday(t)-1), 0, 0, 0, 0, t.Location())
+}
+
+// ISOWeekRange returns the start (midnight on Monday) of a given ISO 8601 week
+// of a given ISO year and the start of the following week, in a given location.
+//
+// Week 1 of an ISO year is the week containing the 4th January.  A week outside
+// the range of weeks in the year is normalised into a preceding or following
+// year; ISOWeeksInYear returns the number of weeks in an ISO year.
+func ISOWeekRange(year, week int, loc *time.Location) (start, end time.Time) {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	start = StartOfISOWeek(jan4).AddDate(0, 0, (week-1)*7)
+	return start, start.AddDate(0, 0, 7)
+}
+
+// ISOWeeksInYear returns the number of weeks (52 or 53) in a given ISO 8601 year.
+func ISOWeeksInYear(year int) int {
+	// the 28th December is always in the last week of the ISO year
+	_, w := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return w
+}
+
+// FormatISOWeekDate returns the ISO 8601 week date of t, e.g. "2024-W15-3".
+func FormatISOWeekDate(t time.Time) string {
+	y, w := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d-%d", y, w, ISOWeekday(t))
+}
+
+// ParseISOWeekDate parses an ISO 8601 week date (e.g. "2024-W15-3") returning
+// midnight on that date in the given location.
+//
+// If the date is not a valid ISO week date, an error wrapping
+// ErrInvalidISOWeekDate is returned.
+func ParseISOWeekDate(s string, loc *time.Location) (time.Time, error) {
+	var y, w, d int
+	if n, err := fmt.Sscanf(s, "%4d-W%2d-%1d", &y, &w, &d); err != nil || n != 3 || len(s) != 10 {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidISOWeekDate, s)
+	}
+	if w < 1 || w > ISOWeeksInYear(y) || d < 1 || d > 7 {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidISOWeekDate, s)
+	}
+
+	start, _ := ISOWeekRange(y, w, loc)
+	return start.AddDate(0, 0, d-1), nil
+}
@@ -0,0 +1,150 @@
+package time
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestAddMonths(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 10, 30, 0, 0, time.UTC)
+	}
+
+	testcases := []struct {
+		scenario string
+		t        time.Time
+		n        int
+		policy   MonthEndPolicy
+		want     time.Time
+	}{
+		{scenario: "clamp/day exists", t: date(2023, 1, 15), n: 1, policy: ClampToMonthEnd, want: date(2023, 2, 15)},
+		{scenario: "clamp/end of month", t: date(2023, 1, 31), n: 1, policy: ClampToMonthEnd, want: date(2023, 2, 28)},
+		{scenario: "clamp/leap year", t: date(2024, 1, 31), n: 1, policy: ClampToMonthEnd, want: date(2024, 2, 29)},
+		{scenario: "clamp/negative", t: date(2023, 3, 31), n: -1, policy: ClampToMonthEnd, want: date(2023, 2, 28)},
+		{scenario: "clamp/across years", t: date(2023, 11, 30), n: 3, policy: ClampToMonthEnd, want: date(2024, 2, 29)},
+		{scenario: "clamp/not month end", t: date(2023, 2, 28), n: 1, policy: ClampToMonthEnd, want: date(2023, 3, 28)},
+		{scenario: "overflow/end of month", t: date(2023, 1, 31), n: 1, policy: OverflowMonthEnd, want: date(2023, 3, 3)},
+		{scenario: "preserve/month end", t: date(2023, 2, 28), n: 1, policy: PreserveMonthEnd, want: date(2023, 3, 31)},
+		{scenario: "preserve/not month end", t: date(2024, 2, 28), n: 1, policy: PreserveMonthEnd, want: date(2024, 3, 28)},
+		{scenario: "preserve/clamped", t: date(2023, 1, 30), n: 1, policy: PreserveMonthEnd, want: date(2023, 2, 28)},
+		{scenario: "invalid policy", t: date(2023, 1, 31), n: 1, policy: 99, want: date(2023, 2, 28)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.That(t, AddMonths(tc.t, tc.n, tc.policy)).Equals(tc.want)
+		})
+	}
+}
+
+func TestAddYears(t *testing.T) {
+	leapDay := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	test.That(t, AddYears(leapDay, 1, ClampToMonthEnd)).Equals(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))
+	test.That(t, AddYears(leapDay, 1, OverflowMonthEnd)).Equals(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	test.That(t, AddYears(leapDay, 4, ClampToMonthEnd)).Equals(time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC))
+	test.That(t, AddYears(leapDay, -1, PreserveMonthEnd)).Equals(time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC))
+}
+
+func TestMonthEndPolicy_String(t *testing.T) {
+	test.That(t, ClampToMonthEnd.String()).Equals("ClampToMonthEnd")
+	test.That(t, OverflowMonthEnd.String()).Equals("OverflowMonthEnd")
+	test.That(t, PreserveMonthEnd.String()).Equals("PreserveMonthEnd")
+	test.That(t, MonthEndPolicy(99).String()).Equals("<invalid MonthEndPolicy(99)>")
+}
+
+func TestQuarter(t *testing.T) {
+	for m, want := range map[time.Month]int{1: 1, 3: 1, 4: 2, 6: 2, 7: 3, 9: 3, 10: 4, 12: 4} {
+		t.Run(m.String(), func(t *testing.T) {
+			tm := time.Date(2024, m, 15, 0, 0, 0, 0, time.UTC)
+			test.That(t, Quarter(tm)).Equals(want)
+		})
+	}
+}
+
+func TestStartOfQuarter(t *testing.T) {
+	// act
+	result := StartOfQuarter(time.Date(2024, 8, 15, 10, 30, 0, 0, time.UTC))
+
+	// assert
+	test.That(t, result).Equals(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestStartOfISOWeek(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		t        time.Time
+		want     time.Time
+	}{
+		{scenario: "monday", t: time.Date(2024, 4, 8, 10, 0, 0, 0, time.UTC), want: time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC)},
+		{scenario: "wednesday", t: time.Date(2024, 4, 10, 10, 0, 0, 0, time.UTC), want: time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC)},
+		{scenario: "sunday", t: time.Date(2024, 4, 14, 23, 0, 0, 0, time.UTC), want: time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC)},
+		{scenario: "across years", t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.That(t, StartOfISOWeek(tc.t)).Equals(tc.want)
+		})
+	}
+}
+
+func TestISOWeekRange(t *testing.T) {
+	testcases := []struct {
+		year, week int
+		start      time.Time
+	}{
+		{year: 2024, week: 1, start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{year: 2024, week: 15, start: time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC)},
+		{year: 2021, week: 1, start: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{year: 2020, week: 53, start: time.Date(2020, 12, 28, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%d-W%02d", tc.year, tc.week), func(t *testing.T) {
+			start, end := ISOWeekRange(tc.year, tc.week, time.UTC)
+
+			test.That(t, start).Equals(tc.start)
+			test.That(t, end).Equals(tc.start.AddDate(0, 0, 7))
+
+			y, w := start.ISOWeek()
+			test.That(t, y).Equals(tc.year)
+			test.That(t, w).Equals(tc.week)
+		})
+	}
+}
+
+func TestISOWeeksInYear(t *testing.T) {
+	test.That(t, ISOWeeksInYear(2020)).Equals(53)
+	test.That(t, ISOWeeksInYear(2024)).Equals(52)
+	test.That(t, ISOWeeksInYear(2026)).Equals(53)
+}
+
+func TestFormatISOWeekDate(t *testing.T) {
+	test.That(t, FormatISOWeekDate(time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC))).Equals("2024-W15-3")
+	test.That(t, FormatISOWeekDate(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC))).Equals("2020-W53-7")
+}
+
+func TestParseISOWeekDate(t *testing.T) {
+	testcases := []struct {
+		s    string
+		want time.Time
+		err  error
+	}{
+		{s: "2024-W15-3", want: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)},
+		{s: "2020-W53-7", want: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{s: "2024-W53-1", err: ErrInvalidISOWeekDate},
+		{s: "2024-W00-1", err: ErrInvalidISOWeekDate},
+		{s: "2024-W15-8", err: ErrInvalidISOWeekDate},
+		{s: "2024-15-3", err: ErrInvalidISOWeekDate},
+		{s: "2024-W15-3x", err: ErrInvalidISOWeekDate},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.s, func(t *testing.T) {
+			result, err := ParseISOWeekDate(tc.s, time.UTC)
+
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package civil provides the wall-clock (civil) values Date, TimeOfDay and
+// DateTime, which identify a date and/or time as read from a calendar and
+// clock, independently of any location.
+//
+// A civil value is not an instant; it identifies an instant only when
+// interpreted in a location (using In).  This makes civil values suitable for
+// schedules, appointments and business rules expressed in local time, such as
+// "every day at 09:00" or "the last day of the month", which must be
+// evaluated in whatever location (and daylight saving period) applies:
+//
+//	today := civil.Today(clock, loc)
+//	start := today.At(civil.TimeOfDay{Hour: 9}).In(loc)
+package civil
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/blugnu/time"
+)
+
+// Date is a civil date: a year, month and day, in no particular location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of a time, in the location of the time.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// Today returns the current date of a clock (or the system clock, if nil) in
+// a location (or the location of the clock, if nil).
+func Today(clock time.Clock, loc *time.Location) Date {
+	if clock == nil {
+		clock = time.SystemClock()
+	}
+	now := clock.Now()
+	if loc != nil {
+		now = now.In(loc)
+	}
+	return DateOf(now)
+}
+
+// ParseDate parses a date in RFC 3339 full-date form (e.g. "2024-03-31").  If
+// the date is not valid an error wrapping ErrInvalidDate is returned.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("%w: %w", ErrInvalidDate, err)
+	}
+	return DateOf(t), nil
+}
+
+// String returns the date in RFC 3339 full-date form (e.g. "2024-03-31").
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsValid returns true if the date identifies a day that exists in the
+// calendar; e.g. 2023-02-29 is not valid.
+func (d Date) IsValid() bool {
+	return DateOf(d.utc()) == d
+}
+
+// In returns the time at the start of the date (midnight) in a location.  If
+// midnight does not occur on the date in the location (due to a change from
+// daylight saving time), the time is normalised as by time.Date.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// At returns the DateTime at a time of day on the date.
+func (d Date) At(t TimeOfDay) DateTime {
+	return DateTime{Date: d, TimeOfDay: t}
+}
+
+// Weekday returns the day of the week of the date.
+func (d Date) Weekday() time.Weekday {
+	return d.utc().Weekday()
+}
+
+// AddDays returns the date a number of days after the date (or before, if
+// negative).
+func (d Date) AddDays(n int) Date {
+	return d.AddDate(0, 0, n)
+}
+
+// AddDate returns the date a number of years, months and days after the date
+// (or before, if negative), normalised as by time.Time.AddDate; e.g. adding
+// one month to 2024-01-31 results in 2024-03-02.
+func (d Date) AddDate(years, months, days int) Date {
+	return DateOf(d.utc().AddDate(years, months, days))
+}
+
+// DaysSince returns the number of days from another date to the date; the
+// result is negative if the other date is later.
+func (d Date) DaysSince(other Date) int {
+	return int(d.utc().Sub(other.utc()) / time.Day)
+}
+
+// Compare compares the date with another, returning -1 if the date is
+// earlier, +1 if it is later or 0 if they are the same date.
+func (d Date) Compare(other Date) int {
+	return cmp.Or(
+		cmp.Compare(d.Year, other.Year),
+		cmp.Compare(d.Month, other.Month),
+		cmp.Compare(d.Day, other.Day),
+	)
+}
+
+// Before returns true if the date is earlier than another.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After returns true if the date is later than another.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// IsZero returns true if the date is the zero value.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the date in the
+// form returned by String.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding a date in the
+// form accepted by ParseDate.
+func (d *Date) UnmarshalText(b []byte) error {
+	var err error
+	*d, err = ParseDate(string(b))
+	return err
+}
+
+// utc returns the time at the start of the date in UTC, in which every day
+// has 24 hours, for calendar arithmetic.
+func (d Date) utc() time.Time {
+	return d.In(time.UTC)
+}
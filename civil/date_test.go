@@ -0,0 +1,123 @@
+package civil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+func TestDateOf(t *testing.T) {
+	// arrange
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	tm := time.Date(2024, 3, 30, 20, 0, 0, 0, time.UTC)
+
+	// act/assert
+	test.That(t, DateOf(tm)).Equals(Date{2024, time.March, 30})
+	test.That(t, DateOf(tm.In(loc))).Equals(Date{2024, time.March, 31})
+}
+
+func TestToday(t *testing.T) {
+	// arrange
+	loc := time.FixedZone("UTC-1", -60*60)
+	clock := time.NewMockClock(time.AtTime(time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)))
+
+	// act/assert
+	test.That(t, Today(clock, nil)).Equals(Date{2024, time.January, 1})
+	test.That(t, Today(clock, loc)).Equals(Date{2023, time.December, 31})
+	test.IsFalse(t, Today(nil, nil).IsZero())
+}
+
+func TestParseDate(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		s        string
+		result   Date
+		err      error
+	}{
+		{scenario: "valid", s: "2024-02-29", result: Date{2024, time.February, 29}},
+		{scenario: "not a leap year", s: "2023-02-29", err: ErrInvalidDate},
+		{scenario: "not a date", s: "29/02/2024", err: ErrInvalidDate},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, err := ParseDate(tc.s)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestDate(t *testing.T) {
+	// arrange
+	sut := Date{2024, time.January, 31}
+
+	// act/assert
+	test.That(t, sut.String()).Equals("2024-01-31")
+	test.That(t, sut.Weekday()).Equals(time.Wednesday)
+	test.That(t, sut.AddDays(30)).Equals(Date{2024, time.March, 1})
+	test.That(t, sut.AddDays(-31)).Equals(Date{2023, time.December, 31})
+	test.That(t, sut.AddDate(0, 1, 0)).Equals(Date{2024, time.March, 2})
+	test.That(t, Date{2024, time.March, 1}.DaysSince(sut)).Equals(30)
+	test.That(t, sut.DaysSince(Date{2024, time.March, 1})).Equals(-30)
+	test.IsTrue(t, sut.IsValid(), "valid")
+	test.IsFalse(t, Date{2024, time.February, 30}.IsValid(), "30th February")
+}
+
+// Tests that Date.In returns the start of the date in a location, including on
+// a day on which the clocks go forward.
+func TestDate_In(t *testing.T) {
+	// arrange
+	london, err := time.LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+	sut := Date{2024, time.March, 31}
+
+	// act
+	result := sut.In(london)
+
+	// assert
+	test.That(t, result).Equals(time.Date(2024, 3, 31, 0, 0, 0, 0, london))
+	test.That(t, sut.AddDays(1).In(london).Sub(result)).Equals(23 * time.Hour)
+}
+
+func TestDate_Compare(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		a, b     Date
+		result   int
+	}{
+		{scenario: "equal", a: Date{2024, 1, 1}, b: Date{2024, 1, 1}, result: 0},
+		{scenario: "earlier year", a: Date{2023, 12, 31}, b: Date{2024, 1, 1}, result: -1},
+		{scenario: "later month", a: Date{2024, 2, 1}, b: Date{2024, 1, 31}, result: 1},
+		{scenario: "earlier day", a: Date{2024, 1, 1}, b: Date{2024, 1, 2}, result: -1},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act/assert
+			test.That(t, tc.a.Compare(tc.b)).Equals(tc.result)
+			test.That(t, tc.a.Before(tc.b), "before").Equals(tc.result < 0)
+			test.That(t, tc.a.After(tc.b), "after").Equals(tc.result > 0)
+		})
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	// arrange
+	sut := struct{ D Date }{D: Date{2024, time.March, 31}}
+
+	// act
+	b, err := json.Marshal(sut)
+	test.Error(t, err).IsNil()
+	sut.D = Date{}
+	err = json.Unmarshal(b, &sut)
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, string(b)).Equals(`{"D":"2024-03-31"}`)
+	test.That(t, sut.D).Equals(Date{2024, time.March, 31})
+	test.Error(t, json.Unmarshal([]byte(`{"D":"today"}`), &sut)).Is(ErrInvalidDate)
+}
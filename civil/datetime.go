@@ -0,0 +1,116 @@
+package civil
+
+import (
+	"fmt"
+
+	"github.com/blugnu/time"
+)
+
+// DateTime is a civil date and time of day, in no particular location.
+type DateTime struct {
+	Date
+	TimeOfDay
+}
+
+// DateTimeOf returns the date and time of day of a time, in the location of
+// the time.
+func DateTimeOf(t time.Time) DateTime {
+	return DateTime{Date: DateOf(t), TimeOfDay: TimeOfDayOf(t)}
+}
+
+// Now returns the current date and time of day of a clock (or the system
+// clock, if nil) in a location (or the location of the clock, if nil).
+func Now(clock time.Clock, loc *time.Location) DateTime {
+	if clock == nil {
+		clock = time.SystemClock()
+	}
+	now := clock.Now()
+	if loc != nil {
+		now = now.In(loc)
+	}
+	return DateTimeOf(now)
+}
+
+// ParseDateTime parses a date and time of day separated by a 'T', in the
+// forms accepted by ParseDate and ParseTimeOfDay (e.g. "2024-03-31T09:30:00").
+// If the date and time is not valid an error wrapping ErrInvalidDateTime is
+// returned.
+func ParseDateTime(s string) (DateTime, error) {
+	t, err := time.Parse("2006-01-02T15:04:05.999999999", s)
+	if err != nil {
+		return DateTime{}, fmt.Errorf("%w: %w", ErrInvalidDateTime, err)
+	}
+	return DateTimeOf(t), nil
+}
+
+// String returns the date and time of day separated by a 'T', in the forms
+// returned by Date.String and TimeOfDay.String (e.g. "2024-03-31T09:30:00").
+func (dt DateTime) String() string {
+	return dt.Date.String() + "T" + dt.TimeOfDay.String()
+}
+
+// IsValid returns true if both the date and the time of day are valid.
+func (dt DateTime) IsValid() bool {
+	return dt.Date.IsValid() && dt.TimeOfDay.IsValid()
+}
+
+// In returns the time at which the date and time of day occurs in a location.
+//
+// If the time of day does not occur on the date in the location (when clocks
+// go forward) or occurs twice (when clocks go back) the time is normalised as
+// by time.Date, which does not guarantee which of two occurrences is returned.
+func (dt DateTime) In(loc *time.Location) time.Time {
+	return time.Date(dt.Year, dt.Month, dt.Day, dt.Hour, dt.Minute, dt.Second, dt.Nanosecond, loc)
+}
+
+// Add returns the date and time of day a duration after the date and time (or
+// before, if negative), as measured on a wall clock unaffected by changes in
+// daylight saving time.
+func (dt DateTime) Add(d time.Duration) DateTime {
+	return DateTimeOf(dt.In(time.UTC).Add(d))
+}
+
+// Sub returns the duration from another date and time to the date and time,
+// as measured on a wall clock unaffected by changes in daylight saving time;
+// the result is negative if the other date and time is later.
+func (dt DateTime) Sub(other DateTime) time.Duration {
+	return dt.In(time.UTC).Sub(other.In(time.UTC))
+}
+
+// Compare compares the date and time with another, returning -1 if the date
+// and time is earlier, +1 if it is later or 0 if they are the same.
+func (dt DateTime) Compare(other DateTime) int {
+	if c := dt.Date.Compare(other.Date); c != 0 {
+		return c
+	}
+	return dt.TimeOfDay.Compare(other.TimeOfDay)
+}
+
+// Before returns true if the date and time is earlier than another.
+func (dt DateTime) Before(other DateTime) bool {
+	return dt.Compare(other) < 0
+}
+
+// After returns true if the date and time is later than another.
+func (dt DateTime) After(other DateTime) bool {
+	return dt.Compare(other) > 0
+}
+
+// IsZero returns true if the date and time is the zero value.
+func (dt DateTime) IsZero() bool {
+	return dt == DateTime{}
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the date and time
+// in the form returned by String.
+func (dt DateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding a date and time
+// in the form accepted by ParseDateTime.
+func (dt *DateTime) UnmarshalText(b []byte) error {
+	var err error
+	*dt, err = ParseDateTime(string(b))
+	return err
+}
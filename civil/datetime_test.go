@@ -0,0 +1,73 @@
+package civil
+
+import (
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+func TestParseDateTime(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		s        string
+		result   DateTime
+		err      error
+	}{
+		{scenario: "valid", s: "2024-03-31T01:30:00", result: DateTime{Date{2024, time.March, 31}, TimeOfDay{Hour: 1, Minute: 30}}},
+		{scenario: "with zone", s: "2024-03-31T01:30:00Z", err: ErrInvalidDateTime},
+		{scenario: "date only", s: "2024-03-31", err: ErrInvalidDateTime},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, err := ParseDateTime(tc.s)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	// arrange
+	sut := Date{2024, time.December, 31}.At(TimeOfDay{Hour: 23, Minute: 30})
+
+	// act/assert
+	test.That(t, sut.String()).Equals("2024-12-31T23:30:00")
+	test.That(t, sut.Add(time.Hour)).Equals(DateTime{Date{2025, time.January, 1}, TimeOfDay{Minute: 30}})
+	test.That(t, sut.Sub(DateTime{Date: Date{2024, time.December, 31}})).Equals(23*time.Hour + 30*time.Minute)
+	test.IsTrue(t, sut.Before(sut.Add(1)), "before")
+	test.IsTrue(t, sut.After(DateTime{Date: Date{2025, time.January, 1}}.Add(-time.Hour)), "after")
+	test.IsTrue(t, sut.IsValid(), "valid")
+	test.IsFalse(t, DateTime{Date: Date{2024, time.December, 31}, TimeOfDay: TimeOfDay{Hour: 24}}.IsValid(), "invalid")
+	test.IsFalse(t, sut.IsZero(), "zero")
+}
+
+// Tests that a DateTime is converted to and from a time in a location.
+func TestDateTime_In(t *testing.T) {
+	// arrange
+	london, err := time.LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+	sut := DateTime{Date{2024, time.July, 1}, TimeOfDay{Hour: 9}}
+
+	// act
+	result := sut.In(london)
+
+	// assert
+	test.That(t, result.UTC()).Equals(time.Date(2024, 7, 1, 8, 0, 0, 0, time.UTC))
+	test.That(t, DateTimeOf(result)).Equals(sut)
+	test.That(t, DateTimeOf(result.UTC())).Equals(sut.Add(-time.Hour))
+}
+
+func TestNow(t *testing.T) {
+	// arrange
+	loc := time.FixedZone("UTC+1", 60*60)
+	clock := time.NewMockClock(time.AtTime(time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC)))
+
+	// act/assert
+	test.That(t, Now(clock, nil)).Equals(DateTime{Date{2024, time.January, 1}, TimeOfDay{Hour: 23, Minute: 30}})
+	test.That(t, Now(clock, loc)).Equals(DateTime{Date{2024, time.January, 2}, TimeOfDay{Minute: 30}})
+	test.IsFalse(t, Now(nil, nil).IsZero())
+}
@@ -0,0 +1,9 @@
+package civil
+
+import "errors"
+
+var (
+	ErrInvalidDate      = errors.New("invalid date")
+	ErrInvalidDateTime  = errors.New("invalid date and time")
+	ErrInvalidTimeOfDay = errors.New("invalid time of day")
+)
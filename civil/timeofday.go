@@ -0,0 +1,112 @@
+package civil
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/blugnu/time"
+)
+
+// TimeOfDay is a civil time of day, as read from a 24-hour clock, in no
+// particular location.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOfDayOf returns the time of day of a time, in the location of the time.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	h, m, s := t.Clock()
+	return TimeOfDay{Hour: h, Minute: m, Second: s, Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay parses a time of day in RFC 3339 partial-time form, with
+// optional fractional seconds (e.g. "09:30:00" or "09:30:00.25").  If the time
+// of day is not valid an error wrapping ErrInvalidTimeOfDay is returned.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04:05.999999999", s)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("%w: %w", ErrInvalidTimeOfDay, err)
+	}
+	return TimeOfDayOf(t), nil
+}
+
+// String returns the time of day in RFC 3339 partial-time form, with
+// fractional seconds only if non-zero (e.g. "09:30:00" or "09:30:00.25").
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond == 0 {
+		return s
+	}
+	return s + time.Date(0, 1, 1, 0, 0, 0, t.Nanosecond, time.UTC).Format(".999999999")
+}
+
+// IsValid returns true if each field of the time of day is within range; leap
+// seconds are not valid.
+func (t TimeOfDay) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 &&
+		t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 &&
+		t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// SinceMidnight returns the duration from midnight to the time of day, as
+// measured on a 24-hour clock (i.e. disregarding any change in daylight
+// saving time).
+func (t TimeOfDay) SinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour +
+		time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second +
+		time.Duration(t.Nanosecond)
+}
+
+// Add returns the time of day a duration after the time of day (or before, if
+// negative), wrapping around midnight.
+func (t TimeOfDay) Add(d time.Duration) TimeOfDay {
+	d = (t.SinceMidnight() + d%time.Day + time.Day) % time.Day
+	return TimeOfDayOf(time.Unix(0, int64(d)).UTC())
+}
+
+// Sub returns the duration from another time of day to the time of day, as
+// measured on a 24-hour clock; the result is negative if the other time of
+// day is later.
+func (t TimeOfDay) Sub(other TimeOfDay) time.Duration {
+	return t.SinceMidnight() - other.SinceMidnight()
+}
+
+// Compare compares the time of day with another, returning -1 if the time of
+// day is earlier, +1 if it is later or 0 if they are the same.
+func (t TimeOfDay) Compare(other TimeOfDay) int {
+	return cmp.Or(
+		cmp.Compare(t.Hour, other.Hour),
+		cmp.Compare(t.Minute, other.Minute),
+		cmp.Compare(t.Second, other.Second),
+		cmp.Compare(t.Nanosecond, other.Nanosecond),
+	)
+}
+
+// Before returns true if the time of day is earlier than another.
+func (t TimeOfDay) Before(other TimeOfDay) bool {
+	return t.Compare(other) < 0
+}
+
+// After returns true if the time of day is later than another.
+func (t TimeOfDay) After(other TimeOfDay) bool {
+	return t.Compare(other) > 0
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the time of day in
+// the form returned by String.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding a time of day
+// in the form accepted by ParseTimeOfDay.
+func (t *TimeOfDay) UnmarshalText(b []byte) error {
+	var err error
+	*t, err = ParseTimeOfDay(string(b))
+	return err
+}
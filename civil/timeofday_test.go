@@ -0,0 +1,79 @@
+package civil
+
+import (
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		s        string
+		result   TimeOfDay
+		err      error
+	}{
+		{scenario: "seconds", s: "09:30:15", result: TimeOfDay{Hour: 9, Minute: 30, Second: 15}},
+		{scenario: "fractional seconds", s: "23:59:59.25", result: TimeOfDay{23, 59, 59, 250_000_000}},
+		{scenario: "hour out of range", s: "24:00:00", err: ErrInvalidTimeOfDay},
+		{scenario: "no seconds", s: "09:30", err: ErrInvalidTimeOfDay},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, err := ParseTimeOfDay(tc.s)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestTimeOfDay_String(t *testing.T) {
+	testcases := []struct {
+		sut    TimeOfDay
+		result string
+	}{
+		{sut: TimeOfDay{Hour: 9}, result: "09:00:00"},
+		{sut: TimeOfDay{9, 5, 3, 250_000_000}, result: "09:05:03.25"},
+		{sut: TimeOfDay{23, 59, 59, 1}, result: "23:59:59.000000001"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.result, func(t *testing.T) {
+			test.That(t, tc.sut.String()).Equals(tc.result)
+		})
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	// arrange
+	sut := TimeOfDay{Hour: 22, Minute: 30}
+
+	// act/assert
+	test.That(t, TimeOfDayOf(time.Date(2024, 1, 1, 22, 30, 0, 0, time.UTC))).Equals(sut)
+	test.That(t, sut.SinceMidnight()).Equals(22*time.Hour + 30*time.Minute)
+	test.That(t, sut.Add(2*time.Hour)).Equals(TimeOfDay{Hour: 0, Minute: 30})
+	test.That(t, sut.Add(-23*time.Hour)).Equals(TimeOfDay{Hour: 23, Minute: 30})
+	test.That(t, sut.Add(49*time.Hour)).Equals(TimeOfDay{Hour: 23, Minute: 30})
+	test.That(t, sut.Sub(TimeOfDay{Hour: 23})).Equals(-30 * time.Minute)
+	test.IsTrue(t, sut.Before(TimeOfDay{Hour: 22, Minute: 30, Nanosecond: 1}), "before")
+	test.IsTrue(t, sut.After(TimeOfDay{Hour: 22, Minute: 29, Second: 59}), "after")
+	test.That(t, sut.Compare(sut)).Equals(0)
+	test.IsTrue(t, sut.IsValid(), "valid")
+	test.IsFalse(t, TimeOfDay{Minute: 60}.IsValid(), "60 minutes")
+}
+
+func TestTimeOfDay_Text(t *testing.T) {
+	// arrange
+	sut := TimeOfDay{}
+
+	// act
+	err := sut.UnmarshalText([]byte("17:45:00"))
+	b, _ := sut.MarshalText()
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, string(b)).Equals("17:45:00")
+}
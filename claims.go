@@ -0,0 +1,49 @@
+package time
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidateClaims validates the time-based claims of a token (such as a JWT)
+// against the current time of a clock, allowing a leeway for clock skew.  A
+// claim that is not present is given as the zero time.
+//
+// In accordance with RFC 7519:
+//
+//   - the token has expired if the current time is at or after the expiry
+//     time (exp) plus leeway;
+//   - the token is not yet valid if the current time is before the not-before
+//     time (nbf) minus leeway;
+//   - the token is not valid if the issued-at time (iat) is later than the
+//     current time plus leeway.
+//
+// The returned error wraps ErrTokenExpired, ErrTokenNotYetValid or
+// ErrTokenIssuedInFuture respectively; claims are validated in that order and
+// the first failure is returned.
+func ValidateClaims(clock Clock, iat, nbf, exp time.Time, leeway time.Duration) error {
+	now := clock.Now()
+	leeway = max(leeway, 0)
+
+	if !exp.IsZero() && !now.Before(exp.Add(leeway)) {
+		return fmt.Errorf("%w: expired at %s", ErrTokenExpired, exp.Format(time.RFC3339))
+	}
+	if !nbf.IsZero() && now.Before(nbf.Add(-leeway)) {
+		return fmt.Errorf("%w: not valid before %s", ErrTokenNotYetValid, nbf.Format(time.RFC3339))
+	}
+	if !iat.IsZero() && iat.After(now.Add(leeway)) {
+		return fmt.Errorf("%w: issued at %s", ErrTokenIssuedInFuture, iat.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// NumericDate returns the time of an RFC 7519 NumericDate (seconds since the
+// Unix epoch), as used by the time-based claims of a JWT.  A NumericDate of 0
+// (a claim that is not present) returns the zero time, as expected by
+// ValidateClaims.
+func NumericDate(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(n, 0)
+}
@@ -0,0 +1,50 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestValidateClaims(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	testcases := []struct {
+		scenario string
+		iat      time.Time
+		nbf      time.Time
+		exp      time.Time
+		leeway   time.Duration
+		err      error
+	}{
+		{scenario: "no claims"},
+		{scenario: "valid", iat: now.Add(-time.Minute), nbf: now.Add(-time.Minute), exp: now.Add(time.Minute)},
+		{scenario: "exp exactly now", exp: now, err: ErrTokenExpired},
+		{scenario: "exp exactly now within leeway", exp: now, leeway: time.Second},
+		{scenario: "exp at end of leeway", exp: now.Add(-time.Second), leeway: time.Second, err: ErrTokenExpired},
+		{scenario: "nbf exactly now", nbf: now},
+		{scenario: "nbf in future", nbf: now.Add(time.Second), err: ErrTokenNotYetValid},
+		{scenario: "nbf in future within leeway", nbf: now.Add(time.Second), leeway: time.Second},
+		{scenario: "iat exactly now", iat: now},
+		{scenario: "iat in future", iat: now.Add(time.Second), err: ErrTokenIssuedInFuture},
+		{scenario: "iat in future within leeway", iat: now.Add(time.Second), leeway: time.Second},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(now))
+
+			// act
+			err := ValidateClaims(clock, tc.iat, tc.nbf, tc.exp, tc.leeway)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+		})
+	}
+}
+
+func TestNumericDate(t *testing.T) {
+	test.IsTrue(t, NumericDate(0).IsZero())
+	test.IsTrue(t, NumericDate(1000).Equal(time.Unix(1000, 0)))
+}
@@ -0,0 +1,240 @@
+package time
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DeadlineNode describes a context derived by a DeadlineAudit using one of
+// the ContextWithDeadline, ContextWithDeadlineCause, ContextWithTimeout or
+// ContextWithTimeoutCause methods.
+type DeadlineNode struct {
+	// ID identifies the context; contexts are numbered from 1 in the order in
+	// which they are derived.
+	ID int
+
+	// Parent is the ID of the nearest ancestor of the context that was derived
+	// by the audit, or 0 if there is none.
+	Parent int
+
+	// Caller identifies the location (file and line) of the code that derived
+	// the context, directly or using a function of this package (such as
+	// ContextWithTimeout).
+	Caller string
+
+	// Timeout is the timeout requested, or zero if a deadline was requested.
+	Timeout time.Duration
+
+	// Requested is the deadline requested; for a timeout, this is the time of
+	// the clock when the context was derived plus the timeout.
+	Requested time.Time
+
+	// Inherited is the deadline of the parent context when the context was
+	// derived, or the zero time if the parent had no deadline.
+	Inherited time.Time
+
+	// Effective is the deadline of the derived context: the earlier of the
+	// requested and the inherited deadlines.
+	Effective time.Time
+}
+
+// Shortened returns true if the context shortened the deadline inherited
+// from its parent, or if the parent had no deadline.
+func (n DeadlineNode) Shortened() bool {
+	return n.Inherited.IsZero() || n.Effective.Before(n.Inherited)
+}
+
+// String returns a single line description of the node.
+func (n DeadlineNode) String() string {
+	request := "ContextWithDeadline(" + n.Requested.Format(time.RFC3339Nano) + ")"
+	if n.Timeout != 0 {
+		request = "ContextWithTimeout(" + n.Timeout.String() + ")"
+	}
+
+	switch {
+	case n.Inherited.IsZero():
+		return fmt.Sprintf("#%d %s at %s: deadline %s", n.ID, request, n.Caller, n.Effective.Format(time.RFC3339Nano))
+	case n.Shortened():
+		return fmt.Sprintf("#%d %s at %s: deadline %s (shortened by %s)", n.ID, request, n.Caller, n.Effective.Format(time.RFC3339Nano), n.Inherited.Sub(n.Effective))
+	default:
+		return fmt.Sprintf("#%d %s at %s: deadline %s (inherited; %s later requested)", n.ID, request, n.Caller, n.Effective.Format(time.RFC3339Nano), n.Requested.Sub(n.Effective))
+	}
+}
+
+// DeadlineAudit is a Clock which records the contexts derived using its
+// ContextWithDeadline, ContextWithDeadlineCause, ContextWithTimeout and
+// ContextWithTimeoutCause methods, identifying for each the context from
+// which it was derived, the code that derived it and the deadline requested,
+// inherited and effective.  All other methods are those of the base clock.
+//
+// Installed as the clock of a root context (see: ContextWithClock), the audit
+// records the tree of the contexts derived from that root using the functions
+// of this package (such as ContextWithTimeout), so that the code responsible
+// for shortening the deadline of a deeply derived context may be identified.
+// With a mock base clock the deadlines are deterministic, so the report of an
+// audit may be asserted in a test.
+type DeadlineAudit struct {
+	Clock
+	mu    sync.Mutex
+	nodes []DeadlineNode
+}
+
+// NewDeadlineAudit returns a DeadlineAudit recording the contexts derived using
+// a base clock (or the system clock, if nil).
+func NewDeadlineAudit(base Clock) *DeadlineAudit {
+	if base == nil {
+		base = SystemClock()
+	}
+	return &DeadlineAudit{Clock: base}
+}
+
+// In returns a view of the auditing clock in a given location.
+func (c *DeadlineAudit) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Close closes the base clock (see: CloseClock).
+func (c *DeadlineAudit) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// ContextWithDeadline returns a context with a deadline derived using the base
+// clock, recording the derived context.
+func (c *DeadlineAudit) ContextWithDeadline(ctx context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	derived, cancel := c.Clock.ContextWithDeadline(ctx, d)
+	return c.record(ctx, derived, d, 0), cancel
+}
+
+// ContextWithDeadlineCause returns a context with a deadline and cause derived
+// using the base clock, recording the derived context.
+func (c *DeadlineAudit) ContextWithDeadlineCause(ctx context.Context, d time.Time, cause error) (context.Context, context.CancelFunc) {
+	derived, cancel := c.Clock.ContextWithDeadlineCause(ctx, d, cause)
+	return c.record(ctx, derived, d, 0), cancel
+}
+
+// ContextWithTimeout returns a context with a timeout derived using the base
+// clock, recording the derived context.
+func (c *DeadlineAudit) ContextWithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	requested := c.Clock.Now().Add(d)
+	derived, cancel := c.Clock.ContextWithTimeout(ctx, d)
+	return c.record(ctx, derived, requested, d), cancel
+}
+
+// ContextWithTimeoutCause returns a context with a timeout and cause derived
+// using the base clock, recording the derived context.
+func (c *DeadlineAudit) ContextWithTimeoutCause(ctx context.Context, d time.Duration, cause error) (context.Context, context.CancelFunc) {
+	requested := c.Clock.Now().Add(d)
+	derived, cancel := c.Clock.ContextWithTimeoutCause(ctx, d, cause)
+	return c.record(ctx, derived, requested, d), cancel
+}
+
+// Nodes returns the contexts recorded by the audit, in the order in which they
+// were derived.
+func (c *DeadlineAudit) Nodes() []DeadlineNode {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]DeadlineNode(nil), c.nodes...)
+}
+
+// Chain returns the recorded contexts from which a given context was derived,
+// from the outermost to the context itself (or its nearest recorded ancestor).
+// If no recorded context is an ancestor of the context, nil is returned.
+func (c *DeadlineAudit) Chain(ctx context.Context) []DeadlineNode {
+	n, ok := ctx.Value(auditKey).(DeadlineNode)
+	if !ok {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the node is not recorded if the audit has been reset since the context
+	// was derived
+	if n.ID > len(c.nodes) || c.nodes[n.ID-1] != n {
+		return nil
+	}
+
+	var chain []DeadlineNode
+	for id := n.ID; id > 0; id = c.nodes[id-1].Parent {
+		chain = append([]DeadlineNode{c.nodes[id-1]}, chain...)
+	}
+	return chain
+}
+
+// Report returns a description of the tree of the recorded contexts, with
+// each context on a separate line indented beneath its parent.
+func (c *DeadlineAudit) Report() string {
+	nodes := c.Nodes()
+
+	children := map[int][]DeadlineNode{}
+	for _, n := range nodes {
+		children[n.Parent] = append(children[n.Parent], n)
+	}
+
+	sb := &strings.Builder{}
+	var write func(parent, depth int)
+	write = func(parent, depth int) {
+		for _, n := range children[parent] {
+			sb.WriteString(strings.Repeat("  ", depth) + n.String() + "\n")
+			write(n.ID, depth+1)
+		}
+	}
+	write(0, 0)
+
+	return sb.String()
+}
+
+// Reset discards the contexts recorded by the audit.  Contexts derived from a
+// context recorded before the audit was reset are recorded with no parent.
+func (c *DeadlineAudit) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nodes = nil
+}
+
+// record records a context derived from a parent with a requested deadline
+// (and timeout, if any), returning the derived context carrying the node that
+// describes it.
+func (c *DeadlineAudit) record(parent, derived context.Context, requested time.Time, timeout time.Duration) context.Context {
+	n := DeadlineNode{
+		Caller:    externalCaller(),
+		Timeout:   timeout,
+		Requested: requested,
+	}
+	n.Inherited, _ = parent.Deadline()
+	n.Effective, _ = derived.Deadline()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if p, ok := parent.Value(auditKey).(DeadlineNode); ok && p.ID <= len(c.nodes) && c.nodes[p.ID-1] == p {
+		n.Parent = p.ID
+	}
+	n.ID = len(c.nodes) + 1
+	c.nodes = append(c.nodes, n)
+
+	return context.WithValue(derived, auditKey, n)
+}
+
+// externalCaller returns the location (file and line) of the first caller
+// outside of this package (see: isInternalFrame), or "unknown".
+func externalCaller() string {
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(2, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		if !isInternalFrame(frame) {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package time
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestDeadlineAudit(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewDeadlineAudit(clock)
+	ctx := ContextWithClock(context.Background(), sut)
+
+	// act
+	request, cancel := ContextWithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	query, cancel := ContextWithTimeout(request, 50*time.Millisecond)
+	defer cancel()
+	retry, cancel := ContextWithDeadlineCause(query, time.Unix(10, 0), ErrTimeout)
+	defer cancel()
+
+	// assert
+	nodes := sut.Nodes()
+	test.That(t, len(nodes)).Equals(3)
+	test.That(t, nodes[1].Parent).Equals(1)
+	test.That(t, nodes[1].Inherited).Equals(time.Unix(5, 0).UTC())
+	test.That(t, nodes[1].Effective).Equals(time.Unix(0, 50_000_000).UTC())
+	test.IsTrue(t, nodes[1].Shortened())
+	test.IsTrue(t, strings.Contains(nodes[1].Caller, "clock.audit_test.go:"))
+	test.That(t, nodes[2].Parent).Equals(2)
+	test.That(t, nodes[2].Requested).Equals(time.Unix(10, 0))
+	test.IsFalse(t, nodes[2].Shortened())
+
+	test.That(t, sut.Chain(retry)).Equals(nodes)
+	test.That(t, sut.Chain(ctx)).IsNil()
+
+	report := strings.Split(strings.TrimSpace(sut.Report()), "\n")
+	test.That(t, len(report)).Equals(3)
+	test.IsTrue(t, strings.HasPrefix(report[0], "#1 ContextWithTimeout(5s) at "))
+	test.IsTrue(t, strings.HasPrefix(report[1], "  #2 ContextWithTimeout(50ms) at "))
+	test.IsTrue(t, strings.HasSuffix(report[1], "(shortened by 4.95s)"))
+	test.IsTrue(t, strings.HasPrefix(report[2], "    #3 ContextWithDeadline(1970-01-01T00:00:10Z) at "))
+	test.IsTrue(t, strings.HasSuffix(report[2], "(inherited; 9.95s later requested)"))
+
+	// act
+	clock.AdvanceBy(50 * time.Millisecond)
+
+	// assert
+	test.Error(t, context.Cause(retry)).Is(context.DeadlineExceeded)
+}
+
+func TestDeadlineAudit_Reset(t *testing.T) {
+	// arrange
+	sut := NewDeadlineAudit(NewMockClock())
+	ctx, cancel := sut.ContextWithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// act
+	sut.Reset()
+	child, cancel := sut.ContextWithTimeoutCause(ctx, time.Millisecond, ErrTimeout)
+	defer cancel()
+
+	// assert
+	test.That(t, sut.Chain(ctx)).IsNil()
+	test.That(t, len(sut.Chain(child))).Equals(1)
+	test.That(t, sut.Nodes()[0].Parent).Equals(0)
+}
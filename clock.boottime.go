@@ -0,0 +1,140 @@
+package time
+
+import (
+	"sync"
+	"time"
+)
+
+// bootEpoch is the time at which the package was initialised, from which
+// monotonic readings are measured where the readings of the system are not
+// available (see: systemBootReadings).
+var bootEpoch = time.Now()
+
+// BootReadings are the readings of the monotonic and boottime clocks of a
+// BootClock.
+type BootReadings struct {
+	// Monotonic is the time elapsed according to a monotonic clock which does
+	// not advance while the system is suspended (CLOCK_MONOTONIC, on Linux).
+	Monotonic time.Duration
+
+	// Boottime is the time elapsed according to a monotonic clock which also
+	// counts the time for which the system has been suspended
+	// (CLOCK_BOOTTIME, on Linux).
+	Boottime time.Duration
+}
+
+// Suspended returns the time for which the system has been suspended
+// according to the readings: the difference between the boottime and
+// monotonic readings.
+func (r BootReadings) Suspended() time.Duration {
+	return r.Boottime - r.Monotonic
+}
+
+// BootClock is a Clock providing readings of both a monotonic clock and a
+// boottime clock, which (unlike the monotonic clock) counts the time for which
+// the system is suspended (see: Readings).  This allows a daemon to detect that
+// the system has been suspended, e.g. to renew leases or re-establish
+// connections on resume, by comparing the time suspended (see: Suspended)
+// between readings.  All other methods are those of the base clock.
+//
+// With the system clock (or a clock other than a mock clock) as its base, the
+// readings are those of CLOCK_MONOTONIC and CLOCK_BOOTTIME on Linux; on other
+// platforms (see: SuspendAware) both readings are of the monotonic clock of the
+// process, so that no suspension is detected.
+//
+// With a mock clock as its base, the readings are simulated, measured from the
+// creation of the BootClock by the mock clock, and a suspension of the system
+// may be simulated using SimulateSuspend.
+type BootClock struct {
+	Clock
+	mock      MockClock
+	start     time.Time
+	mu        sync.Mutex
+	simulated time.Duration
+	initial   BootReadings
+}
+
+// NewBootClock returns a BootClock providing readings of the monotonic and
+// boottime clocks of the system or, if the base clock is a mock clock,
+// simulated readings.  If the base clock is nil the system clock is used.
+func NewBootClock(base Clock) *BootClock {
+	if base == nil {
+		base = SystemClock()
+	}
+
+	c := &BootClock{Clock: base}
+	if mock, ok := base.(MockClock); ok {
+		c.mock, c.start = mock, mock.Now()
+	}
+	c.initial = c.Readings()
+	return c
+}
+
+// In returns a view of the boot clock in a given location.
+func (c *BootClock) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Close closes the base clock (see: CloseClock).
+func (c *BootClock) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// Readings returns the current readings of the monotonic and boottime clocks,
+// including any suspension simulated using SimulateSuspend.
+func (c *BootClock) Readings() BootReadings {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mock != nil {
+		elapsed := c.mock.Now().Sub(c.start)
+		return BootReadings{Monotonic: elapsed - c.simulated, Boottime: elapsed}
+	}
+
+	r, ok := systemBootReadings()
+	if !ok {
+		elapsed := time.Since(bootEpoch)
+		r = BootReadings{Monotonic: elapsed, Boottime: elapsed}
+	}
+	r.Boottime += c.simulated
+	return r
+}
+
+// Suspended returns the time for which the system has been suspended since
+// the BootClock was created.
+func (c *BootClock) Suspended() time.Duration {
+	return c.Readings().Suspended() - c.initial.Suspended()
+}
+
+// SuspendAware returns true if the readings of the clock reflect the
+// suspension of the system: if the base clock is a mock clock or the boottime
+// clock of the system is available.
+func (c *BootClock) SuspendAware() bool {
+	if c.mock != nil {
+		return true
+	}
+	_, ok := systemBootReadings()
+	return ok
+}
+
+// SimulateSuspend simulates a suspension of the system for a given duration:
+// the boottime reading advances by the duration while the monotonic reading
+// does not.
+//
+// If the base clock is a mock clock, the mock clock is advanced by the
+// duration, as the wall clock of a system advances while it is suspended; any
+// timers and tickers due during the suspension are triggered as if on resume.
+// Otherwise, only the boottime reading is affected.
+func (c *BootClock) SimulateSuspend(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.simulated += d
+	c.mu.Unlock()
+
+	if c.mock != nil {
+		c.mock.AdvanceBy(d)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build linux
+
+package time
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// clock ids of the clock_gettime syscall (see: linux/time.h)
+const (
+	clockMonotonic = 1
+	clockBoottime  = 7
+)
+
+// systemBootReadings returns the readings of the CLOCK_MONOTONIC and
+// CLOCK_BOOTTIME clocks of the system, and true, or false if either could not
+// be read.
+func systemBootReadings() (BootReadings, bool) {
+	var mono, boot syscall.Timespec
+	if clockGettime(clockMonotonic, &mono) != nil || clockGettime(clockBoottime, &boot) != nil {
+		return BootReadings{}, false
+	}
+	return BootReadings{
+		Monotonic: time.Duration(mono.Nano()),
+		Boottime:  time.Duration(boot.Nano()),
+	}, true
+}
+
+// clockGettime reads a clock of the system using the clock_gettime syscall.
+func clockGettime(id int, ts *syscall.Timespec) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_CLOCK_GETTIME, uintptr(id), uintptr(unsafe.Pointer(ts)), 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
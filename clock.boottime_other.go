@@ -0,0 +1,9 @@
+//go:build !linux
+
+package time
+
+// systemBootReadings returns false; the boottime clock of the system is
+// available only on Linux.
+func systemBootReadings() (BootReadings, bool) {
+	return BootReadings{}, false
+}
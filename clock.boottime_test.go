@@ -0,0 +1,69 @@
+package time
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestBootClock_Mock(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewBootClock(base)
+
+	fired := make(chan struct{})
+	_ = base.AfterFunc(time.Minute, func() { close(fired) })
+
+	// act
+	base.AdvanceBy(10 * time.Second)
+	sut.SimulateSuspend(time.Hour)
+	base.AdvanceBy(5 * time.Second)
+
+	// assert
+	test.That(t, sut.Readings()).Equals(BootReadings{
+		Monotonic: 15 * time.Second,
+		Boottime:  time.Hour + 15*time.Second,
+	})
+	test.That(t, sut.Suspended()).Equals(time.Hour)
+	test.That(t, sut.Now()).Equals(time.Unix(3615, 0).UTC())
+	test.IsTrue(t, sut.SuspendAware())
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Error("timer due during suspension did not fire")
+	}
+}
+
+func TestBootClock_SimulateSuspend_NonPositive(t *testing.T) {
+	// arrange
+	sut := NewBootClock(NewMockClock())
+
+	// act
+	sut.SimulateSuspend(0)
+	sut.SimulateSuspend(-time.Second)
+
+	// assert
+	test.That(t, sut.Suspended()).Equals(time.Duration(0))
+	test.That(t, sut.Now()).Equals(time.Unix(0, 0).UTC())
+}
+
+func TestBootClock_System(t *testing.T) {
+	// arrange
+	sut := NewBootClock(nil)
+
+	// act
+	before := sut.Readings()
+	sut.SimulateSuspend(time.Minute)
+	after := sut.Readings()
+
+	// assert
+	test.That(t, sut.Clock).Equals(SystemClock())
+	test.That(t, sut.SuspendAware()).Equals(runtime.GOOS == "linux")
+	test.IsFalse(t, after.Monotonic < before.Monotonic, "monotonic reading does not decrease")
+	test.IsTrue(t, after.Boottime-before.Boottime >= time.Minute, "boottime includes simulated suspension")
+	// the clocks of the system are read successively, so the readings are
+	// not precisely simultaneous
+	test.IsTrue(t, sut.Suspended() > time.Minute-time.Millisecond, "suspended includes simulated suspension")
+}
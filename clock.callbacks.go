@@ -0,0 +1,97 @@
+package time
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+)
+
+// SlowCallback describes a function called by a timer (created by AfterFunc)
+// that took longer than a threshold to return, reported by a clock obtained
+// from NewCallbackMonitor.
+type SlowCallback struct {
+	// Caller identifies the location (file and line) of the call to AfterFunc
+	// that created the timer.
+	Caller string
+
+	// Due is the time (according to the clock) at which the function was
+	// called.
+	Due time.Time
+
+	// Elapsed is the real time that the function took to return.
+	Elapsed time.Duration
+
+	// Threshold is the threshold which the function exceeded.
+	Threshold time.Duration
+}
+
+// String returns a single line description of the slow callback.
+func (cb SlowCallback) String() string {
+	return fmt.Sprintf("slow callback: AfterFunc at %s took %s (threshold %s)", cb.Caller, cb.Elapsed, cb.Threshold)
+}
+
+// callbackMonitor is a Clock which measures the real time taken by the
+// functions called by the timers created by its AfterFunc method.
+type callbackMonitor struct {
+	Clock
+	threshold time.Duration
+	report    func(SlowCallback)
+}
+
+// NewCallbackMonitor returns a Clock which measures the real time taken by each
+// function called by a timer created using AfterFunc, calling a report
+// function with a SlowCallback for any that take longer than a threshold to
+// return.  If the report function is nil, slow callbacks are written to
+// os.Stderr.
+//
+// The functions called by timers run on goroutines of the clock (or of the
+// runtime, for the system clock), so blocking work in a callback can delay
+// other work scheduled on the clock; this may be used in a production service
+// to surface such work.  The report function is called on the goroutine of
+// the slow callback, after it has returned.
+//
+// All other methods (including the timers created for context deadlines and
+// timeouts) are those of the base clock.
+func NewCallbackMonitor(base Clock, threshold time.Duration, report func(SlowCallback)) Clock {
+	if report == nil {
+		report = func(cb SlowCallback) { _, _ = fmt.Fprintln(os.Stderr, cb) }
+	}
+	return callbackMonitor{Clock: base, threshold: threshold, report: report}
+}
+
+// In returns a view of the monitoring clock in a given location.
+func (c callbackMonitor) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Close closes the base clock (see: CloseClock).
+func (c callbackMonitor) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// AfterFunc waits for the duration to elapse and then calls f in its own
+// goroutine, reporting the call if f takes longer than the threshold of the
+// clock to return.
+func (c callbackMonitor) AfterFunc(d time.Duration, f func()) *Timer {
+	caller := "unknown"
+	if _, file, line, ok := runtime.Caller(1); ok {
+		caller = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	return c.Clock.AfterFunc(d, func() {
+		due := c.Clock.Now()
+		started := time.Now()
+		defer func() {
+			if elapsed := time.Since(started); elapsed > c.threshold {
+				c.report(SlowCallback{
+					Caller:    caller,
+					Due:       due,
+					Elapsed:   elapsed,
+					Threshold: c.threshold,
+				})
+			}
+		}()
+		f()
+	})
+}
@@ -0,0 +1,37 @@
+package time
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that only functions taking longer than the threshold are reported.
+func TestCallbackMonitor(t *testing.T) {
+	// arrange
+	var (
+		base     = NewMockClock()
+		reported = make(chan SlowCallback, 2)
+		sut      = MonitorCallbacks(10*time.Millisecond, func(cb SlowCallback) { reported <- cb })(base)
+		done     = make(chan struct{}, 2)
+	)
+	sut.AfterFunc(time.Second, func() { done <- struct{}{} })
+	sut.AfterFunc(2*time.Second, func() { time.Sleep(20 * time.Millisecond); done <- struct{}{} })
+
+	// act
+	base.AdvanceBy(2 * time.Second)
+	<-done
+	<-done
+	cb := <-reported
+
+	// assert
+	test.That(t, cb.Due).Equals(time.Unix(2, 0).UTC())
+	test.IsTrue(t, cb.Elapsed >= 20*time.Millisecond, "elapsed")
+	test.That(t, cb.Threshold).Equals(10 * time.Millisecond)
+	test.IsTrue(t, strings.Contains(cb.Caller, "clock.callbacks_test.go:"), "caller")
+	test.IsTrue(t, strings.HasPrefix(cb.String(), "slow callback: AfterFunc at "), "string")
+	test.That(t, len(reported)).Equals(0)
+	test.That(t, sut.In(time.UTC).Now()).Equals(time.Unix(2, 0).UTC())
+}
@@ -0,0 +1,101 @@
+package time
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CoarseClock is a Clock for which Now returns a cached time, refreshed at a
+// regular interval by a ticker obtained from a base clock.  Reading the cached
+// time is very cheap, trading precision for performance (e.g. for timestamps
+// in high-throughput request logging).
+//
+// Since and Until are consistent with Now; timers, tickers, sleeps and contexts
+// are those of the base clock.
+//
+// A CoarseClock must be stopped when no longer required, to stop the ticker
+// and the goroutine refreshing the cached time.
+type CoarseClock struct {
+	Clock
+	now    atomic.Pointer[time.Time]
+	ticker *Ticker
+	done   chan struct{}
+	stop   sync.Once
+}
+
+// NewCoarseClock returns a CoarseClock caching the current time of a base clock,
+// refreshed at a given interval.  The interval must be greater than zero; if
+// refresh <= 0, NewCoarseClock will panic.
+//
+// If the base clock is a mock clock the cached time is refreshed when the mock
+// clock is advanced by the refresh interval (or more), allowing code using a
+// CoarseClock to be tested deterministically.
+func NewCoarseClock(base Clock, refresh time.Duration) *CoarseClock {
+	if refresh <= 0 {
+		panic(fmt.Errorf("%w for NewCoarseClock", errNonPositiveInterval))
+	}
+
+	c := &CoarseClock{
+		Clock:  base,
+		ticker: base.NewTickerNamed(refresh, "CoarseClock"),
+		done:   make(chan struct{}),
+	}
+	now := base.Now()
+	c.now.Store(&now)
+
+	go c.refresh()
+
+	return c
+}
+
+// refresh updates the cached time on each tick of the ticker until the clock
+// is stopped.
+func (c *CoarseClock) refresh() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case t := <-c.ticker.C:
+			c.now.Store(&t)
+		}
+	}
+}
+
+// In returns a view of the coarse clock in a given location.
+func (c *CoarseClock) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Now returns the cached time of the clock.
+func (c *CoarseClock) Now() time.Time {
+	return *c.now.Load()
+}
+
+// Since returns the duration since t, according to the cached time.
+func (c *CoarseClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t, according to the cached time.
+func (c *CoarseClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// Close stops the refreshing of the cached time (see: Stop) and closes the
+// base clock (see: CloseClock).
+func (c *CoarseClock) Close() error {
+	c.Stop()
+	return CloseClock(c.Clock)
+}
+
+// Stop stops the refreshing of the cached time; once stopped, Now returns the
+// time that was cached when the clock was stopped.  Calling Stop more than
+// once has no effect.
+func (c *CoarseClock) Stop() {
+	c.stop.Do(func() {
+		c.ticker.Stop()
+		close(c.done)
+	})
+}
@@ -0,0 +1,85 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// waitFor waits (in real-time) for up to a second for a condition to be
+// satisfied, returning true if it was.
+func waitFor(cond func() bool) bool {
+	for range 1000 {
+		if cond() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return cond()
+}
+
+// created returns the number of timers and tickers that have been created by
+// a mock clock, including any that are no longer retained by the clock.
+func created(clock MockClock) int {
+	m := clock.(*mockClock)
+	return eval(m, func() int { return m.nextTickerId })
+}
+
+func TestCoarseClock(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewCoarseClock(base, time.Second)
+	defer sut.Stop()
+
+	// act: advance the base clock by less than the refresh interval
+	base.AdvanceBy(500 * time.Millisecond)
+
+	// assert: the cached time has not been refreshed
+	test.That(t, sut.Now()).Equals(time.Unix(0, 0).UTC())
+	test.That(t, sut.Since(time.Unix(0, 0))).Equals(time.Duration(0))
+	test.That(t, sut.Until(time.Unix(1, 0))).Equals(time.Second)
+
+	// act: advance the base clock to the refresh interval
+	base.AdvanceBy(500 * time.Millisecond)
+
+	// assert: the cached time is refreshed
+	test.IsTrue(t, waitFor(func() bool { return sut.Now().Equal(time.Unix(1, 0)) }))
+}
+
+func TestCoarseClock_Stop(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewCoarseClock(base, time.Second)
+
+	// act
+	sut.Stop()
+	sut.Stop()
+	base.AdvanceBy(time.Second)
+
+	// assert
+	test.That(t, sut.Now()).Equals(time.Unix(0, 0).UTC())
+	test.That(t, base.Timers()[0].State).Equals("stopped")
+}
+
+// Tests that a view of a coarse clock in a location returns the cached time.
+func TestCoarseClock_In(t *testing.T) {
+	// arrange
+	loc := time.FixedZone("UTC+1", 3600)
+	base := NewMockClock()
+	sut := NewCoarseClock(base, time.Minute)
+	defer sut.Stop()
+	base.AdvanceBy(time.Second)
+
+	// act
+	result := sut.In(loc).Now()
+
+	// assert
+	test.That(t, result).Equals(time.Unix(0, 0).In(loc))
+}
+
+func TestNewCoarseClock_NonPositiveRefresh(t *testing.T) {
+	defer test.ExpectPanic(errNonPositiveInterval).Assert(t)
+
+	_ = NewCoarseClock(NewMockClock(), 0)
+}
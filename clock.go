@@ -13,6 +13,10 @@ import (
 // This allows for the creation of mock clocks for testing purposes through an
 // API that is similar to and consistent with that of the system clock in the
 // standard library `time` package.
+//
+// Methods added to Clock that are not described by the time package (such as
+// NewTimerNamed) must also be provided by any implementation outside this
+// package; an implementation that embeds a Clock inherits them.
 type Clock interface {
 	// After returns a channel that will send the current time after at least
 	// duration d.
@@ -25,6 +29,24 @@ type Clock interface {
 	// If the Timer is stopped, the function f will not be called.
 	AfterFunc(d time.Duration, f func()) *Timer
 
+	// Date returns the time corresponding to the given date and time of day
+	// in the location of the clock, as for time.Date.
+	//
+	// For the system clock this is the local time zone (time.Local, or the
+	// location set using WithTZ); for a mock clock it is the location
+	// configured with the InLocation option and for a view obtained using
+	// In it is the location of the view.
+	Date(year int, month time.Month, day, hour, min, sec, nsec int) time.Time
+
+	// In returns a view of the clock in a given location: the Clock returned
+	// is the same clock, except that Now returns the current time in that
+	// location.  Timers, tickers and contexts obtained from the view are
+	// those of the underlying clock.
+	//
+	// This allows, for example, a multi-tenant service to obtain the local
+	// time of each tenant from the same underlying clock.
+	In(loc *time.Location) Clock
+
 	// NewTicker returns a new Ticker that will send the current time on its
 	// channel after each tick. The duration d must be greater than zero; if
 	// d <= 0, NewTicker will panic.
@@ -34,6 +56,14 @@ type Clock interface {
 	// The Ticker will continue ticking until Stop is called on it.
 	NewTicker(d time.Duration) *Ticker
 
+	// NewTickerNamed returns a new Ticker in the same way as NewTicker,
+	// associating the given name with the Ticker.
+	//
+	// The name is used to identify the Ticker in diagnostics and panic
+	// messages provided by a mock clock; it has no effect on a Ticker
+	// obtained from the system clock.
+	NewTickerNamed(d time.Duration, name string) *Ticker
+
 	// NewTimer returns a new Timer that will send the current time on its
 	// channel after the duration d. The duration d must be greater than zero;
 	// if d <= 0, NewTimer will panic.
@@ -44,9 +74,22 @@ type Clock interface {
 	// beforehand.  A stopped Timer will resume if it is reset.
 	NewTimer(d time.Duration) *Timer
 
+	// NewTimerNamed returns a new Timer in the same way as NewTimer,
+	// associating the given name with the Timer.
+	//
+	// The name is used to identify the Timer in diagnostics and panic
+	// messages provided by a mock clock; it has no effect on a Timer
+	// obtained from the system clock.
+	NewTimerNamed(d time.Duration, name string) *Timer
+
 	// Now returns the current time.
 	Now() time.Time
 
+	// ParseInLocation parses a formatted string and returns the time value it
+	// represents, as for time.ParseInLocation, interpreting a time without
+	// time zone information in the location of the clock (see: Date).
+	ParseInLocation(layout, value string) (time.Time, error)
+
 	// Since returns the duration since t, according to the current time.  It is
 	// shorthand for time.Since(c.Now()).
 	Since(t time.Time) time.Duration
@@ -115,22 +158,39 @@ type systemClock struct{}
 
 func (c systemClock) After(d time.Duration) <-chan Time { return time.After(d) }
 func (c systemClock) AfterFunc(d time.Duration, f func()) *Timer {
-	return &Timer{Timer: time.AfterFunc(d, f)}
+	return &Timer{Timer: time.AfterFunc(d, f), initialised: true, deadline: time.Now().Add(d)}
+}
+func (c systemClock) Date(year int, month time.Month, day, hour, min, sec, nsec int) time.Time {
+	return time.Date(year, month, day, hour, min, sec, nsec, localLocation())
+}
+func (c systemClock) ParseInLocation(layout, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, localLocation())
 }
-func (c systemClock) Now() time.Time                        { return time.Now() }
+func (c systemClock) In(loc *time.Location) Clock           { return inLocation(c, loc) }
 func (c systemClock) Since(t time.Time) time.Duration       { return time.Since(t) }
 func (c systemClock) Until(t time.Time) time.Duration       { return time.Until(t) }
 func (c systemClock) Sleep(d time.Duration)                 { time.Sleep(d) }
 func (c systemClock) Tick(d time.Duration) <-chan time.Time { return time.Tick(d) }
 
+func (c systemClock) Now() time.Time {
+	// the time in a location set using WithTZ has no monotonic clock reading
+	if loc := localTZ.Load(); loc != nil {
+		return time.Now().In(loc)
+	}
+	return time.Now()
+}
+
 func (c systemClock) NewTicker(d time.Duration) *Ticker {
 	return &Ticker{Ticker: time.NewTicker(d), initialised: true}
 }
 
 func (c systemClock) NewTimer(d time.Duration) *Timer {
-	return &Timer{Timer: time.NewTimer(d), initialised: true}
+	return &Timer{Timer: time.NewTimer(d), initialised: true, deadline: time.Now().Add(d)}
 }
 
+func (c systemClock) NewTickerNamed(d time.Duration, _ string) *Ticker { return c.NewTicker(d) }
+func (c systemClock) NewTimerNamed(d time.Duration, _ string) *Timer   { return c.NewTimer(d) }
+
 func (c systemClock) ContextWithDeadline(ctx context.Context, d time.Time) (context.Context, context.CancelFunc) {
 	return context.WithDeadline(ctx, d)
 }
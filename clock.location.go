@@ -0,0 +1,63 @@
+package time
+
+import (
+	"time"
+)
+
+// locatedClock is a view of a Clock in which Now returns the time in a
+// specific location.
+type locatedClock struct {
+	Clock
+	loc *time.Location
+}
+
+// NewLocationClock returns a view of a base clock in a given location: the
+// Clock returned is the base clock, except that Now returns the current time
+// in that location (see: Clock.In).
+//
+// This may be used to implement the In method of a Clock implemented outside
+// this package:
+//
+//	func (c myClock) In(loc *time.Location) time.Clock {
+//		return time.NewLocationClock(c, loc)
+//	}
+func NewLocationClock(base Clock, loc *time.Location) Clock {
+	return inLocation(base, loc)
+}
+
+// inLocation returns a view of a clock in a given location.  If the clock is
+// already a view, the view is of the underlying clock.
+func inLocation(c Clock, loc *time.Location) Clock {
+	if lc, ok := c.(locatedClock); ok {
+		c = lc.Clock
+	}
+	return locatedClock{Clock: c, loc: loc}
+}
+
+// In returns a view of the underlying clock in a given location.
+func (c locatedClock) In(loc *time.Location) Clock {
+	return inLocation(c.Clock, loc)
+}
+
+// Close closes the underlying clock (see: CloseClock).
+func (c locatedClock) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// Now returns the current time of the underlying clock in the location of
+// the view.
+func (c locatedClock) Now() time.Time {
+	return c.Clock.Now().In(c.loc)
+}
+
+// Date returns the time corresponding to the given date and time of day in
+// the location of the view.
+func (c locatedClock) Date(year int, month time.Month, day, hour, min, sec, nsec int) time.Time {
+	return time.Date(year, month, day, hour, min, sec, nsec, c.loc)
+}
+
+// ParseInLocation parses a formatted string, interpreting a time without time
+// zone information in the location of the view.
+func (c locatedClock) ParseInLocation(layout, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, c.loc)
+}
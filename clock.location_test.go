@@ -0,0 +1,110 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a view of a mock clock in a location returns the time of the
+// clock in that location.
+func TestClock_In(t *testing.T) {
+	// arrange
+	tokyo := time.FixedZone("JST", 9*60*60)
+	clock := NewMockClock(AtTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	// act
+	sut := clock.In(tokyo)
+	clock.AdvanceBy(time.Hour)
+
+	// assert
+	test.That(t, sut.Now()).Equals(time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC).In(tokyo))
+	test.That(t, clock.Now().Location()).Equals(time.UTC)
+}
+
+// Tests that timers obtained from a view are those of the underlying clock.
+func TestClock_In_Timers(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := clock.In(time.FixedZone("JST", 9*60*60))
+
+	// act
+	timer := sut.NewTimer(time.Second)
+	clock.AdvanceBy(time.Second)
+
+	// assert
+	test.That(t, <-timer.C).Equals(time.Unix(1, 0).UTC())
+}
+
+// Tests that a view of a view is a view of the underlying clock.
+func TestClock_In_NestedView(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	// act
+	sut := clock.In(time.FixedZone("A", 3600)).In(time.FixedZone("B", 7200))
+
+	// assert
+	lc, ok := sut.(locatedClock)
+	test.IsTrue(t, ok)
+	test.That(t, lc.Clock).Equals(Clock(clock))
+	test.That(t, sut.Now().Location().String()).Equals("B")
+}
+
+func TestSystemClock_In(t *testing.T) {
+	// arrange
+	loc := time.FixedZone("UTC+1", 3600)
+
+	// act
+	result := SystemClock().In(loc).Now()
+
+	// assert
+	test.That(t, result.Location()).Equals(loc)
+}
+
+// Tests that Date and ParseInLocation use the location of the clock.
+func TestClock_Date(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	testcases := []struct {
+		scenario string
+		sut      Clock
+		loc      *time.Location
+	}{
+		{scenario: "system clock", sut: SystemClock(), loc: time.Local},
+		{scenario: "mock clock", sut: NewMockClock(), loc: time.UTC},
+		{scenario: "mock clock in location", sut: NewMockClock(InLocation(tokyo)), loc: tokyo},
+		{scenario: "view", sut: NewMockClock().In(tokyo), loc: tokyo},
+		{scenario: "decorated view", sut: NewOffsetClock(SystemClock().In(tokyo), time.Hour), loc: tokyo},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			date := tc.sut.Date(2024, time.March, 1, 6, 0, 0, 0)
+			parsed, err := tc.sut.ParseInLocation(time.DateTime, "2024-03-01 06:00:00")
+
+			// assert
+			test.That(t, date).Equals(time.Date(2024, time.March, 1, 6, 0, 0, 0, tc.loc))
+			test.Error(t, err).IsNil()
+			test.IsTrue(t, parsed.Equal(date))
+			test.That(t, parsed.Location().String()).Equals(tc.loc.String())
+		})
+	}
+}
+
+// Tests that a location clock of a Clock implemented outside the package is a
+// view of that clock.
+func TestNewLocationClock(t *testing.T) {
+	// arrange
+	type wrapper struct{ Clock }
+	tokyo := time.FixedZone("JST", 9*60*60)
+	base := wrapper{NewMockClock()}
+
+	// act
+	sut := NewLocationClock(base, tokyo)
+
+	// assert
+	test.That(t, sut.Now()).Equals(time.Unix(0, 0).In(tokyo))
+	test.That(t, sut.Date(2024, 1, 1, 6, 0, 0, 0)).Equals(time.Date(2024, 1, 1, 6, 0, 0, 0, tokyo))
+}
@@ -0,0 +1,72 @@
+package time
+
+import (
+	"context"
+	"time"
+)
+
+// ClockMiddleware is a function that decorates a Clock, returning a Clock that
+// modifies or instruments the behaviour of the decorated clock.
+//
+// Middleware may be composed using Chain and applied to a clock in a context
+// using ContextWithClockMiddleware.
+type ClockMiddleware func(Clock) Clock
+
+// Chain composes middleware into a single ClockMiddleware.  The first
+// middleware is the outermost decorator; that is, Chain(a, b)(clock) is
+// equivalent to a(b(clock)).
+//
+// Chain with no middleware returns middleware that returns the clock
+// unchanged.
+func Chain(middleware ...ClockMiddleware) ClockMiddleware {
+	return func(c Clock) Clock {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			c = middleware[i](c)
+		}
+		return c
+	}
+}
+
+// ContextWithClockMiddleware returns a new context containing a given clock,
+// decorated by the given middleware (applied as for Chain).  If the clock is
+// nil the system clock is decorated.
+//
+// If the context already contains a clock the function panics with
+// ErrClockAlreadyExists.
+func ContextWithClockMiddleware(ctx context.Context, c Clock, middleware ...ClockMiddleware) context.Context {
+	if c == nil {
+		c = SystemClock()
+	}
+	return ContextWithClock(ctx, Chain(middleware...)(c))
+}
+
+// Located returns middleware providing a view of a clock in a given location
+// (see: Clock.In).
+func Located(loc *time.Location) ClockMiddleware {
+	return func(c Clock) Clock { return c.In(loc) }
+}
+
+// MonitorCallbacks returns middleware reporting functions called by the timers
+// of a clock that take longer than a threshold to return (see:
+// NewCallbackMonitor).
+func MonitorCallbacks(threshold time.Duration, report func(SlowCallback)) ClockMiddleware {
+	return func(c Clock) Clock { return NewCallbackMonitor(c, threshold, report) }
+}
+
+// Offset returns middleware offsetting the current time of a clock by a given
+// duration (see: NewOffsetClock).
+func Offset(d time.Duration) ClockMiddleware {
+	return func(c Clock) Clock { return NewOffsetClock(c, d) }
+}
+
+// Quantized returns middleware truncating the current time of a clock to a
+// given resolution (see: NewQuantizedClock).
+func Quantized(resolution time.Duration) ClockMiddleware {
+	return func(c Clock) Clock { return NewQuantizedClock(c, resolution) }
+}
+
+// Slow returns middleware consuming a given duration of real time on each
+// call to Now of a clock (see: NewSlowClock).
+func Slow(latency time.Duration) ClockMiddleware {
+	return func(c Clock) Clock { return NewSlowClock(c, latency) }
+}
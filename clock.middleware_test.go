@@ -0,0 +1,72 @@
+package time
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestChain(t *testing.T) {
+	// arrange
+	var order []string
+	record := func(name string) ClockMiddleware {
+		return func(c Clock) Clock {
+			order = append(order, name)
+			return c
+		}
+	}
+
+	// act
+	_ = Chain(record("a"), record("b"), record("c"))(NewMockClock())
+
+	// assert: the first middleware is the outermost, so is applied last
+	test.Slice(t, order).Equals([]string{"c", "b", "a"})
+}
+
+func TestChain_NoMiddleware(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	// act
+	result := Chain()(clock)
+
+	// assert
+	test.That(t, result).Equals(Clock(clock))
+}
+
+// Tests that the order of middleware determines the result: quantizing
+// an offset clock differs from offsetting a quantized clock.
+func TestChain_Order(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Unix(90, 0)))
+
+	// act
+	quantizedOffset := Chain(Quantized(time.Minute), Offset(time.Minute/2))(clock)
+	offsetQuantized := Chain(Offset(time.Minute/2), Quantized(time.Minute))(clock)
+
+	// assert
+	test.That(t, quantizedOffset.Now()).Equals(time.Unix(120, 0).UTC())
+	test.That(t, offsetQuantized.Now()).Equals(time.Unix(90, 0).UTC())
+}
+
+func TestContextWithClockMiddleware(t *testing.T) {
+	// arrange
+	loc := time.FixedZone("UTC+1", 3600)
+	clock := NewMockClock(AtTime(time.Unix(90, 0)))
+
+	// act
+	ctx := ContextWithClockMiddleware(context.Background(), clock, Located(loc), Quantized(time.Minute))
+
+	// assert
+	test.That(t, Now(ctx)).Equals(time.Unix(60, 0).In(loc))
+}
+
+func TestContextWithClockMiddleware_NilClock(t *testing.T) {
+	// act
+	ctx := ContextWithClockMiddleware(context.Background(), nil, Offset(Day))
+
+	// assert
+	test.IsTrue(t, Now(ctx).Sub(time.Now()) > Day-time.Minute)
+}
@@ -0,0 +1,87 @@
+package time
+
+import (
+	"sync"
+	"time"
+)
+
+// MonotonicClock is a Clock for which Now never returns a time earlier than a
+// time it has previously returned.  If the current time of the base clock is
+// earlier than the latest time returned (the wall clock has been set back),
+// Now returns the latest time, recording the clamp.
+//
+// This may be used where the ordering of timestamps must be consistent with
+// the order in which events occur, e.g. for event logs or versioning.  Since
+// and Until are consistent with Now; timers, tickers, sleeps and contexts are
+// those of the base clock.
+type MonotonicClock struct {
+	Clock
+	mu       sync.Mutex
+	last     time.Time
+	clamps   int
+	maxClamp time.Duration
+}
+
+// MonotonicNow returns a MonotonicClock for which the current time is that of
+// a base clock (or the system clock, if nil), never decreasing.
+func MonotonicNow(base Clock) *MonotonicClock {
+	if base == nil {
+		base = SystemClock()
+	}
+	return &MonotonicClock{Clock: base}
+}
+
+// In returns a view of the monotonic clock in a given location.
+func (c *MonotonicClock) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Close closes the base clock (see: CloseClock).
+func (c *MonotonicClock) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// Now returns the current time of the base clock or, if that is earlier than
+// the latest time returned by the clock, the latest time.
+func (c *MonotonicClock) Now() time.Time {
+	now := c.Clock.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Before(c.last) {
+		c.clamps++
+		c.maxClamp = max(c.maxClamp, c.last.Sub(now))
+		return c.last
+	}
+	c.last = now
+	return now
+}
+
+// Since returns the duration since t, according to the monotonic current time.
+func (c *MonotonicClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t, according to the monotonic current time.
+func (c *MonotonicClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// Clamps returns the number of times that Now has returned the latest time
+// because the current time of the base clock was earlier.
+func (c *MonotonicClock) Clamps() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.clamps
+}
+
+// MaxClamp returns the greatest duration by which the current time of the
+// base clock has been earlier than the latest time returned by Now.
+func (c *MonotonicClock) MaxClamp() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.maxClamp
+}
@@ -0,0 +1,53 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// rewindableClock is a Clock for which the current time is offset from that of
+// a mock clock by an offset that may be changed, so that the clock may be set
+// back.
+type rewindableClock struct {
+	MockClock
+	offset time.Duration
+}
+
+func (c *rewindableClock) Now() time.Time { return c.MockClock.Now().Add(c.offset) }
+
+func TestMonotonicNow(t *testing.T) {
+	// arrange
+	base := &rewindableClock{MockClock: NewMockClock()}
+	sut := MonotonicNow(base)
+
+	// act
+	base.AdvanceBy(time.Hour)
+	before := sut.Now()
+	base.offset = -10 * time.Minute
+	clamped := []time.Time{sut.Now()}
+	base.AdvanceBy(5 * time.Minute)
+	clamped = append(clamped, sut.Now())
+	base.AdvanceBy(10 * time.Minute)
+	after := sut.Now()
+
+	// assert
+	test.That(t, before).Equals(time.Unix(3600, 0).UTC())
+	test.That(t, clamped).Equals([]time.Time{before, before})
+	test.That(t, after).Equals(time.Unix(3900, 0).UTC())
+	test.That(t, sut.Clamps()).Equals(2)
+	test.That(t, sut.MaxClamp()).Equals(10 * time.Minute)
+	test.That(t, sut.Since(time.Unix(3600, 0))).Equals(5 * time.Minute)
+	test.That(t, sut.Until(time.Unix(4000, 0))).Equals(100 * time.Second)
+	test.That(t, sut.In(time.UTC).Now()).Equals(after)
+}
+
+func TestMonotonicNow_NilClock(t *testing.T) {
+	// act
+	sut := MonotonicNow(nil)
+
+	// assert
+	test.That(t, sut.Clock).Equals(SystemClock())
+	test.IsFalse(t, sut.Now().After(sut.Now()))
+}
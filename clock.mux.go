@@ -0,0 +1,328 @@
+package time
+
+import (
+	"container/heap"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TimerMux is a Clock which multiplexes the timers created by its After,
+// AfterFunc, NewTimer and NewTimerNamed methods onto a single timer of a base
+// clock for each of a number of shards.  The timers of each shard are held in
+// a heap ordered by expiry, with the timer of the base clock set to expire
+// when the earliest of them is due.
+//
+// This may be used by a service managing a very large number of timers (e.g.
+// hundreds of thousands of per-connection timeouts, most of which are reset or
+// stopped before they expire) where a timer of the runtime for each would be
+// too costly.  Timers are distributed across the shards to reduce contention.
+//
+// The timers obtained from a TimerMux have the same behaviour as those of the
+// base clock: a function called by a timer created by AfterFunc is called in
+// its own goroutine and, as for go 1.23+, no stale time is received from the
+// channel of a timer after it has been stopped or reset.  If the base clock is
+// a mock clock, the timers expire as the mock clock is advanced.
+//
+// Tickers, sleeps and contexts are those of the base clock.
+type TimerMux struct {
+	Clock
+	shards []*muxShard
+	next   atomic.Uint32
+}
+
+// NewTimerMux returns a TimerMux multiplexing timers onto the timers of a base
+// clock (or the system clock, if nil) using a given number of shards.  If
+// shards <= 0, runtime.GOMAXPROCS(0) shards are used.
+func NewTimerMux(base Clock, shards int) *TimerMux {
+	if base == nil {
+		base = SystemClock()
+	}
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+
+	m := &TimerMux{Clock: base, shards: make([]*muxShard, shards)}
+	for i := range m.shards {
+		m.shards[i] = &muxShard{clock: base}
+	}
+	return m
+}
+
+// In returns a view of the multiplexing clock in a given location.
+func (m *TimerMux) In(loc *time.Location) Clock {
+	return inLocation(m, loc)
+}
+
+// After returns a channel that will send the current time after at least
+// duration d, using a multiplexed timer.
+func (m *TimerMux) After(d time.Duration) <-chan time.Time {
+	return m.NewTimer(d).C
+}
+
+// AfterFunc waits for the duration to elapse and then calls f in its own
+// goroutine, using a multiplexed timer.  It returns a Timer that can be used
+// to stop the countdown or to reset the Timer to run at a different time.
+func (m *TimerMux) AfterFunc(d time.Duration, f func()) *Timer {
+	return m.newTimer(d, f)
+}
+
+// NewTimer returns a new multiplexed Timer that will send the current time on
+// its channel after the duration d.
+func (m *TimerMux) NewTimer(d time.Duration) *Timer {
+	return m.newTimer(d, nil)
+}
+
+// NewTimerNamed returns a new multiplexed Timer in the same way as NewTimer;
+// the name has no effect on a multiplexed timer.
+func (m *TimerMux) NewTimerNamed(d time.Duration, _ string) *Timer {
+	return m.newTimer(d, nil)
+}
+
+// Close stops the timers of the base clock used by each shard, discarding any
+// multiplexed timers that have not expired (these will not expire), and closes
+// the base clock (see: CloseClock).
+func (m *TimerMux) Close() error {
+	for _, s := range m.shards {
+		s.close()
+	}
+	return CloseClock(m.Clock)
+}
+
+// Pending returns the number of multiplexed timers that have not yet expired
+// and have not been stopped.
+func (m *TimerMux) Pending() int {
+	n := 0
+	for _, s := range m.shards {
+		s.mu.Lock()
+		n += len(s.timers)
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// newTimer creates a Timer multiplexed on the next shard of the clock; if fn
+// is nil the time is sent on the channel of the Timer when it expires,
+// otherwise fn is called.
+func (m *TimerMux) newTimer(d time.Duration, fn func()) *Timer {
+	s := m.shards[int(m.next.Add(1)-1)%len(m.shards)]
+
+	t := &muxTimer{shard: s, index: -1, fn: fn}
+	if fn == nil {
+		t.c = make(chan time.Time, 1)
+	}
+
+	s.mu.Lock()
+	s.schedule(t, s.clock.Now().Add(d))
+	s.mu.Unlock()
+
+	// the time.Timer is not initialised and is not used for timing purposes;
+	// it provides a read-only reference to the channel of the timer
+	return &Timer{Timer: &time.Timer{C: t.c}, mux: t, initialised: true}
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// muxShard holds the multiplexed timers of a shard of a TimerMux, with a timer
+// of the base clock set to expire when the earliest of them is due.
+type muxShard struct {
+	clock  Clock
+	mu     sync.Mutex
+	timers muxHeap
+	timer  *Timer    // the timer of the base clock; nil until first required
+	armed  bool      // true if the timer of the base clock is set to expire
+	due    time.Time // the time at which the timer of the base clock expires
+}
+
+// schedule adds a timer to the heap of the shard, to expire at a given time,
+// setting the timer of the base clock to expire earlier if required.
+//
+// This method is not thread-safe and should only be called while the shard is
+// locked.
+func (s *muxShard) schedule(t *muxTimer, due time.Time) {
+	t.due = due
+	heap.Push(&s.timers, t)
+	s.arm()
+}
+
+// arm sets the timer of the base clock to expire when the earliest timer of
+// the shard is due, unless it is already set to expire no later than that.
+//
+// A timer of the base clock that expires before any timer of the shard is due
+// (because the earliest has been stopped or reset) does no harm; the timer is
+// then set for the earliest timer that remains.
+//
+// This method is not thread-safe and should only be called while the shard is
+// locked.
+func (s *muxShard) arm() {
+	if len(s.timers) == 0 {
+		return
+	}
+
+	next := s.timers[0].due
+	if s.armed && !next.Before(s.due) {
+		return
+	}
+
+	s.armed, s.due = true, next
+	d := max(s.clock.Until(next), 0)
+	if s.timer == nil {
+		s.timer = s.clock.AfterFunc(d, s.expire)
+		return
+	}
+	_ = s.timer.Reset(d)
+}
+
+// expire is called by the timer of the base clock; any timers of the shard
+// that are due are removed and expired and the timer of the base clock is set
+// to expire when the next timer is due.
+func (s *muxShard) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	for len(s.timers) > 0 && !s.timers[0].due.After(now) {
+		heap.Pop(&s.timers).(*muxTimer).expire(now)
+	}
+
+	s.armed = false
+	s.arm()
+}
+
+// close stops the timer of the base clock and discards the timers of the
+// shard.
+func (s *muxShard) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.timers {
+		t.index = -1
+	}
+	s.timers, s.armed = nil, false
+	if s.timer != nil {
+		_ = s.timer.Stop()
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// muxTimer implements the behaviour of a Timer obtained from a TimerMux.
+type muxTimer struct {
+	shard *muxShard
+	due   time.Time
+	index int // the index of the timer in the heap of the shard; -1 if not scheduled
+	c     chan time.Time
+	fn    func()
+}
+
+// expire sends the time on the channel of the timer (if the channel is not
+// already holding a time) or calls the function of the timer in its own
+// goroutine.
+//
+// This method is not thread-safe and should only be called while the shard is
+// locked.
+func (t *muxTimer) expire(now time.Time) {
+	if t.fn != nil {
+		go t.fn()
+		return
+	}
+
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+// drain discards any time that has been sent on the channel of the timer and
+// not received.
+//
+// This method is not thread-safe and should only be called while the shard is
+// locked.
+func (t *muxTimer) drain() {
+	if t.c == nil {
+		return
+	}
+
+	select {
+	case <-t.c:
+	default:
+	}
+}
+
+// reset re-schedules the timer to expire after a duration from the current
+// time, returning true if the timer was scheduled.
+func (t *muxTimer) reset(d time.Duration) bool {
+	s := t.shard
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := t.index >= 0
+	if active {
+		heap.Remove(&s.timers, t.index)
+	}
+	t.drain()
+	s.schedule(t, s.clock.Now().Add(d))
+
+	return active
+}
+
+// stop removes the timer from the heap of the shard, returning true if the
+// timer was scheduled.
+func (t *muxTimer) stop() bool {
+	s := t.shard
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t.drain()
+	if t.index < 0 {
+		return false
+	}
+	heap.Remove(&s.timers, t.index)
+
+	return true
+}
+
+// pause removes the timer from the heap of the shard, returning the duration
+// that remained before the timer was due and true if the timer was scheduled.
+func (t *muxTimer) pause() (time.Duration, bool) {
+	s := t.shard
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.index < 0 {
+		return 0, false
+	}
+	heap.Remove(&s.timers, t.index)
+
+	return s.clock.Until(t.due), true
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// muxHeap is a heap of multiplexed timers ordered by expiry (implementing
+// heap.Interface).
+type muxHeap []*muxTimer
+
+func (h muxHeap) Len() int           { return len(h) }
+func (h muxHeap) Less(i, j int) bool { return h[i].due.Before(h[j].due) }
+
+func (h muxHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *muxHeap) Push(x any) {
+	t := x.(*muxTimer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *muxHeap) Pop() any {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1], t.index = nil, -1
+	*h = old[:n-1]
+	return t
+}
@@ -0,0 +1,134 @@
+package time
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestTimerMux(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewTimerMux(base, 2)
+	timers := make([]*Timer, 0, 100)
+	for i := range 100 {
+		timers = append(timers, sut.NewTimer(time.Duration(i+1)*time.Second))
+	}
+
+	// act
+	base.AdvanceBy(time.Second)
+
+	// assert
+	test.That(t, (<-timers[0].C).Unix()).Equals(int64(1))
+	test.That(t, sut.Pending()).Equals(99)
+	test.That(t, created(base)).Equals(2)
+
+	// act
+	stopped := timers[1].Stop()
+	base.AdvanceBy(2 * time.Second)
+
+	// assert
+	test.IsTrue(t, stopped)
+	test.That(t, (<-timers[2].C).Unix()).Equals(int64(3))
+	test.That(t, sut.Pending()).Equals(97)
+	select {
+	case <-timers[1].C:
+		t.Error("stopped timer expired")
+	default:
+	}
+}
+
+func TestTimerMux_AfterFunc(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewTimerMux(base, 1)
+	called := make(chan struct{})
+	_ = sut.AfterFunc(time.Second, func() { close(called) })
+
+	// act
+	base.AdvanceBy(time.Second)
+
+	// assert
+	<-called
+	test.That(t, sut.Pending()).Equals(0)
+}
+
+func TestTimerMux_Reset(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewTimerMux(base, 1)
+	timer := sut.NewTimer(time.Second)
+
+	// act
+	active := timer.Reset(3 * time.Second)
+	base.AdvanceBy(2 * time.Second)
+
+	// assert
+	test.IsTrue(t, active)
+	select {
+	case <-timer.C:
+		t.Error("timer expired before reset duration")
+	default:
+	}
+
+	// act
+	base.AdvanceBy(time.Second)
+
+	// assert
+	test.That(t, (<-timer.C).Unix()).Equals(int64(3))
+	test.IsFalse(t, timer.Reset(time.Second))
+}
+
+func TestTimerMux_PauseResume(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewTimerMux(base, 1)
+	timer := sut.NewTimer(3 * time.Second)
+	base.AdvanceBy(time.Second)
+
+	// act
+	remaining := timer.Pause()
+	base.AdvanceBy(time.Minute)
+	resumed := timer.Resume()
+	base.AdvanceBy(2 * time.Second)
+
+	// assert
+	test.That(t, remaining).Equals(2 * time.Second)
+	test.IsTrue(t, resumed)
+	test.That(t, (<-timer.C).Unix()).Equals(int64(63))
+}
+
+func TestTimerMux_Close(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewTimerMux(base, 1)
+	_ = sut.NewTimer(time.Second)
+
+	// act
+	err := sut.Close()
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, sut.Pending()).Equals(0)
+	test.That(t, base.Timers()[0].State).Equals("stopped")
+}
+
+// Tests that timers multiplexed on the system clock expire.
+func TestTimerMux_SystemClock(t *testing.T) {
+	// arrange
+	sut := NewTimerMux(nil, 0)
+	n := atomic.Int32{}
+	for i := range 1000 {
+		_ = sut.AfterFunc(time.Duration(i%10)*time.Millisecond, func() { n.Add(1) })
+	}
+
+	// act
+	timer := sut.NewTimer(20 * time.Millisecond)
+	<-timer.C
+
+	// assert
+	test.IsTrue(t, waitFor(func() bool { return n.Load() == 1000 }))
+	test.That(t, sut.Pending()).Equals(0)
+}
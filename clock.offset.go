@@ -0,0 +1,104 @@
+package time
+
+import (
+	"context"
+	"time"
+)
+
+// offsetClock is a Clock for which Now is offset by a fixed duration.
+type offsetClock struct {
+	Clock
+	offset time.Duration
+}
+
+// NewOffsetClock returns a Clock for which Now returns the current time of a
+// base clock offset by a given duration, which may be negative.  Since and
+// Until are consistent with Now; timers, tickers and sleeps are those of the
+// base clock (durations are unaffected by the offset).
+//
+// Contexts with deadlines and timeouts are those of the base clock, with
+// deadlines converted between the offset time and the time of the base clock:
+// the deadline of a context obtained from the clock is reported in the offset
+// time and a context with a deadline of ContextWithDeadline(ctx, Now().Add(d))
+// expires when the base clock has advanced by d.  The deadline of a parent
+// context is assumed to be in the offset time (as it is when obtained from
+// the clock).
+//
+// This may be used to simulate a clock that is ahead of (or behind) the true
+// time, e.g. to test tolerance of clock skew between systems.
+func NewOffsetClock(base Clock, offset time.Duration) Clock {
+	return offsetClock{Clock: base, offset: offset}
+}
+
+// In returns a view of the offset clock in a given location.
+func (c offsetClock) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Close closes the base clock (see: CloseClock).
+func (c offsetClock) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// Now returns the current time of the base clock offset by the offset of
+// the clock.
+func (c offsetClock) Now() time.Time {
+	return c.Clock.Now().Add(c.offset)
+}
+
+// Since returns the duration since t, according to the offset current time.
+func (c offsetClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t, according to the offset current time.
+func (c offsetClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// ContextWithDeadline returns a context with a deadline in the offset time,
+// expiring when the base clock reaches the corresponding time.
+func (c offsetClock) ContextWithDeadline(ctx context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	derived, cancel := c.Clock.ContextWithDeadline(offsetContext{ctx, -c.offset}, d.Add(-c.offset))
+	return offsetContext{derived, c.offset}, cancel
+}
+
+// ContextWithDeadlineCause returns a context with a deadline in the offset
+// time and a cause, expiring when the base clock reaches the corresponding
+// time.
+func (c offsetClock) ContextWithDeadlineCause(ctx context.Context, d time.Time, cause error) (context.Context, context.CancelFunc) {
+	derived, cancel := c.Clock.ContextWithDeadlineCause(offsetContext{ctx, -c.offset}, d.Add(-c.offset), cause)
+	return offsetContext{derived, c.offset}, cancel
+}
+
+// ContextWithTimeout returns a context with a timeout of the base clock, with
+// the deadline of the context reported in the offset time.
+func (c offsetClock) ContextWithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	derived, cancel := c.Clock.ContextWithTimeout(offsetContext{ctx, -c.offset}, d)
+	return offsetContext{derived, c.offset}, cancel
+}
+
+// ContextWithTimeoutCause returns a context with a timeout of the base clock
+// and a cause, with the deadline of the context reported in the offset time.
+func (c offsetClock) ContextWithTimeoutCause(ctx context.Context, d time.Duration, cause error) (context.Context, context.CancelFunc) {
+	derived, cancel := c.Clock.ContextWithTimeoutCause(offsetContext{ctx, -c.offset}, d, cause)
+	return offsetContext{derived, c.offset}, cancel
+}
+
+// offsetContext is a context for which the deadline is offset by a fixed
+// duration, converting the deadline of a context between the time of a base
+// clock and the time of an offset clock.
+type offsetContext struct {
+	context.Context
+	offset time.Duration
+}
+
+// Deadline returns the deadline of the context offset by the offset of the
+// context.
+func (c offsetContext) Deadline() (time.Time, bool) {
+	d, ok := c.Context.Deadline()
+	if !ok {
+		return d, false
+	}
+	return d.Add(c.offset), true
+}
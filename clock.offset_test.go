@@ -0,0 +1,121 @@
+package time
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestOffsetClock(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewOffsetClock(base, -time.Minute)
+
+	// act
+	base.AdvanceBy(time.Hour)
+
+	// assert
+	test.That(t, sut.Now()).Equals(time.Unix(59*60, 0).UTC())
+	test.That(t, sut.Since(time.Unix(0, 0))).Equals(59 * time.Minute)
+	test.That(t, sut.Until(time.Unix(3600, 0))).Equals(time.Minute)
+	test.That(t, sut.In(time.UTC).Now()).Equals(time.Unix(59*60, 0).UTC())
+}
+
+func TestOffsetClock_Contexts(t *testing.T) {
+	type result struct {
+		Remaining time.Duration
+		Err       error
+	}
+
+	testcases := []struct {
+		scenario string
+		exec     func(context.Context, Clock) (context.Context, context.CancelFunc)
+	}{
+		{scenario: "ContextWithDeadline",
+			exec: func(ctx context.Context, c Clock) (context.Context, context.CancelFunc) {
+				return c.ContextWithDeadline(ctx, c.Now().Add(10*time.Second))
+			},
+		},
+		{scenario: "ContextWithDeadlineCause",
+			exec: func(ctx context.Context, c Clock) (context.Context, context.CancelFunc) {
+				return c.ContextWithDeadlineCause(ctx, c.Now().Add(10*time.Second), nil)
+			},
+		},
+		{scenario: "ContextWithTimeout",
+			exec: func(ctx context.Context, c Clock) (context.Context, context.CancelFunc) {
+				return c.ContextWithTimeout(ctx, 10*time.Second)
+			},
+		},
+		{scenario: "ContextWithTimeoutCause",
+			exec: func(ctx context.Context, c Clock) (context.Context, context.CancelFunc) {
+				return c.ContextWithTimeoutCause(ctx, 10*time.Second, nil)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		for _, offset := range []time.Duration{time.Hour, -time.Hour} {
+			t.Run(tc.scenario+"/"+offset.String(), func(t *testing.T) {
+				// arrange
+				base := NewMockClock()
+				sut := NewOffsetClock(base, offset)
+				ctx, cancel := tc.exec(ContextWithClock(context.Background(), sut), sut)
+				defer cancel()
+
+				// act
+				var got []result
+				observe := func() {
+					remaining, _ := DeadlineRemaining(ctx)
+					got = append(got, result{remaining, ctx.Err()})
+				}
+				observe()
+				base.AdvanceBy(9 * time.Second)
+				observe()
+				base.AdvanceBy(2 * time.Second)
+				observe()
+
+				// assert
+				deadline, _ := ctx.Deadline()
+				test.That(t, deadline).Equals(sut.Now().Add(-time.Second))
+				test.That(t, got).Equals([]result{
+					{Remaining: 10 * time.Second},
+					{Remaining: time.Second},
+					{Err: context.DeadlineExceeded},
+				})
+			})
+		}
+	}
+}
+
+// Tests that a context obtained from an offset clock with a deadline later
+// than that of its parent inherits the parent deadline, and that a context
+// with an earlier deadline expires at its own deadline.
+func TestOffsetClock_Contexts_Parent(t *testing.T) {
+	for _, offset := range []time.Duration{time.Hour, -time.Hour} {
+		t.Run(offset.String(), func(t *testing.T) {
+			// arrange
+			base := NewMockClock()
+			sut := NewOffsetClock(base, offset)
+			parent, cancel := sut.ContextWithTimeout(ContextWithClock(context.Background(), sut), 10*time.Second)
+			defer cancel()
+
+			// act
+			later, cancel := sut.ContextWithTimeout(parent, 20*time.Second)
+			defer cancel()
+			earlier, cancel := sut.ContextWithDeadline(parent, sut.Now().Add(5*time.Second))
+			defer cancel()
+
+			// assert
+			pd, _ := parent.Deadline()
+			ld, _ := later.Deadline()
+			ed, _ := earlier.Deadline()
+			test.That(t, ld).Equals(pd)
+			test.That(t, ed).Equals(sut.Now().Add(5 * time.Second))
+
+			base.AdvanceBy(6 * time.Second)
+			test.That(t, earlier.Err()).Equals(context.DeadlineExceeded)
+			test.That(t, parent.Err()).IsNil()
+		})
+	}
+}
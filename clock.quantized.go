@@ -0,0 +1,57 @@
+package time
+
+import (
+	"time"
+)
+
+// quantizedClock is a Clock for which Now is truncated to a resolution.
+type quantizedClock struct {
+	Clock
+	resolution time.Duration
+}
+
+// NewQuantizedClock returns a Clock for which Now returns the current time of
+// a base clock truncated to a given resolution (e.g. a second or a minute).
+// Since and Until are consistent with Now; timers, tickers, sleeps and contexts
+// are those of the base clock.
+//
+// A context with a deadline or timeout expires at the precise time of the
+// base clock, so the duration until the deadline according to Until (or
+// DeadlineRemaining) may exceed the time remaining before the context is done
+// by up to the resolution of the clock.
+//
+// This may be used to generate coarse timestamps or time-based cache keys, or
+// to obtain stable timestamps in snapshot tests.
+//
+// The time is truncated as for time.Time.Truncate; if the resolution is zero
+// or negative the time is returned unchanged (other than stripping any
+// monotonic clock reading).
+func NewQuantizedClock(base Clock, resolution time.Duration) Clock {
+	return quantizedClock{Clock: base, resolution: resolution}
+}
+
+// In returns a view of the quantized clock in a given location.
+func (c quantizedClock) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Close closes the base clock (see: CloseClock).
+func (c quantizedClock) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// Now returns the current time of the base clock truncated to the resolution
+// of the clock.
+func (c quantizedClock) Now() time.Time {
+	return c.Clock.Now().Truncate(c.resolution)
+}
+
+// Since returns the duration since t, according to the quantized current time.
+func (c quantizedClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t, according to the quantized current time.
+func (c quantizedClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
@@ -0,0 +1,77 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestQuantizedClock_Now(t *testing.T) {
+	// arrange
+	base := NewMockClock(AtTime(time.Date(2024, 1, 1, 12, 34, 56, 789, time.UTC)))
+
+	testcases := []struct {
+		scenario   string
+		resolution time.Duration
+		want       time.Time
+	}{
+		{scenario: "second", resolution: time.Second, want: time.Date(2024, 1, 1, 12, 34, 56, 0, time.UTC)},
+		{scenario: "minute", resolution: time.Minute, want: time.Date(2024, 1, 1, 12, 34, 0, 0, time.UTC)},
+		{scenario: "zero", resolution: 0, want: time.Date(2024, 1, 1, 12, 34, 56, 789, time.UTC)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			sut := NewQuantizedClock(base, tc.resolution)
+
+			// act
+			result := sut.Now()
+
+			// assert
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestQuantizedClock_SinceUntil(t *testing.T) {
+	// arrange
+	base := NewMockClock(AtTime(time.Date(2024, 1, 1, 12, 34, 56, 0, time.UTC)))
+	sut := NewQuantizedClock(base, time.Minute)
+	at := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	// act
+	since := sut.Since(at)
+	until := sut.Until(at)
+
+	// assert
+	test.That(t, since).Equals(4 * time.Minute)
+	test.That(t, until).Equals(-4 * time.Minute)
+}
+
+// Tests that timers are those of the base clock.
+func TestQuantizedClock_Timers(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewQuantizedClock(base, time.Minute)
+
+	// act
+	timer := sut.NewTimer(time.Second)
+	base.AdvanceBy(time.Second)
+
+	// assert
+	test.That(t, <-timer.C).Equals(time.Unix(1, 0).UTC())
+}
+
+// Tests that a view of a quantized clock in a location is also quantized.
+func TestQuantizedClock_In(t *testing.T) {
+	// arrange
+	loc := time.FixedZone("UTC+1", 3600)
+	base := NewMockClock(AtTime(time.Date(2024, 1, 1, 12, 34, 56, 0, time.UTC)))
+
+	// act
+	result := NewQuantizedClock(base, time.Minute).In(loc).Now()
+
+	// assert
+	test.That(t, result).Equals(time.Date(2024, 1, 1, 12, 34, 0, 0, time.UTC).In(loc))
+}
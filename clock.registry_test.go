@@ -0,0 +1,61 @@
+package time
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestClockRegistry(t *testing.T) {
+	// arrange
+	billing := NewMockClock()
+	metrics := NewMockClock(AtTime(time.Unix(1000, 0)))
+	sut := &ClockRegistry{}
+
+	// act
+	sut.Set("metrics", metrics)
+	sut.Set("billing", billing)
+	sut.Set("audit", SystemClock())
+	sut.Set("audit", nil)
+
+	// assert
+	test.That(t, sut.Names()).Equals([]string{"billing", "metrics"})
+	test.That(t, sut.Clock("billing")).Equals(billing)
+	test.That(t, sut.Clock("audit")).Equals(SystemClock())
+	_, ok := sut.Lookup("audit")
+	test.IsFalse(t, ok, "audit registered")
+}
+
+// Tests that named clocks in a context are independent of each other and of
+// the unnamed clock in the context.
+func TestNamedClockFromContext(t *testing.T) {
+	// arrange
+	billing := NewMockClock()
+	metrics := NewMockClock()
+	registry := &ClockRegistry{}
+	registry.Set("billing", billing)
+	registry.Set("metrics", metrics)
+	ctx, unnamed := ContextWithMockClock(context.Background())
+	ctx = registry.Context(ctx)
+
+	// act
+	billing.AdvanceBy(time.Hour)
+
+	// assert
+	test.That(t, NamedClockFromContext(ctx, "billing").Now()).Equals(time.Unix(3600, 0).UTC())
+	test.That(t, NamedClockFromContext(ctx, "metrics").Now()).Equals(time.Unix(0, 0).UTC())
+	test.That(t, NamedClockFromContext(ctx, "audit")).Equals(unnamed)
+	test.That(t, NamedClockFromContext(context.Background(), "audit")).Equals(SystemClock())
+}
+
+func TestContextWithNamedClock(t *testing.T) {
+	// arrange
+	ctx := ContextWithNamedClock(context.Background(), "billing", nil)
+	defer test.ExpectPanic(ErrClockAlreadyExists).Assert(t)
+
+	// act/assert
+	test.That(t, NamedClockFromContext(ctx, "billing")).Equals(SystemClock())
+	_ = ContextWithNamedClock(ctx, "billing", NewMockClock())
+}
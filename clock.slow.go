@@ -0,0 +1,56 @@
+package time
+
+import (
+	"time"
+)
+
+// slowClock is a Clock for which each call to Now consumes a fixed duration
+// of real time.
+type slowClock struct {
+	Clock
+	latency time.Duration
+}
+
+// NewSlowClock returns a Clock for which each call to Now (and to Since and
+// Until) suspends the calling goroutine for a given duration of real time
+// before returning the current time of a base clock.  Timers, tickers, sleeps
+// and contexts are those of the base clock.
+//
+// This may be used to simulate a slow clock syscall in a constrained
+// environment, or to surface code paths that call Now excessively in hot
+// loops.  To consume virtual time on each call to Now of a mock clock, use the
+// WithNowLatency option.
+func NewSlowClock(base Clock, latency time.Duration) Clock {
+	return slowClock{Clock: base, latency: latency}
+}
+
+// In returns a view of the slow clock in a given location.
+func (c slowClock) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Close closes the base clock (see: CloseClock).
+func (c slowClock) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// Now returns the current time of the base clock after suspending the calling
+// goroutine for the latency of the clock.
+func (c slowClock) Now() time.Time {
+	if c.latency > 0 {
+		time.Sleep(c.latency)
+	}
+	return c.Clock.Now()
+}
+
+// Since returns the duration since t, according to the current time of the
+// clock.
+func (c slowClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t, according to the current time of the
+// clock.
+func (c slowClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
@@ -0,0 +1,25 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestSlowClock(t *testing.T) {
+	// arrange
+	base := NewMockClock(AtTime(time.Unix(10, 0)))
+	sut := NewSlowClock(base, 10*time.Millisecond)
+	start := time.Now()
+
+	// act
+	result := sut.Now()
+
+	// assert
+	test.That(t, result).Equals(time.Unix(10, 0).UTC())
+	test.IsTrue(t, time.Since(start) >= 10*time.Millisecond)
+	test.That(t, sut.Since(time.Unix(5, 0))).Equals(5 * time.Second)
+	test.That(t, sut.Until(time.Unix(15, 0))).Equals(5 * time.Second)
+	test.That(t, sut.In(time.UTC).Now()).Equals(time.Unix(10, 0).UTC())
+}
@@ -0,0 +1,172 @@
+package time
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultWaitBuckets are the upper bounds of the buckets of a WaitHistogram
+// created without specifying buckets.
+var DefaultWaitBuckets = []time.Duration{
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+	time.Minute,
+	10 * time.Minute,
+	time.Hour,
+}
+
+// WaitBucket is a bucket of a histogram of wait durations.
+type WaitBucket struct {
+	// UpperBound is the (inclusive) upper bound of the durations counted by
+	// the bucket.
+	UpperBound time.Duration
+
+	// Count is the number of waits with a duration no greater than the upper
+	// bound of the bucket (the count is cumulative, including the waits
+	// counted by any buckets with a lower upper bound).
+	Count int
+}
+
+// WaitStats is a snapshot of the durations of the waits requested of a clock
+// obtained from NewWaitHistogram.
+type WaitStats struct {
+	// Count is the number of waits requested.
+	Count int
+
+	// Sum is the total of the durations of the waits requested.
+	Sum time.Duration
+
+	// Max is the longest duration of a wait requested.
+	Max time.Duration
+
+	// Buckets are the buckets of the histogram, in order of upper bound.  A
+	// wait longer than the upper bound of the last bucket is counted only by
+	// Count.
+	Buckets []WaitBucket
+}
+
+// WaitHistogram is a Clock which records a histogram of the durations of the
+// waits requested of it using After, AfterFunc, NewTimer, NewTimerNamed and
+// Sleep; all other methods (including the contexts with deadlines and
+// timeouts) are those of the base clock.  The durations are those requested:
+// a negative duration is recorded as zero and the duration with which a Timer
+// is reset is not recorded.
+//
+// This may be used in a test to assert that code does not request waits that
+// are longer than expected (e.g. no sleeps longer than 5s) or, in a production
+// service, to inform the tuning of timeouts and intervals.
+type WaitHistogram struct {
+	Clock
+	mu      sync.Mutex
+	bounds  []time.Duration
+	counts  []int
+	count   int
+	sum     time.Duration
+	longest time.Duration
+}
+
+// NewWaitHistogram returns a WaitHistogram recording the waits requested of a
+// base clock (or the system clock, if nil) in buckets with given upper bounds
+// (or DefaultWaitBuckets, if none are specified).
+func NewWaitHistogram(base Clock, buckets ...time.Duration) *WaitHistogram {
+	if base == nil {
+		base = SystemClock()
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultWaitBuckets
+	}
+
+	bounds := slices.Clone(buckets)
+	slices.Sort(bounds)
+	bounds = slices.Compact(bounds)
+
+	return &WaitHistogram{Clock: base, bounds: bounds, counts: make([]int, len(bounds))}
+}
+
+// In returns a view of the recording clock in a given location.
+func (c *WaitHistogram) In(loc *time.Location) Clock {
+	return inLocation(c, loc)
+}
+
+// Close closes the base clock (see: CloseClock).
+func (c *WaitHistogram) Close() error {
+	return CloseClock(c.Clock)
+}
+
+// After records the duration and returns the channel of the base clock.
+func (c *WaitHistogram) After(d time.Duration) <-chan time.Time {
+	c.observe(d)
+	return c.Clock.After(d)
+}
+
+// AfterFunc records the duration and returns the timer of the base clock.
+func (c *WaitHistogram) AfterFunc(d time.Duration, f func()) *Timer {
+	c.observe(d)
+	return c.Clock.AfterFunc(d, f)
+}
+
+// NewTimer records the duration and returns the timer of the base clock.
+func (c *WaitHistogram) NewTimer(d time.Duration) *Timer {
+	c.observe(d)
+	return c.Clock.NewTimer(d)
+}
+
+// NewTimerNamed records the duration and returns the timer of the base
+// clock.
+func (c *WaitHistogram) NewTimerNamed(d time.Duration, name string) *Timer {
+	c.observe(d)
+	return c.Clock.NewTimerNamed(d, name)
+}
+
+// Sleep records the duration and sleeps using the base clock.
+func (c *WaitHistogram) Sleep(d time.Duration) {
+	c.observe(d)
+	c.Clock.Sleep(d)
+}
+
+// Stats returns a snapshot of the waits recorded by the clock.
+func (c *WaitHistogram) Stats() WaitStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := WaitStats{
+		Count:   c.count,
+		Sum:     c.sum,
+		Max:     c.longest,
+		Buckets: make([]WaitBucket, len(c.bounds)),
+	}
+	n := 0
+	for i, bound := range c.bounds {
+		n += c.counts[i]
+		stats.Buckets[i] = WaitBucket{UpperBound: bound, Count: n}
+	}
+	return stats
+}
+
+// Reset discards the waits recorded by the clock.
+func (c *WaitHistogram) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.counts)
+	c.count, c.sum, c.longest = 0, 0, 0
+}
+
+// observe records the duration of a wait.
+func (c *WaitHistogram) observe(d time.Duration) {
+	d = max(d, 0)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.count++
+	c.sum += d
+	c.longest = max(c.longest, d)
+	if i, _ := slices.BinarySearch(c.bounds, d); i < len(c.bounds) {
+		c.counts[i]++
+	}
+}
@@ -0,0 +1,63 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestWaitHistogram(t *testing.T) {
+	// arrange
+	base := NewMockClock()
+	sut := NewWaitHistogram(base, time.Second, 10*time.Millisecond, time.Second)
+
+	// act
+	_ = sut.After(5 * time.Millisecond)
+	_ = sut.AfterFunc(10*time.Millisecond, func() {})
+	_ = sut.NewTimer(500 * time.Millisecond)
+	_ = sut.NewTimerNamed(time.Minute, "timeout")
+	sut.Sleep(-time.Second)
+	stats := sut.Stats()
+
+	// assert
+	test.That(t, stats).Equals(WaitStats{
+		Count: 5,
+		Sum:   time.Minute + 515*time.Millisecond,
+		Max:   time.Minute,
+		Buckets: []WaitBucket{
+			{UpperBound: 10 * time.Millisecond, Count: 3},
+			{UpperBound: time.Second, Count: 4},
+		},
+	})
+	test.That(t, len(base.Timers())).Equals(4)
+}
+
+func TestWaitHistogram_DefaultBuckets(t *testing.T) {
+	// arrange
+	sut := NewWaitHistogram(nil)
+
+	// act
+	_ = sut.NewTimer(time.Hour).Stop()
+	stats := sut.Stats()
+
+	// assert
+	test.That(t, len(stats.Buckets)).Equals(len(DefaultWaitBuckets))
+	test.That(t, stats.Buckets[len(stats.Buckets)-1]).Equals(WaitBucket{UpperBound: time.Hour, Count: 1})
+	test.That(t, stats.Buckets[len(stats.Buckets)-2].Count).Equals(0)
+}
+
+func TestWaitHistogram_Reset(t *testing.T) {
+	// arrange
+	sut := NewWaitHistogram(NewMockClock())
+	_ = sut.After(time.Second)
+
+	// act
+	sut.Reset()
+
+	// assert
+	stats := sut.Stats()
+	test.That(t, stats.Count).Equals(0)
+	test.That(t, stats.Max).Equals(time.Duration(0))
+	test.That(t, stats.Buckets[3].Count).Equals(0)
+}
@@ -0,0 +1,182 @@
+package clockctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/blugnu/time"
+)
+
+// retryInterval is the real-time duration for which a Client waits before
+// retrying a failed request to wait for a change in the time of the remote
+// clock.
+const retryInterval = 100 * time.Millisecond
+
+// Client is a Clock following the time of a mock clock exposed by a Server.
+//
+// The Clock is provided by a local mock clock, advanced to the time of the
+// remote clock whenever the remote clock is advanced.  Timers, tickers and
+// context deadlines established using the Client are therefore triggered
+// when the remote clock is advanced, subject to the latency of notification
+// of the change.
+//
+// Sync may be used to synchronise the local clock with the remote clock
+// immediately; when the remote clock is advanced using the Client, the local
+// clock is synchronised before the advance returns.
+type Client struct {
+	// Clock is the local mock clock following the remote clock
+	time.Clock
+	local time.MockClock
+
+	url  string
+	http *http.Client
+
+	// mu serialises changes to the local clock
+	mu sync.Mutex
+
+	// cancel stops the goroutine following the remote clock; done is closed
+	// when the goroutine has stopped
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Dial returns a Client following the clock exposed by a Server at the given
+// base URL.  Any options are applied to the local mock clock (the initial time
+// of the local clock is always set to the time of the remote clock).
+//
+// The Client must be closed when no longer required.
+func Dial(ctx context.Context, baseURL string, opts ...time.ClockOption) (*Client, error) {
+	c := &Client{
+		url:  strings.TrimSuffix(baseURL, "/"),
+		http: http.DefaultClient,
+		done: make(chan struct{}),
+	}
+
+	now, err := c.request(ctx, http.MethodGet, "/now", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.local = time.NewMockClock(append(opts, time.AtTime(now))...)
+	c.Clock = c.local
+
+	ctx, c.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	go c.follow(ctx)
+
+	return c, nil
+}
+
+// AdvanceBy advances the remote clock by the given duration.
+func (c *Client) AdvanceBy(ctx context.Context, d time.Duration) error {
+	return c.advance(ctx, advanceRequest{By: d.String()})
+}
+
+// AdvanceTo advances the remote clock to the given time.
+func (c *Client) AdvanceTo(ctx context.Context, t time.Time) error {
+	return c.advance(ctx, advanceRequest{To: &t})
+}
+
+// Close stops the Client following the remote clock.  The local clock is not
+// advanced by any further changes to the remote clock.
+func (c *Client) Close() error {
+	c.cancel()
+	<-c.done
+	return nil
+}
+
+// Sync advances the local clock to the current time of the remote clock.
+func (c *Client) Sync(ctx context.Context) error {
+	now, err := c.request(ctx, http.MethodGet, "/now", nil)
+	if err != nil {
+		return err
+	}
+	c.update(now)
+	return nil
+}
+
+// advance makes a request to advance the remote clock and synchronises the
+// local clock with the time returned.
+func (c *Client) advance(ctx context.Context, req advanceRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	now, err := c.request(ctx, http.MethodPost, "/advance", body)
+	if err != nil {
+		return err
+	}
+	c.update(now)
+	return nil
+}
+
+// follow waits for changes in the time of the remote clock, advancing the local
+// clock accordingly, until the given context is cancelled.
+func (c *Client) follow(ctx context.Context) {
+	defer close(c.done)
+
+	for {
+		after := c.local.Now().Format(time.RFC3339Nano)
+		now, err := c.request(ctx, http.MethodGet, "/wait?after="+url.QueryEscape(after), nil)
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.SystemClock().After(retryInterval):
+			}
+		default:
+			c.update(now)
+		}
+	}
+}
+
+// update advances the local clock to a given time, if later than the current
+// time of the local clock.
+func (c *Client) update(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t.After(c.local.Now()) {
+		c.local.AdvanceTo(t)
+	}
+}
+
+// request makes a request to the server, returning the time of the remote
+// clock from the response.
+func (c *Client) request(ctx context.Context, method, path string, body []byte) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return time.Time{}, fmt.Errorf("%w: %s %s: %s: %s", ErrRequestFailed, method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result timeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return time.Time{}, err
+	}
+	return result.Now, nil
+}
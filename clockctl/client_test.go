@@ -0,0 +1,99 @@
+package clockctl
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+// Tests that a Client follows the time of the remote clock, firing local
+// timers when the remote clock is advanced by another client.
+func TestClient(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	srv := NewServer(time.NewMockClock(time.AtTime(time.Unix(100, 0))))
+	hs := httptest.NewServer(srv)
+	defer hs.Close()
+
+	driver, err := Dial(ctx, hs.URL)
+	test.Error(t, err).IsNil()
+	defer driver.Close()
+
+	follower, err := Dial(ctx, hs.URL)
+	test.Error(t, err).IsNil()
+	defer follower.Close()
+
+	var fired atomic.Bool
+	follower.AfterFunc(10*time.Second, func() { fired.Store(true) })
+
+	// act
+	err = driver.AdvanceBy(ctx, 10*time.Second)
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, driver.Now()).Equals(time.Unix(110, 0).UTC())
+
+	deadline := time.SystemClock().Now().Add(time.Second)
+	for !fired.Load() && time.SystemClock().Now().Before(deadline) {
+		time.SystemClock().Sleep(time.Millisecond)
+	}
+	test.IsTrue(t, fired.Load(), "follower timer fired")
+}
+
+// Tests that Sync advances the local clock to the time of the remote clock.
+func TestClient_Sync(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	srv := NewServer(time.NewMockClock())
+	hs := httptest.NewServer(srv)
+	defer hs.Close()
+
+	client, err := Dial(ctx, hs.URL)
+	test.Error(t, err).IsNil()
+	defer client.Close()
+
+	test.Error(t, srv.AdvanceTo(time.Unix(60, 0))).IsNil()
+
+	// act
+	err = client.Sync(ctx)
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, client.Now()).Equals(time.Unix(60, 0).UTC())
+}
+
+// Tests that errors from the server are returned by the client.
+func TestClient_AdvanceTo_Backwards(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	hs := httptest.NewServer(NewServer(time.NewMockClock(time.AtTime(time.Unix(60, 0)))))
+	defer hs.Close()
+
+	client, err := Dial(ctx, hs.URL)
+	test.Error(t, err).IsNil()
+	defer client.Close()
+
+	// act
+	err = client.AdvanceTo(ctx, time.Unix(0, 0))
+
+	// assert
+	test.Error(t, err).Is(ErrRequestFailed)
+}
+
+// Tests that Dial returns an error if the server cannot be reached.
+func TestDial_Error(t *testing.T) {
+	// arrange
+	hs := httptest.NewServer(NewServer(time.NewMockClock()))
+	hs.Close()
+
+	// act
+	client, err := Dial(context.Background(), hs.URL)
+
+	// assert
+	test.IsNil(t, client)
+	test.IsNotNil(t, err)
+}
@@ -0,0 +1,9 @@
+package clockctl
+
+import "errors"
+
+var (
+	ErrInvalidFixture = errors.New("invalid fixture")
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrRequestFailed  = errors.New("request failed")
+)
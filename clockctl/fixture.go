@@ -0,0 +1,215 @@
+package clockctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/blugnu/time"
+)
+
+// pollInterval is the real-time interval at which a FixtureClock reads its
+// fixture file for changes.
+const pollInterval = 10 * time.Millisecond
+
+// Fixture is a time fixture, shared by the processes of an integration test
+// through a file (see: WriteFixture and FollowFixture), determining the time
+// of a fake clock.
+//
+// The time of the fixture is the base time plus the offset, advancing from
+// the (real) time at which the fixture was written at the given speed, as a
+// multiple of real time.  A fixture with a speed of zero is frozen.
+type Fixture struct {
+	// Base is the base time of the fixture.
+	Base time.Time
+
+	// Offset is added to the base time; an offset may be rewritten to move
+	// the time of the fixture without changing the base time.
+	Offset time.Duration
+
+	// Speed is the rate at which the time of the fixture advances, as a
+	// multiple of real time; if zero (or less), the time does not advance.
+	Speed float64
+
+	// Written is the real time at which the fixture was written, from which
+	// its time advances (set by WriteFixture, if zero).
+	Written time.Time
+}
+
+// fixtureFile is the JSON representation of a Fixture in a file.
+type fixtureFile struct {
+	Base    time.Time `json:"base"`
+	Offset  string    `json:"offset"`
+	Speed   float64   `json:"speed"`
+	Written time.Time `json:"written"`
+}
+
+// Now returns the time of the fixture at a given real time.
+func (f Fixture) Now(real time.Time) time.Time {
+	t := f.Base.Add(f.Offset)
+	if f.Speed > 0 {
+		t = t.Add(time.Duration(float64(real.Sub(f.Written)) * f.Speed))
+	}
+	return t
+}
+
+// WriteFixture writes a fixture to a file, replacing any existing file.  If
+// the fixture does not specify the time at which it was written, the current
+// (real) time is used.
+//
+// The file is replaced atomically, so that a process reading the file does not
+// read a partially written fixture.
+func WriteFixture(path string, f Fixture) error {
+	if f.Written.IsZero() {
+		f.Written = time.SystemClock().Now()
+	}
+
+	data, err := json.Marshal(fixtureFile{
+		Base:    f.Base,
+		Offset:  f.Offset.String(),
+		Speed:   f.Speed,
+		Written: f.Written,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ReadFixture reads a fixture from a file.  If the file does not contain a
+// valid fixture an error wrapping ErrInvalidFixture is returned.
+func ReadFixture(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	var file fixtureFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Fixture{}, fmt.Errorf("%w: %s: %w", ErrInvalidFixture, path, err)
+	}
+
+	f := Fixture{Base: file.Base, Speed: file.Speed, Written: file.Written}
+	if file.Offset != "" {
+		if f.Offset, err = time.ParseDuration(file.Offset); err != nil {
+			return Fixture{}, fmt.Errorf("%w: %s: offset: %w", ErrInvalidFixture, path, err)
+		}
+	}
+	return f, nil
+}
+
+// FixtureClock is a Clock following the time of a fixture file (see:
+// FollowFixture).
+//
+// As for a Client, the Clock is provided by a local mock clock, advanced to
+// the time of the fixture as the fixture file is read at intervals.  Timers,
+// tickers and context deadlines established using the clock are therefore
+// triggered as the time of the fixture advances (or is moved forward by
+// rewriting the file), subject to the interval at which the file is read.  The
+// clock never moves backwards: if the time of the fixture is moved back, the
+// clock does not advance until the fixture reaches the time of the clock.
+//
+// Sync may be used to synchronise the local clock with the fixture
+// immediately.
+type FixtureClock struct {
+	// Clock is the local mock clock following the fixture
+	time.Clock
+	local time.MockClock
+
+	path string
+
+	// mu serialises changes to the local clock
+	mu sync.Mutex
+
+	// cancel stops the goroutine following the fixture; done is closed when
+	// the goroutine has stopped
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// FollowFixture returns a FixtureClock following the time of the fixture in a
+// given file.  Any options are applied to the local mock clock (the initial
+// time of the local clock is always set to the time of the fixture).
+//
+// The FixtureClock must be closed when no longer required.
+func FollowFixture(ctx context.Context, path string, opts ...time.ClockOption) (*FixtureClock, error) {
+	f, err := ReadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &FixtureClock{path: path, done: make(chan struct{})}
+	c.local = time.NewMockClock(append(opts, time.AtTime(f.Now(time.SystemClock().Now())))...)
+	c.Clock = c.local
+
+	ctx, c.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	go c.follow(ctx)
+
+	return c, nil
+}
+
+// Close stops the FixtureClock following the fixture.  The local clock is not
+// advanced by any further changes to the fixture.
+func (c *FixtureClock) Close() error {
+	c.cancel()
+	<-c.done
+	return nil
+}
+
+// Sync reads the fixture file, advancing the local clock to the current time
+// of the fixture.
+func (c *FixtureClock) Sync() error {
+	f, err := ReadFixture(c.path)
+	if err != nil {
+		return err
+	}
+	c.update(f.Now(time.SystemClock().Now()))
+	return nil
+}
+
+// follow reads the fixture file at intervals, advancing the local clock
+// accordingly, until the given context is cancelled.  A failure to read the
+// file (e.g. while it is being replaced) is ignored until the next interval.
+func (c *FixtureClock) follow(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.SystemClock().NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.Sync()
+		}
+	}
+}
+
+// update advances the local clock to a given time, if later than the current
+// time of the local clock.
+func (c *FixtureClock) update(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t.After(c.local.Now()) {
+		c.local.AdvanceTo(t)
+	}
+}
@@ -0,0 +1,153 @@
+package clockctl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+func TestFixture_Now(t *testing.T) {
+	// arrange
+	written := time.Unix(1000, 0)
+	sut := Fixture{Base: time.Unix(100, 0), Offset: 10 * time.Second, Written: written}
+
+	testcases := []struct {
+		scenario string
+		speed    float64
+		result   time.Time
+	}{
+		{scenario: "frozen", speed: 0, result: time.Unix(110, 0)},
+		{scenario: "real time", speed: 1, result: time.Unix(112, 0)},
+		{scenario: "double speed", speed: 2, result: time.Unix(114, 0)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			sut.Speed = tc.speed
+			result := sut.Now(written.Add(2 * time.Second))
+
+			// assert
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestWriteFixture(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "time.json")
+	fixture := Fixture{Base: time.Unix(100, 0).UTC(), Offset: time.Minute, Speed: 0.5}
+
+	// act
+	err := WriteFixture(path, fixture)
+
+	// assert
+	test.Error(t, err).IsNil()
+
+	result, err := ReadFixture(path)
+	test.Error(t, err).IsNil()
+	test.That(t, result.Base).Equals(fixture.Base)
+	test.That(t, result.Offset).Equals(fixture.Offset)
+	test.That(t, result.Speed).Equals(fixture.Speed)
+	test.IsFalse(t, result.Written.IsZero(), "written time")
+}
+
+func TestReadFixture(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		content  string
+		err      error
+	}{
+		{scenario: "valid", content: `{"base":"1970-01-01T00:01:40Z","offset":"1m"}`},
+		{scenario: "invalid json", content: `{`, err: ErrInvalidFixture},
+		{scenario: "invalid offset", content: `{"offset":"soon"}`, err: ErrInvalidFixture},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			path := filepath.Join(t.TempDir(), "time.json")
+			test.Error(t, os.WriteFile(path, []byte(tc.content), 0o600)).IsNil()
+
+			// act
+			_, err := ReadFixture(path)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+		})
+	}
+}
+
+// Tests that a FixtureClock follows the time of a fixture file, firing local
+// timers when the fixture is rewritten by another process.
+func TestFixtureClock(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "time.json")
+	test.Error(t, WriteFixture(path, Fixture{Base: time.Unix(100, 0)})).IsNil()
+
+	sut, err := FollowFixture(ctx, path)
+	test.Error(t, err).IsNil()
+	defer sut.Close()
+
+	var fired atomic.Bool
+	sut.AfterFunc(10*time.Second, func() { fired.Store(true) })
+
+	// act
+	err = WriteFixture(path, Fixture{Base: time.Unix(100, 0), Offset: 10 * time.Second})
+
+	// assert
+	test.Error(t, err).IsNil()
+
+	deadline := time.SystemClock().Now().Add(time.Second)
+	for !fired.Load() && time.SystemClock().Now().Before(deadline) {
+		time.SystemClock().Sleep(time.Millisecond)
+	}
+	test.IsTrue(t, fired.Load(), "timer fired")
+	test.IsTrue(t, sut.Now().Equal(time.Unix(110, 0)), "clock follows fixture")
+}
+
+// Tests that Sync advances the local clock to the time of the fixture and
+// that the clock does not move backwards.
+func TestFixtureClock_Sync(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "time.json")
+	test.Error(t, WriteFixture(path, Fixture{Base: time.Unix(100, 0)})).IsNil()
+
+	sut, err := FollowFixture(ctx, path)
+	test.Error(t, err).IsNil()
+	defer sut.Close()
+
+	testcases := []struct {
+		scenario string
+		offset   time.Duration
+		result   time.Time
+	}{
+		{scenario: "forward", offset: time.Minute, result: time.Unix(160, 0)},
+		{scenario: "backward", offset: 0, result: time.Unix(160, 0)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.Error(t, WriteFixture(path, Fixture{Base: time.Unix(100, 0), Offset: tc.offset})).IsNil()
+
+			// act
+			err := sut.Sync()
+
+			// assert
+			test.Error(t, err).IsNil()
+			test.IsTrue(t, sut.Now().Equal(tc.result), "time of clock")
+		})
+	}
+}
+
+func TestFollowFixture_MissingFile(t *testing.T) {
+	// act
+	_, err := FollowFixture(context.Background(), filepath.Join(t.TempDir(), "missing.json"))
+
+	// assert
+	test.Error(t, err).Is(os.ErrNotExist)
+}
@@ -0,0 +1,220 @@
+// Package clockctl provides remote control of a mock clock over HTTP, allowing
+// integration tests spanning several processes to share and drive a single
+// virtual clock.
+//
+// A Server exposes a MockClock in one process; a Client in another process
+// provides a Clock which follows the time of the remote clock and may be used
+// to advance it:
+//
+//	// in the process controlling the clock
+//	srv := clockctl.NewServer(time.NewMockClock())
+//	go http.ListenAndServe(addr, srv)
+//
+//	// in each other process
+//	client, err := clockctl.Dial(ctx, "http://"+addr)
+//	...
+//	ctx = time.ContextWithClock(ctx, client)
+//
+// The control endpoint is intended for use in tests only; it provides no
+// authentication and should not be exposed in production.
+//
+// Alternatively, processes may share a fake time without a network control
+// plane, through a time fixture file written using WriteFixture and followed
+// by a FixtureClock:
+//
+//	// in the process controlling the clock
+//	err := clockctl.WriteFixture(path, clockctl.Fixture{Base: start, Speed: 1})
+//
+//	// in each other process
+//	clock, err := clockctl.FollowFixture(ctx, path)
+package clockctl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/blugnu/time"
+)
+
+// defaultWaitTimeout is the real-time duration for which the server holds a
+// wait request for a change in the time of the clock before responding with
+// the current (unchanged) time.
+const defaultWaitTimeout = 30 * time.Second
+
+// Server is an http.Handler exposing a MockClock for remote control.
+//
+// The following endpoints are provided:
+//
+//	GET  /now                  returns the current time of the clock
+//	POST /advance              advances the clock (request: {"by": "1s"} or {"to": "<RFC3339 time>"})
+//	GET  /wait?after=<RFC3339> returns the time of the clock once it is later than a given time
+//
+// Each endpoint responds with the time of the clock as a JSON object: {"now": "<RFC3339 time>"}.
+//
+// Changes to the time of the clock are notified to waiting clients only when the clock is advanced
+// through the Server (using the /advance endpoint or the AdvanceBy and AdvanceTo methods of the
+// Server).  A clock exposed by a Server should not be advanced directly.
+type Server struct {
+	clock time.MockClock
+
+	// advancing serialises the advances of the clock, so that concurrent
+	// requests to advance the clock are applied in turn
+	advancing sync.Mutex
+
+	mu      sync.Mutex
+	changed chan struct{}
+
+	// waitTimeout is the real-time duration for which a wait request is held
+	waitTimeout time.Duration
+}
+
+// NewServer returns a new Server exposing the given clock.
+func NewServer(clock time.MockClock) *Server {
+	return &Server{
+		clock:       clock,
+		changed:     make(chan struct{}),
+		waitTimeout: defaultWaitTimeout,
+	}
+}
+
+// AdvanceBy advances the clock by the given duration, notifying any waiting clients.
+func (s *Server) AdvanceBy(d time.Duration) error {
+	return s.advance(func() { s.clock.AdvanceBy(d) })
+}
+
+// AdvanceTo advances the clock to the given time, notifying any waiting clients.
+func (s *Server) AdvanceTo(t time.Time) error {
+	return s.advance(func() { s.clock.AdvanceTo(t) })
+}
+
+// Now returns the current time of the clock.
+func (s *Server) Now() time.Time {
+	return s.clock.Now()
+}
+
+// advance calls a function to advance the clock, recovering any panic as an
+// error, and notifies any waiting clients.  Only one advance is made at a
+// time.
+func (s *Server) advance(fn func()) (err error) {
+	s.advancing.Lock()
+	defer s.advancing.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+				return
+			}
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	defer s.notify()
+
+	fn()
+	return nil
+}
+
+// notify wakes any clients waiting for a change in the time of the clock.
+func (s *Server) notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/now" && r.Method == http.MethodGet:
+		s.respond(w, s.clock.Now())
+	case r.URL.Path == "/advance" && r.Method == http.MethodPost:
+		s.serveAdvance(w, r)
+	case r.URL.Path == "/wait" && r.Method == http.MethodGet:
+		s.serveWait(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) serveAdvance(w http.ResponseWriter, r *http.Request) {
+	var req advanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	switch {
+	case req.By != "" && req.To != nil:
+		err = fmt.Errorf("%w: only one of 'by' or 'to' may be specified", ErrInvalidRequest)
+	case req.By != "":
+		var d time.Duration
+		if d, err = time.ParseDuration(req.By); err == nil {
+			err = s.AdvanceBy(d)
+		}
+	case req.To != nil:
+		err = s.AdvanceTo(*req.To)
+	default:
+		err = fmt.Errorf("%w: one of 'by' or 'to' must be specified", ErrInvalidRequest)
+	}
+
+	switch {
+	case errors.Is(err, time.ErrNotADelorean):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		s.respond(w, s.clock.Now())
+	}
+}
+
+func (s *Server) serveWait(w http.ResponseWriter, r *http.Request) {
+	after, err := time.Parse(time.RFC3339Nano, r.URL.Query().Get("after"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	timeout := time.SystemClock().NewTimer(s.waitTimeout)
+	defer timeout.Stop()
+
+	for {
+		s.mu.Lock()
+		changed := s.changed
+		s.mu.Unlock()
+
+		if now := s.clock.Now(); now.After(after) {
+			s.respond(w, now)
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-timeout.C:
+			s.respond(w, s.clock.Now())
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (s *Server) respond(w http.ResponseWriter, now time.Time) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(timeResponse{Now: now})
+}
+
+// advanceRequest is the body of a request to the /advance endpoint.
+type advanceRequest struct {
+	By string     `json:"by,omitempty"`
+	To *time.Time `json:"to,omitempty"`
+}
+
+// timeResponse is the body of a response from any endpoint.
+type timeResponse struct {
+	Now time.Time `json:"now"`
+}
@@ -0,0 +1,141 @@
+package clockctl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+func serve(method, path, body string, srv *Server) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return rec
+}
+
+func TestServer_Now(t *testing.T) {
+	// arrange
+	srv := NewServer(time.NewMockClock(time.AtTime(time.Unix(10, 0))))
+
+	// act
+	rec := serve(http.MethodGet, "/now", "", srv)
+
+	// assert
+	test.That(t, rec.Code).Equals(http.StatusOK)
+	test.That(t, rec.Body.String()).Equals(`{"now":"1970-01-01T00:00:10Z"}` + "\n")
+}
+
+func TestServer_Advance(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		body     string
+		status   int
+		response string
+	}{
+		{scenario: "by", body: `{"by":"5s"}`, status: http.StatusOK, response: `{"now":"1970-01-01T00:00:15Z"}`},
+		{scenario: "to", body: `{"to":"1970-01-01T00:01:00Z"}`, status: http.StatusOK, response: `{"now":"1970-01-01T00:01:00Z"}`},
+		{scenario: "backwards", body: `{"to":"1970-01-01T00:00:00Z"}`, status: http.StatusConflict, response: time.ErrNotADelorean.Error()},
+		{scenario: "by and to", body: `{"by":"5s","to":"1970-01-01T00:01:00Z"}`, status: http.StatusBadRequest, response: "only one of"},
+		{scenario: "neither", body: `{}`, status: http.StatusBadRequest, response: "one of 'by' or 'to' must be specified"},
+		{scenario: "invalid duration", body: `{"by":"five"}`, status: http.StatusBadRequest, response: "invalid duration"},
+		{scenario: "invalid json", body: `{`, status: http.StatusBadRequest, response: "EOF"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			srv := NewServer(time.NewMockClock(time.AtTime(time.Unix(10, 0)), time.Yielding(0)))
+
+			// act
+			rec := serve(http.MethodPost, "/advance", tc.body, srv)
+
+			// assert
+			test.That(t, rec.Code).Equals(tc.status)
+			test.String(t, rec.Body.String()).Contains(tc.response)
+		})
+	}
+}
+
+// Tests that concurrent requests to advance the clock are each applied in
+// full.
+func TestServer_Advance_Concurrent(t *testing.T) {
+	// arrange
+	srv := NewServer(time.NewMockClock())
+	codes := make(chan int)
+
+	// act
+	for range 50 {
+		go func() { codes <- serve(http.MethodPost, "/advance", `{"by":"1s"}`, srv).Code }()
+	}
+
+	// assert
+	for range 50 {
+		test.That(t, <-codes).Equals(http.StatusOK)
+	}
+	test.That(t, srv.Now()).Equals(time.Unix(50, 0).UTC())
+}
+
+func TestServer_NotFound(t *testing.T) {
+	// arrange
+	srv := NewServer(time.NewMockClock())
+
+	// act
+	rec := serve(http.MethodPost, "/now", "", srv)
+
+	// assert
+	test.That(t, rec.Code).Equals(http.StatusNotFound)
+}
+
+func TestServer_Wait(t *testing.T) {
+	t.Run("already later", func(t *testing.T) {
+		// arrange
+		srv := NewServer(time.NewMockClock(time.AtTime(time.Unix(10, 0))))
+
+		// act
+		rec := serve(http.MethodGet, "/wait?after=1970-01-01T00:00:05Z", "", srv)
+
+		// assert
+		test.That(t, rec.Body.String()).Equals(`{"now":"1970-01-01T00:00:10Z"}` + "\n")
+	})
+
+	t.Run("advanced while waiting", func(t *testing.T) {
+		// arrange
+		srv := NewServer(time.NewMockClock(time.Yielding(0)))
+		result := make(chan string)
+		go func() {
+			result <- serve(http.MethodGet, "/wait?after=1970-01-01T00:00:00Z", "", srv).Body.String()
+		}()
+
+		// act
+		time.SystemClock().Sleep(10 * time.Millisecond)
+		test.Error(t, srv.AdvanceBy(time.Second)).IsNil()
+
+		// assert
+		test.That(t, <-result).Equals(`{"now":"1970-01-01T00:00:01Z"}` + "\n")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		// arrange
+		srv := NewServer(time.NewMockClock())
+		srv.waitTimeout = 10 * time.Millisecond
+
+		// act
+		rec := serve(http.MethodGet, "/wait?after=1970-01-01T00:00:00Z", "", srv)
+
+		// assert
+		test.That(t, rec.Body.String()).Equals(`{"now":"1970-01-01T00:00:00Z"}` + "\n")
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		// arrange
+		srv := NewServer(time.NewMockClock())
+
+		// act
+		rec := serve(http.MethodGet, "/wait?after=yesterday", "", srv)
+
+		// assert
+		test.That(t, rec.Code).Equals(http.StatusBadRequest)
+	})
+}
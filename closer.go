@@ -0,0 +1,26 @@
+package time
+
+// Closer is implemented by clocks that hold resources (such as goroutines,
+// tickers or connections) which should be released when the clock is no
+// longer required, or which may otherwise be invalidated.
+//
+// The mock clock and the clock decorators provided by this package implement
+// Closer; closing a decorator releases any resources of the decorator and then
+// closes the decorated clock.  The system clock does not implement Closer.
+type Closer interface {
+	// Close releases the resources of the clock.  The effect of using a
+	// clock after it has been closed depends on the clock.
+	Close() error
+}
+
+// CloseClock closes a clock if it implements Closer, returning any error.  If
+// the clock does not implement Closer, nil is returned.
+//
+// This allows a service to close whichever clock it was configured with when
+// shutting down, and a test to ensure that no goroutines of a clock are leaked.
+func CloseClock(c Clock) error {
+	if closer, ok := c.(Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestCloseClock(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		decorate func(Clock) Clock
+	}{
+		{scenario: "mock clock", decorate: func(c Clock) Clock { return c }},
+		{scenario: "offset clock", decorate: func(c Clock) Clock { return NewOffsetClock(c, time.Hour) }},
+		{scenario: "quantized clock", decorate: func(c Clock) Clock { return NewQuantizedClock(c, time.Second) }},
+		{scenario: "slow clock", decorate: func(c Clock) Clock { return NewSlowClock(c, 0) }},
+		{scenario: "located clock", decorate: func(c Clock) Clock { return c.In(time.UTC) }},
+		{scenario: "callback monitor", decorate: func(c Clock) Clock { return NewCallbackMonitor(c, time.Second, nil) }},
+		{scenario: "monotonic clock", decorate: func(c Clock) Clock { return MonotonicNow(c) }},
+		{scenario: "coarse clock", decorate: func(c Clock) Clock { return NewCoarseClock(c, time.Second) }},
+		{scenario: "timer mux", decorate: func(c Clock) Clock { return NewTimerMux(c, 1) }},
+		{scenario: "wait histogram", decorate: func(c Clock) Clock { return NewWaitHistogram(c) }},
+		{scenario: "deadline audit", decorate: func(c Clock) Clock { return NewDeadlineAudit(c) }},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			base := NewMockClock()
+			sut := tc.decorate(base)
+
+			// act
+			err := CloseClock(sut)
+
+			// assert
+			test.Error(t, err).IsNil()
+			defer test.ExpectPanic(ErrClockClosed).Assert(t)
+			base.AdvanceBy(time.Second)
+		})
+	}
+}
+
+func TestCloseClock_NotACloser(t *testing.T) {
+	// act
+	err := CloseClock(SystemClock())
+
+	// assert
+	test.Error(t, err).IsNil()
+}
+
+func TestCoarseClock_Close(t *testing.T) {
+	// arrange
+	sut := NewCoarseClock(SystemClock(), time.Millisecond)
+
+	// act
+	err := sut.Close()
+	cached := sut.Now()
+	time.Sleep(5 * time.Millisecond)
+
+	// assert: the cached time is no longer refreshed
+	test.Error(t, err).IsNil()
+	test.That(t, sut.Now()).Equals(cached)
+}
@@ -2,20 +2,24 @@ package time
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
 type contextKey int
 
-const clockKey contextKey = iota
+const (
+	clockKey contextKey = iota
+	auditKey            // the DeadlineNode of a context derived by a DeadlineAudit
+)
 
 // ClockFromContext returns the Clock in the given context.
-// If no Clock is in the context the system clock is returned.
+// If no Clock is in the context the default clock is returned (see: Default).
 func ClockFromContext(ctx context.Context) Clock {
 	if clock := TryClockFromContext(ctx); clock != nil {
 		return clock
 	}
-	return SystemClock()
+	return Default()
 }
 
 // TryClockFromContext returns the Clock in the given context or nil
@@ -58,12 +62,34 @@ func ContextWithClock(ctx context.Context, c Clock) context.Context {
 	}
 }
 
+// DetachContext returns a context that is not cancelled when the given context
+// is cancelled, with no deadline, but which carries the values of the given
+// context (as for context.WithoutCancel).
+//
+// The clock of the given context is guaranteed to be carried into the detached
+// context, including the clock of a context with a deadline or timeout obtained
+// directly from a mock clock (which does not otherwise carry the clock as a
+// value), so that background work started by (for example) a request handler
+// continues to use the same, possibly mocked, clock.
+func DetachContext(ctx context.Context) context.Context {
+	clock := TryClockFromContext(ctx)
+	if mc, ok := ctx.(*mockContext); ok && clock == nil {
+		clock = mc.clock
+	}
+
+	detached := context.WithoutCancel(ctx)
+	if clock != nil && TryClockFromContext(detached) == nil {
+		detached = context.WithValue(detached, clockKey, clock)
+	}
+	return detached
+}
+
 // ContextWithDeadline returns a new context with the given deadline. If the given time
 // is in the past, the returned context is already done.
 //
 // The deadline is set using the clock in the given context.  If there is no
-// clock in the context the system clock is used and the result is the same as
-// calling context.WithDeadline.
+// clock in the context the default clock (see: Default) is used; if that is the
+// system clock the result is the same as calling context.WithDeadline.
 //
 // If the context contains a mock clock, the deadline will expire when that
 // mock clock is advanced to the deadline or later.
@@ -77,8 +103,8 @@ func ContextWithDeadline(ctx context.Context, t time.Time) (context.Context, con
 // The cause is used to set the context error.
 //
 // The deadline is set using the clock in the given context.  If there is no
-// clock in the context the system clock is used and the result is the same as
-// calling context.WithDeadlineCause.
+// clock in the context the default clock (see: Default) is used; if that is the
+// system clock the result is the same as calling context.WithDeadlineCause.
 //
 // If the context contains a mock clock, the deadline will expire when that
 // mock clock is advanced to the deadline or later.
@@ -90,8 +116,8 @@ func ContextWithDeadlineCause(ctx context.Context, t time.Time, cause error) (co
 // If the given duration is zero or negative, the returned context is already done.
 //
 // The timeout is set using the clock in the given context.  If there is no
-// clock in the context the system clock is used and the result is the same as
-// calling context.WithTimeout.
+// clock in the context the default clock (see: Default) is used; if that is the
+// system clock the result is the same as calling context.WithTimeout.
 //
 // If the context contains a mock clock, the timeout will expire when that
 // mock clock is advanced by at least the given duration from its current time.
@@ -105,8 +131,8 @@ func ContextWithTimeout(ctx context.Context, d time.Duration) (context.Context,
 // The cause is used to set the context error.
 //
 // The timeout is set using the clock in the given context.  If there is no
-// clock in the context the system clock is used and the result is the same as
-// calling context.WithTimeoutCause.
+// clock in the context the default clock (see: Default) is used; if that is the
+// system clock the result is the same as calling context.WithTimeoutCause.
 //
 // If the context contains a mock clock, the timeout will expire when that
 // mock clock is advanced by at least the given duration from its current time.
@@ -114,3 +140,102 @@ func ContextWithTimeout(ctx context.Context, d time.Duration) (context.Context,
 func ContextWithTimeoutCause(ctx context.Context, d time.Duration, cause error) (context.Context, context.CancelFunc) {
 	return ClockFromContext(ctx).ContextWithTimeoutCause(ctx, d, cause)
 }
+
+// ContextWithDeadlineCancelCause returns a new context with the given deadline
+// and cause (as for ContextWithDeadlineCause) and a function that cancels the
+// context with a given cause (as for context.WithCancelCause).
+//
+// When the deadline is reached the context error is context.DeadlineExceeded
+// and the cause (see: context.Cause) is the given cause (or, if nil,
+// context.DeadlineExceeded).  When cancelled, the context error is
+// context.Canceled and the cause is the cause passed to the cancel function
+// (or, if nil, context.Canceled).  This is the same whether the clock in the
+// context is the system clock or a mock clock.
+func ContextWithDeadlineCancelCause(ctx context.Context, t time.Time, cause error) (context.Context, context.CancelCauseFunc) {
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	ctx, cancel := ContextWithDeadlineCause(ctx, t, cause)
+	return ctx, func(err error) {
+		cancelCause(err)
+		cancel()
+	}
+}
+
+// ContextWithTimeoutCancelCause returns a new context with the given timeout
+// and cause (as for ContextWithTimeoutCause) and a function that cancels the
+// context with a given cause (see: ContextWithDeadlineCancelCause).
+func ContextWithTimeoutCancelCause(ctx context.Context, d time.Duration, cause error) (context.Context, context.CancelCauseFunc) {
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	ctx, cancel := ContextWithTimeoutCause(ctx, d, cause)
+	return ctx, func(err error) {
+		cancelCause(err)
+		cancel()
+	}
+}
+
+// DeadlineRemaining returns the duration remaining until the deadline of the
+// given context, according to the clock in the context, and true; if the
+// context has no deadline it returns zero and false.
+//
+// If the deadline has passed the duration returned is zero.
+//
+// If the context contains a mock clock, the duration is determined by the
+// current time of that mock clock.
+func DeadlineRemaining(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return max(ClockFromContext(ctx).Until(deadline), 0), true
+}
+
+// RequireAtLeast returns an error if the given context does not have at least
+// a given duration remaining before its deadline, according to the clock in
+// the context.  This may be used as a pre-flight check before starting an
+// operation that would be wasted if the deadline expired before it completed.
+//
+// If the context is already done, the context error is returned.  If the
+// context has insufficient time remaining, an error wrapping
+// ErrInsufficientTime is returned.  If the context has no deadline, nil is
+// returned.
+func RequireAtLeast(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	remaining, ok := DeadlineRemaining(ctx)
+	if ok && remaining < d {
+		return fmt.Errorf("%w: %s remaining, %s required", ErrInsufficientTime, remaining, d)
+	}
+	return nil
+}
+
+// ContextWithSoftTimeout returns a new context with a two-stage timeout: the
+// onSoft function is called (in its own goroutine) when the soft timeout
+// elapses and the context is cancelled when the hard timeout elapses.  This
+// may be used to emit a warning (e.g. "request taking too long" telemetry)
+// before an operation is abandoned.
+//
+// onSoft is not called if the context is done before the soft timeout
+// elapses, including when the soft timeout is not less than the hard timeout.
+// To be notified on a channel, close the channel in the onSoft function.
+//
+// The timeouts are driven by the clock in the given context.  If the context
+// contains a mock clock, the soft and hard timeouts elapse when that mock clock
+// is advanced by at least the respective durations from its current time.
+func ContextWithSoftTimeout(ctx context.Context, soft, hard time.Duration, onSoft func()) (context.Context, context.CancelFunc) {
+	clock := ClockFromContext(ctx)
+
+	ctx, cancel := clock.ContextWithTimeout(ctx, hard)
+	if soft >= hard {
+		return ctx, cancel
+	}
+
+	timer := clock.AfterFunc(soft, func() {
+		if ctx.Err() == nil {
+			onSoft()
+		}
+	})
+	_ = context.AfterFunc(ctx, func() { timer.Stop() })
+
+	return ctx, cancel
+}
@@ -197,6 +197,59 @@ func Test_Mocked_Context_String(t *testing.T) {
 	test.Value(t, str).Equals("mock: context.WithDeadline: 500ms: 1970-01-01 00:00:01 +0000 UTC")
 }
 
+// Tests the string representation of mocked contexts in a given format.
+func Test_Mocked_Context_String_WithContextFormat(t *testing.T) {
+	errCause := errors.New("cause")
+
+	testcases := []struct {
+		scenario string
+		format   *ContextFormat
+		cause    error
+		result   string
+	}{
+		{scenario: "default format/with cause",
+			cause:  errCause,
+			result: "mock: context.WithDeadline: 1s: 1970-01-01 00:00:01 +0000 UTC: cause",
+		},
+		{scenario: "custom prefix and layout",
+			format: &ContextFormat{Prefix: "ctx", Layout: time.RFC3339},
+			result: "ctx: 1s: 1970-01-01T00:00:01Z",
+		},
+		{scenario: "no prefix or layout",
+			format: &ContextFormat{},
+			result: "1s: 1970-01-01 00:00:01 +0000 UTC",
+		},
+		{scenario: "cause excluded",
+			format: &ContextFormat{Prefix: "ctx", Layout: time.Kitchen},
+			cause:  errCause,
+			result: "ctx: 1s: 12:00AM",
+		},
+		{scenario: "cause included",
+			format: &ContextFormat{Prefix: "ctx", Layout: time.Kitchen, IncludeCause: true},
+			cause:  errCause,
+			result: "ctx: 1s: 12:00AM: cause",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			opts := []ClockOption{}
+			if tc.format != nil {
+				opts = append(opts, WithContextFormat(*tc.format))
+			}
+			ctx, _ := ContextWithMockClock(context.Background(), opts...)
+			ctx, cancel := ContextWithTimeoutCause(ctx, time.Second, tc.cause)
+			defer cancel()
+
+			// act
+			result := ctx.(fmt.Stringer).String()
+
+			// assert
+			test.Value(t, result).Equals(tc.result)
+		})
+	}
+}
+
 // Tests that a mocked ContextWithDeadline is cancelled when the mock clock is
 // advanced to the deadline.
 func Test_Mocked_ContextWithDeadline(t *testing.T) {
@@ -211,8 +264,8 @@ func Test_Mocked_ContextWithDeadline(t *testing.T) {
 	}
 }
 
-// Tests that a mocked ContextWithDeadlineCause wraps the cause error
-// and is cancelled when the mock clock is advanced to the deadline.
+// Tests that a mocked ContextWithDeadlineCause is cancelled with the cause
+// when the mock clock is advanced to the deadline, as for the standard library.
 func Test_Mocked_ContextWithDeadlineCause(t *testing.T) {
 	cause := errors.New("cause")
 	ctx, m := ContextWithMockClock(context.Background())
@@ -220,14 +273,72 @@ func Test_Mocked_ContextWithDeadlineCause(t *testing.T) {
 	m.AdvanceBy(time.Second)
 	select {
 	case <-ctx.Done():
-		test.Error(t, ctx.Err()).Is(context.DeadlineExceeded)
-		test.Error(t, ctx.Err()).Is(cause)
-		test.String(t, ctx.Err().Error()).Equals("context deadline exceeded: cause")
+		test.That(t, ctx.Err()).Equals(context.DeadlineExceeded)
+		test.That(t, context.Cause(ctx)).Equals(cause)
 	default:
 		t.Error("context was not cancelled")
 	}
 }
 
+// Tests that the error and cause of a mocked context are the same as for a
+// context from the standard library.
+func Test_Mocked_Context_Cause(t *testing.T) {
+	cause := errors.New("cause")
+	parentCause := errors.New("parent cause")
+
+	testcases := []struct {
+		scenario string
+		act      func(MockClock, context.CancelFunc, context.CancelCauseFunc)
+		cause    error
+		err      error
+		result   error
+	}{
+		{scenario: "deadline without cause",
+			act:    func(m MockClock, _ context.CancelFunc, _ context.CancelCauseFunc) { m.AdvanceBy(time.Second) },
+			err:    context.DeadlineExceeded,
+			result: context.DeadlineExceeded,
+		},
+		{scenario: "deadline with cause",
+			act:    func(m MockClock, _ context.CancelFunc, _ context.CancelCauseFunc) { m.AdvanceBy(time.Second) },
+			cause:  cause,
+			err:    context.DeadlineExceeded,
+			result: cause,
+		},
+		{scenario: "cancelled",
+			act:    func(_ MockClock, cancel context.CancelFunc, _ context.CancelCauseFunc) { cancel() },
+			cause:  cause,
+			err:    context.Canceled,
+			result: context.Canceled,
+		},
+		{scenario: "parent cancelled with cause",
+			act:    func(_ MockClock, _ context.CancelFunc, cancel context.CancelCauseFunc) { cancel(parentCause) },
+			cause:  cause,
+			err:    context.Canceled,
+			result: parentCause,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			m := NewMockClock()
+			parent, cancelParent := context.WithCancelCause(context.Background())
+			ctx, cancel := m.ContextWithTimeoutCause(parent, time.Second, tc.cause)
+			child, cancelChild := context.WithCancel(ctx)
+			defer cancelChild()
+
+			// act
+			tc.act(m, cancel, cancelParent)
+			<-child.Done()
+
+			// assert
+			test.That(t, ctx.Err()).Equals(tc.err)
+			test.That(t, context.Cause(ctx)).Equals(tc.result)
+			test.That(t, child.Err()).Equals(tc.err)
+			test.That(t, context.Cause(child)).Equals(tc.result)
+		})
+	}
+}
+
 // Tests that a mocked ContextWithDeadline does nothing when the deadline
 // is later than a deadline in the parent context.
 func Test_Mocked_ContextWithDeadline_LaterThanParent(t *testing.T) {
@@ -352,3 +463,213 @@ func Test_Mocked_ContextWithTimeout(t *testing.T) {
 		t.Error("context was not cancelled")
 	}
 }
+
+// Tests that the remaining duration of a context deadline is determined by
+// the mock clock in the context.
+func TestDeadlineRemaining(t *testing.T) {
+	// arrange
+	ctx, clock := ContextWithMockClock(context.Background())
+	ctx, cancel := ContextWithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	clock.AdvanceBy(4 * time.Second)
+
+	// act
+	remaining, ok := DeadlineRemaining(ctx)
+
+	// assert
+	test.IsTrue(t, ok)
+	test.That(t, remaining).Equals(6 * time.Second)
+}
+
+func TestDeadlineRemaining_NoDeadline(t *testing.T) {
+	// act
+	remaining, ok := DeadlineRemaining(context.Background())
+
+	// assert
+	test.IsFalse(t, ok)
+	test.That(t, remaining).Equals(time.Duration(0))
+}
+
+func TestDeadlineRemaining_Passed(t *testing.T) {
+	// arrange: a deadline in the past (with a clock that has not advanced
+	// to expire it)
+	ctx, clock := ContextWithMockClock(context.Background(), AtTime(time.Unix(100, 0)))
+	ctx, cancel := context.WithDeadline(ctx, clock.Now().Add(-time.Second))
+	defer cancel()
+
+	// act
+	remaining, ok := DeadlineRemaining(ctx)
+
+	// assert
+	test.IsTrue(t, ok)
+	test.That(t, remaining).Equals(time.Duration(0))
+}
+
+func TestRequireAtLeast(t *testing.T) {
+	// arrange
+	ctx, clock := ContextWithMockClock(context.Background())
+	deadlineCtx, cancel := ContextWithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	clock.AdvanceBy(4 * time.Second)
+
+	cancelledCtx, cancelCtx := context.WithCancel(ctx)
+	cancelCtx()
+
+	testcases := []struct {
+		scenario string
+		ctx      context.Context
+		d        time.Duration
+		err      error
+	}{
+		{scenario: "sufficient time", ctx: deadlineCtx, d: 6 * time.Second},
+		{scenario: "insufficient time", ctx: deadlineCtx, d: 7 * time.Second, err: ErrInsufficientTime},
+		{scenario: "no deadline", ctx: ctx, d: time.Hour},
+		{scenario: "cancelled", ctx: cancelledCtx, d: time.Second, err: context.Canceled},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			err := RequireAtLeast(tc.ctx, tc.d)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+		})
+	}
+}
+
+func TestContextWithSoftTimeout(t *testing.T) {
+	// arrange
+	ctx, clock := ContextWithMockClock(context.Background())
+	warned := make(chan struct{})
+
+	// act
+	ctx, cancel := ContextWithSoftTimeout(ctx, 2*time.Second, 5*time.Second, func() { close(warned) })
+	defer cancel()
+	clock.AdvanceBy(2 * time.Second)
+
+	// assert: warned at the soft timeout but not cancelled
+	<-warned
+	test.Error(t, ctx.Err()).IsNil()
+
+	// act
+	clock.AdvanceBy(3 * time.Second)
+
+	// assert: cancelled at the hard timeout
+	<-ctx.Done()
+	test.Error(t, ctx.Err()).Is(context.DeadlineExceeded)
+}
+
+// Tests that the soft timeout callback is not called if the context is
+// cancelled before the soft timeout elapses.
+func TestContextWithSoftTimeout_CancelledBeforeSoft(t *testing.T) {
+	// arrange
+	ctx, clock := ContextWithMockClock(context.Background())
+	var warned atomic.Bool
+	ctx, cancel := ContextWithSoftTimeout(ctx, 2*time.Second, 5*time.Second, func() { warned.Store(true) })
+
+	// act
+	cancel()
+	<-ctx.Done()
+	time.Sleep(time.Millisecond)
+	clock.AdvanceBy(5 * time.Second)
+
+	// assert
+	test.IsFalse(t, warned.Load())
+}
+
+// Tests that the soft timeout callback is not called if the soft timeout is
+// not less than the hard timeout.
+func TestContextWithSoftTimeout_SoftNotBeforeHard(t *testing.T) {
+	// arrange
+	ctx, clock := ContextWithMockClock(context.Background())
+	var warned atomic.Bool
+	ctx, cancel := ContextWithSoftTimeout(ctx, 5*time.Second, 5*time.Second, func() { warned.Store(true) })
+	defer cancel()
+
+	// act
+	clock.AdvanceBy(5 * time.Second)
+	<-ctx.Done()
+	time.Sleep(time.Millisecond)
+
+	// assert
+	test.IsFalse(t, warned.Load())
+}
+
+// Tests that a detached context is not cancelled with its parent and carries
+// the clock of the parent.
+func TestDetachContext(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		ctx      func(MockClock) (context.Context, context.CancelFunc)
+	}{
+		{scenario: "clock in context", ctx: func(m MockClock) (context.Context, context.CancelFunc) {
+			return ContextWithTimeout(ContextWithClock(context.Background(), m), time.Second)
+		}},
+		{scenario: "context from mock clock", ctx: func(m MockClock) (context.Context, context.CancelFunc) {
+			return m.ContextWithTimeout(context.Background(), time.Second)
+		}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock()
+			parent, cancel := tc.ctx(clock)
+
+			// act
+			result := DetachContext(parent)
+			cancel()
+
+			// assert
+			test.Error(t, parent.Err()).Is(context.Canceled)
+			test.Error(t, result.Err()).IsNil()
+			_, hasDeadline := result.Deadline()
+			test.IsFalse(t, hasDeadline)
+			test.That(t, ClockFromContext(result)).Equals(Clock(clock))
+		})
+	}
+}
+
+// Tests that a context with a cancel cause function has the same error and
+// cause with a mock clock as with the system clock.
+func TestContextWithTimeoutCancelCause(t *testing.T) {
+	cause := errors.New("cause")
+	clocks := []struct {
+		scenario string
+		clock    Clock
+	}{
+		{scenario: "system clock", clock: SystemClock()},
+		{scenario: "mock clock", clock: NewMockClock()},
+	}
+	for _, c := range clocks {
+		t.Run(c.scenario, func(t *testing.T) {
+			// arrange
+			ctx := ContextWithClock(context.Background(), c.clock)
+			ctx, cancel := ContextWithTimeoutCancelCause(ctx, time.Hour, nil)
+
+			// act
+			cancel(cause)
+
+			// assert
+			test.That(t, ctx.Err()).Equals(context.Canceled)
+			test.That(t, context.Cause(ctx)).Equals(cause)
+		})
+	}
+}
+
+// Tests that a context with a cancel cause function reports the deadline cause
+// when the deadline is reached.
+func TestContextWithDeadlineCancelCause_Deadline(t *testing.T) {
+	// arrange
+	cause := errors.New("cause")
+	ctx, m := ContextWithMockClock(context.Background())
+	ctx, cancel := ContextWithDeadlineCancelCause(ctx, m.Now().Add(time.Second), cause)
+	defer cancel(nil)
+
+	// act
+	m.AdvanceBy(time.Second)
+	<-ctx.Done()
+
+	// assert
+	test.That(t, ctx.Err()).Equals(context.DeadlineExceeded)
+	test.That(t, context.Cause(ctx)).Equals(cause)
+}
@@ -0,0 +1,124 @@
+package time
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Countdown counts down to a deadline according to a clock, reporting
+// progress at milestones: fractions of the time from the start of the
+// countdown to the deadline (e.g. 0.5 when half of the time has elapsed, 1 at
+// the deadline).
+//
+// Progress is reported on a channel (C) for the milestones with which the
+// countdown was created, and by calling functions registered for any
+// milestone using OnMilestone or OnExpiry.
+//
+// A Countdown should be stopped if no longer required before the deadline.
+type Countdown struct {
+	// C is the channel on which the fraction of each milestone with which the
+	// countdown was created is sent when the milestone is reached; the
+	// channel is closed when the deadline is reached.  The channel is
+	// buffered so that progress is not lost if not received promptly.
+	C <-chan float64
+
+	mu       sync.Mutex
+	clock    Clock
+	start    time.Time
+	deadline time.Time
+	timers   []*Timer
+	stopped  bool
+}
+
+// NewCountdown returns a Countdown to a given deadline starting at the current
+// time of a given clock (or the system clock, if nil), reporting progress on
+// its channel at given milestones, in addition to the deadline.  Milestones
+// outside the range 0 to 1 are ignored.
+func NewCountdown(clock Clock, deadline time.Time, milestones ...float64) *Countdown {
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	milestones = slices.DeleteFunc(slices.Clone(milestones), func(f float64) bool { return f < 0 || f >= 1 })
+	slices.Sort(milestones)
+	milestones = slices.Compact(milestones)
+
+	c := make(chan float64, len(milestones)+1)
+	cd := &Countdown{C: c, clock: clock, start: clock.Now(), deadline: deadline}
+
+	// progress is sent by a chain of timers, each scheduled when the previous
+	// milestone is reached, so that milestones are sent in order
+	fractions := append(milestones, 1)
+	var next func(i int)
+	next = func(i int) {
+		cd.OnMilestone(fractions[i], func() {
+			c <- fractions[i]
+			if i == len(fractions)-1 {
+				close(c)
+				return
+			}
+			next(i + 1)
+		})
+	}
+	next(0)
+
+	return cd
+}
+
+// Deadline returns the deadline of the countdown.
+func (cd *Countdown) Deadline() time.Time {
+	return cd.deadline
+}
+
+// Remaining returns the time remaining until the deadline, or zero if the
+// deadline has passed.
+func (cd *Countdown) Remaining() time.Duration {
+	return max(cd.clock.Until(cd.deadline), 0)
+}
+
+// Progress returns the fraction of the time from the start of the countdown
+// to the deadline that has elapsed, from 0 to 1.
+func (cd *Countdown) Progress() float64 {
+	total := cd.deadline.Sub(cd.start)
+	if total <= 0 {
+		return 1
+	}
+	return min(float64(cd.clock.Since(cd.start))/float64(total), 1)
+}
+
+// OnMilestone registers a function to be called, on its own goroutine, when a
+// given fraction of the time from the start of the countdown to the deadline
+// has elapsed.  If the milestone has already been reached the function is
+// called immediately.  The fraction is limited to the range 0 to 1.
+func (cd *Countdown) OnMilestone(fraction float64, fn func()) {
+	fraction = min(max(fraction, 0), 1)
+	at := cd.start.Add(time.Duration(fraction * float64(cd.deadline.Sub(cd.start))))
+
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+
+	if cd.stopped {
+		return
+	}
+	cd.timers = append(cd.timers, cd.clock.AfterFunc(cd.clock.Until(at), fn))
+}
+
+// OnExpiry registers a function to be called, on its own goroutine, when the
+// deadline is reached.
+func (cd *Countdown) OnExpiry(fn func()) {
+	cd.OnMilestone(1, fn)
+}
+
+// Stop stops the countdown; no further progress is reported and the channel
+// is not closed.
+func (cd *Countdown) Stop() {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+
+	cd.stopped = true
+	for _, t := range cd.timers {
+		t.Stop()
+	}
+	cd.timers = nil
+}
@@ -0,0 +1,76 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that progress is sent on the channel at each milestone and that the
+// channel is closed at the deadline.
+func TestCountdown(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = NewCountdown(clock, time.Unix(100, 0), 0.9, 0.5, 2)
+	)
+
+	// act
+	clock.AdvanceBy(50 * time.Second)
+	half := <-sut.C
+	remaining := sut.Remaining()
+	progress := sut.Progress()
+	waitFor(func() bool { return created(clock) > 1 })
+	clock.AdvanceBy(50 * time.Second)
+
+	// assert
+	test.That(t, half).Equals(0.5)
+	test.That(t, remaining).Equals(50 * time.Second)
+	test.That(t, progress).Equals(0.5)
+
+	result := []float64{}
+	for f := range sut.C {
+		result = append(result, f)
+	}
+	test.That(t, result).Equals([]float64{0.9, 1})
+	test.That(t, sut.Remaining()).Equals(time.Duration(0))
+}
+
+// Tests that milestone functions are called when the milestone is reached.
+func TestCountdown_OnMilestone(t *testing.T) {
+	// arrange
+	var (
+		clock   = NewMockClock()
+		sut     = NewCountdown(clock, time.Unix(10, 0))
+		reached = make(chan time.Time, 2)
+	)
+	sut.OnMilestone(0.25, func() { reached <- clock.Now() })
+	sut.OnExpiry(func() { reached <- clock.Now() })
+
+	// act
+	clock.AdvanceBy(10 * time.Second)
+
+	// assert
+	test.That(t, <-reached).Equals(time.Unix(2, int64(500*time.Millisecond)).UTC())
+	test.That(t, <-reached).Equals(time.Unix(10, 0).UTC())
+}
+
+// Tests that a stopped countdown reports no further progress.
+func TestCountdown_Stop(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock()
+		sut    = NewCountdown(clock, time.Unix(10, 0), 0.5)
+		called = make(chan struct{}, 1)
+	)
+	sut.OnExpiry(func() { called <- struct{}{} })
+
+	// act
+	sut.Stop()
+	clock.AdvanceBy(10 * time.Second)
+
+	// assert
+	test.That(t, len(sut.C)).Equals(0)
+	test.That(t, len(called)).Equals(0)
+}
@@ -0,0 +1,156 @@
+package time
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// defaultClock holds the clock installed using SetDefault, or nil if the
+// system clock is the default.
+var defaultClock atomic.Pointer[Clock]
+
+// boundClock holds the clock bound using With, or nil if no clock is bound.
+//
+// bindings holds the clocks bound by the calls to With in progress, innermost
+// last (guarded by bindingsMu); bindMu serialises top-level calls to With.
+var (
+	bindMu     sync.Mutex
+	bindingsMu sync.Mutex
+	bindings   []*Clock
+	boundClock atomic.Pointer[Clock]
+)
+
+// Default returns the default clock: the clock installed using SetDefault, or
+// the system clock if none is installed.
+//
+// The default clock is returned by ClockFromContext (and so used by the
+// package-level functions, such as Now and Sleep) for a context that does not
+// contain a clock.  This allows code that cannot be changed to thread a
+// context (or a clock) to be tested with a mock clock installed as the
+// default.
+func Default() Clock {
+	if c := defaultClock.Load(); c != nil {
+		return *c
+	}
+	return SystemClock()
+}
+
+// SetDefault installs a clock as the default clock (or the system clock, if
+// nil), returning a function that restores the previous default.
+//
+// Since the default clock is shared by the entire process, it should be
+// installed only during initialisation or by tests that do not run in
+// parallel; tests should use SetDefaultForTest, which ensures this.
+func SetDefault(c Clock) (restore func()) {
+	var prev *Clock
+	if c == nil {
+		prev = defaultClock.Swap(nil)
+	} else {
+		prev = defaultClock.Swap(&c)
+	}
+	return func() { defaultClock.Store(prev) }
+}
+
+// SetDefaultForTest installs a clock as the default clock for the duration of
+// a test, restoring the previous default when the test (and its subtests)
+// complete.
+//
+// Since the default clock is shared by all tests in the process, a test that
+// installs one must not run in parallel with other tests.  SetDefaultForTest
+// panics if called from a test that has called t.Parallel, and a test that
+// calls t.Parallel after SetDefaultForTest panics.
+func SetDefaultForTest(t testing.TB, c Clock) {
+	t.Helper()
+
+	// t.Setenv panics in (and prevents) parallel tests, guarding the default
+	// clock in the same way as the process environment
+	t.Setenv("BLUGNU_TIME_DEFAULT_CLOCK", t.Name())
+
+	t.Cleanup(SetDefault(c))
+}
+
+// With binds a clock (or the system clock, if nil) as the current clock (see:
+// CurrentClock) for the duration of a call to a given function, unbinding the
+// clock when the function returns (or panics).
+//
+// This allows a legacy call tree that has no context (or clock) plumbed
+// through it to resolve a clock deterministically, by calling CurrentClock,
+// while it is migrated gradually:
+//
+//	time.With(clock, func() {
+//		legacyJob() // calls time.CurrentClock().Now()
+//	})
+//
+// A clock is not bound to a goroutine: while the function is running, the
+// bound clock is the current clock for all goroutines.  To ensure that the
+// current clock is deterministic, top-level calls to With are serialised; a
+// call to With made when no call is in progress waits for any other such call
+// to return.
+//
+// A call to With made while a call is in progress, by the function (or any
+// function it calls) or by a goroutine that it starts, is nested: the clock
+// bound by the nested call replaces the current clock until the nested call
+// returns, when the clock bound by the innermost call still in progress (if
+// any) is restored.
+func With(c Clock, fn func()) {
+	if c == nil {
+		c = SystemClock()
+	}
+
+	bindingsMu.Lock()
+	nested := len(bindings) > 0
+	bindingsMu.Unlock()
+
+	if !nested {
+		bindMu.Lock()
+		defer bindMu.Unlock()
+	}
+
+	bound := &c
+	bindClock(bound)
+	defer unbindClock(bound)
+
+	fn()
+}
+
+// bindClock adds a clock bound by a call to With, as the current clock.
+func bindClock(c *Clock) {
+	bindingsMu.Lock()
+	defer bindingsMu.Unlock()
+
+	bindings = append(bindings, c)
+	boundClock.Store(c)
+}
+
+// unbindClock removes a clock bound by a call to With that is returning,
+// restoring the clock bound by the innermost call still in progress (if any).
+// Nested calls on different goroutines need not return in the order in which
+// they were made.
+func unbindClock(c *Clock) {
+	bindingsMu.Lock()
+	defer bindingsMu.Unlock()
+
+	for i := len(bindings) - 1; i >= 0; i-- {
+		if bindings[i] == c {
+			bindings = append(bindings[:i], bindings[i+1:]...)
+			break
+		}
+	}
+
+	if n := len(bindings); n > 0 {
+		boundClock.Store(bindings[n-1])
+		return
+	}
+	bindings = nil
+	boundClock.Store(nil)
+}
+
+// CurrentClock returns the clock bound by a call to With that is in progress
+// or, if there is none, the default clock (see: Default).
+func CurrentClock() Clock {
+	if c := boundClock.Load(); c != nil {
+		return *c
+	}
+	return Default()
+}
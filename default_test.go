@@ -0,0 +1,226 @@
+package time
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestSetDefault(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	// act
+	restore := SetDefault(clock)
+	defaulted := Default()
+	restoreNil := SetDefault(nil)
+	system := Default()
+	restoreNil()
+	restored := Default()
+	restore()
+
+	// assert
+	test.That(t, defaulted).Equals(clock)
+	test.That(t, system).Equals(SystemClock())
+	test.That(t, restored).Equals(clock)
+	test.That(t, Default()).Equals(SystemClock())
+}
+
+// Tests that the package-level functions use a default clock installed for a
+// test when the context does not contain a clock.
+func TestSetDefaultForTest(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	t.Run("installed", func(t *testing.T) {
+		// act
+		SetDefaultForTest(t, clock)
+		clock.AdvanceBy(time.Hour)
+
+		// assert
+		test.That(t, Now(context.Background())).Equals(time.Unix(3600, 0).UTC())
+		test.That(t, ClockFromContext(context.Background())).Equals(clock)
+	})
+
+	// assert
+	test.That(t, Default()).Equals(SystemClock())
+}
+
+func TestSetDefaultForTest_Parallel(t *testing.T) {
+	// arrange
+	var recovered any
+
+	// parallel subtests are run when the enclosing test completes
+	t.Run("group", func(t *testing.T) {
+		t.Run("parallel", func(t *testing.T) {
+			t.Parallel()
+			defer func() { recovered = recover() }()
+
+			// act
+			SetDefaultForTest(t, NewMockClock())
+		})
+	})
+
+	// assert
+	test.IsTrue(t, recovered != nil, "panicked")
+	test.That(t, Default()).Equals(SystemClock())
+}
+
+func TestWith(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	var current []Clock
+
+	// act
+	With(clock, func() { current = append(current, CurrentClock()) })
+	With(nil, func() { current = append(current, CurrentClock()) })
+	current = append(current, CurrentClock())
+
+	// assert
+	test.That(t, current).Equals([]Clock{clock, SystemClock(), SystemClock()})
+}
+
+func TestWith_Default(t *testing.T) {
+	// arrange
+	def := NewMockClock(AtTime(time.Unix(1, 0)))
+	bound := NewMockClock(AtTime(time.Unix(2, 0)))
+	SetDefaultForTest(t, def)
+
+	// act
+	var inside Clock
+	With(bound, func() { inside = CurrentClock() })
+
+	// assert
+	test.That(t, inside).Equals(bound)
+	test.That(t, CurrentClock()).Equals(def)
+}
+
+func TestWith_Panic(t *testing.T) {
+	// arrange
+	defer func() {
+		// assert
+		test.That(t, recover()).Equals("boom")
+		test.That(t, CurrentClock()).Equals(SystemClock())
+	}()
+
+	// act
+	With(NewMockClock(), func() { panic("boom") })
+}
+
+func TestWith_Nested(t *testing.T) {
+	// arrange
+	outer, inner := NewMockClock(AtTime(time.Unix(1, 0))), NewMockClock(AtTime(time.Unix(2, 0)))
+	var current []Clock
+
+	// act
+	With(outer, func() {
+		current = append(current, CurrentClock())
+		With(inner, func() { current = append(current, CurrentClock()) })
+		current = append(current, CurrentClock())
+	})
+	current = append(current, CurrentClock())
+
+	// assert
+	test.That(t, current).Equals([]Clock{outer, inner, outer, SystemClock()})
+}
+
+func TestWith_NestedPanic(t *testing.T) {
+	// arrange
+	outer := NewMockClock()
+	var current Clock
+
+	// act
+	With(outer, func() {
+		defer func() {
+			recover()
+			current = CurrentClock()
+		}()
+		With(NewMockClock(AtTime(time.Unix(1, 0))), func() { panic("boom") })
+	})
+
+	// assert
+	test.That(t, current).Equals(outer)
+}
+
+// Tests that a call to With on a goroutine started by the function called by
+// With is nested, rather than waiting for the outer call to return.
+func TestWith_NestedGoroutine(t *testing.T) {
+	// arrange
+	outer, inner := NewMockClock(AtTime(time.Unix(1, 0))), NewMockClock(AtTime(time.Unix(2, 0)))
+	var current []Clock
+
+	// act
+	With(outer, func() {
+		done := make(chan Clock)
+		go With(inner, func() { done <- CurrentClock() })
+		current = append(current, <-done)
+		waitFor(func() bool { return CurrentClock() == outer })
+		current = append(current, CurrentClock())
+	})
+	current = append(current, CurrentClock())
+
+	// assert
+	test.That(t, current).Equals([]Clock{inner, outer, SystemClock()})
+}
+
+// Tests that a nested call to With which returns after the outer call remains
+// bound until it returns, after which no clock is bound.
+func TestWith_NestedOutlivesOuter(t *testing.T) {
+	// arrange
+	outer, inner := NewMockClock(AtTime(time.Unix(1, 0))), NewMockClock(AtTime(time.Unix(2, 0)))
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	With(outer, func() {
+		go func() {
+			defer close(done)
+			With(inner, func() {
+				close(entered)
+				<-release
+			})
+		}()
+		<-entered
+	})
+
+	// act
+	during := CurrentClock()
+	close(release)
+	<-done
+
+	// assert
+	test.That(t, during).Equals(Clock(inner))
+	test.That(t, CurrentClock()).Equals(SystemClock())
+}
+
+// Tests that concurrent calls to With each observe a bound clock and that no
+// clock remains bound when all of the calls have returned.
+func TestWith_Concurrent(t *testing.T) {
+	// arrange
+	var (
+		wg    sync.WaitGroup
+		bound atomic.Int32
+	)
+
+	// act
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			With(NewMockClock(AtTime(time.Unix(int64(i+1), 0))), func() {
+				if CurrentClock() != SystemClock() {
+					bound.Add(1)
+				}
+			})
+		}()
+	}
+	wg.Wait()
+
+	// assert
+	test.That(t, bound.Load()).Equals(int32(10))
+	test.That(t, CurrentClock()).Equals(SystemClock())
+}
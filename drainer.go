@@ -0,0 +1,163 @@
+package time
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Drainer coordinates the graceful shutdown of a component, such as a
+// server: once draining, no new work is accepted and in-flight work is given
+// a grace period, timed by a clock, in which to complete.  If work remains in
+// flight when the grace period expires, shutdown is escalated by calling
+// functions registered using Escalate (e.g. to cancel the contexts of
+// in-flight requests and, later, to abandon them).
+//
+// Since the grace period and escalations are timed using a clock, the timing
+// of escalation may be tested by advancing a mock clock.
+type Drainer struct {
+	clock Clock
+	grace time.Duration
+
+	mu          sync.Mutex
+	inflight    int
+	draining    bool
+	idle        chan struct{}
+	escalations []escalation
+	drained     chan struct{}
+	err         error
+}
+
+// escalation is a function called if work remains in flight after the grace
+// period of a Drainer, and a further delay, has elapsed.
+type escalation struct {
+	delay time.Duration
+	fn    func(inflight int)
+}
+
+// NewDrainer returns a Drainer giving in-flight work a grace period in which
+// to complete when draining, according to a clock (or the system clock, if
+// nil).
+func NewDrainer(clock Clock, grace time.Duration) *Drainer {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &Drainer{
+		clock:   clock,
+		grace:   max(grace, 0),
+		idle:    make(chan struct{}),
+		drained: make(chan struct{}),
+	}
+}
+
+// Acquire registers the start of a unit of work, returning a function to be
+// called when the work is complete and true.  If the drainer is draining, the
+// work is not accepted and Acquire returns nil and false.
+//
+// The returned function may be called more than once; only the first call
+// has any effect.
+func (d *Drainer) Acquire() (release func(), ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.draining {
+		return nil, false
+	}
+	d.inflight++
+
+	var once sync.Once
+	return func() { once.Do(d.release) }, true
+}
+
+// release records the completion of a unit of work.
+func (d *Drainer) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.inflight--
+	if d.draining && d.inflight == 0 {
+		close(d.idle)
+	}
+}
+
+// Escalate registers a function to be called, with the number of units of
+// work in flight, if work remains in flight when a given delay has elapsed
+// after the grace period has expired (a delay of zero or less escalates when
+// the grace period expires), returning the drainer.  Escalations are called
+// in order of their delay.
+//
+// Escalations must be registered before Drain is called.
+func (d *Drainer) Escalate(delay time.Duration, fn func(inflight int)) *Drainer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.escalations = append(d.escalations, escalation{delay: max(delay, 0), fn: fn})
+	slices.SortStableFunc(d.escalations, func(a, b escalation) int { return int(a.delay - b.delay) })
+
+	return d
+}
+
+// InFlight returns the number of units of work in flight.
+func (d *Drainer) InFlight() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.inflight
+}
+
+// Draining returns true if the drainer is draining (see: Drain).
+func (d *Drainer) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.draining
+}
+
+// Drain stops accepting new work and waits for the work in flight to
+// complete, returning nil if it completes within the grace period.
+//
+// If work remains in flight when the grace period expires, the escalations
+// are called in turn (see: Escalate) until the work completes or the last
+// escalation has been called, and an error wrapping ErrTimeout is returned.
+//
+// Drain may be called more than once (and concurrently); each call waits for
+// the drain to complete, returning the same result.
+func (d *Drainer) Drain() error {
+	d.mu.Lock()
+	if d.draining {
+		d.mu.Unlock()
+		<-d.drained
+		return d.err
+	}
+	d.draining = true
+	if d.inflight == 0 {
+		close(d.idle)
+	}
+	escalations := d.escalations
+	d.mu.Unlock()
+
+	d.err = d.drain(escalations)
+	close(d.drained)
+
+	return d.err
+}
+
+// drain waits for the work in flight to complete or the grace period to
+// expire, escalating as necessary.
+func (d *Drainer) drain(escalations []escalation) error {
+	expires := d.clock.Now().Add(d.grace)
+	if waitDone(d.clock, d.idle, d.grace) == nil {
+		return nil
+	}
+	err := fmt.Errorf("%w: grace period of %s expired with %d in flight", ErrTimeout, d.grace, d.InFlight())
+
+	for _, e := range escalations {
+		if waitDone(d.clock, d.idle, d.clock.Until(expires.Add(e.delay))) == nil {
+			break
+		}
+		e.fn(d.InFlight())
+	}
+
+	return err
+}
@@ -0,0 +1,102 @@
+package time
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a drainer does not escalate when the work in flight completes
+// within the grace period.
+func TestDrainer_Drain(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	escalated := false
+	sut := NewDrainer(clock, 10*time.Second).Escalate(0, func(int) { escalated = true })
+	release, ok := sut.Acquire()
+	test.IsTrue(t, ok)
+
+	// act
+	result := make(chan error)
+	go func() { result <- sut.Drain() }()
+	waitFor(func() bool { return created(clock) > 0 })
+	clock.AdvanceBy(5 * time.Second)
+	release()
+
+	// assert
+	test.Error(t, <-result).IsNil()
+	test.IsFalse(t, escalated)
+	test.IsTrue(t, sut.Draining())
+	test.That(t, sut.InFlight()).Equals(0)
+}
+
+// Tests that a drainer with no work in flight drains immediately.
+func TestDrainer_Drain_Idle(t *testing.T) {
+	// arrange
+	sut := NewDrainer(NewMockClock(), time.Second)
+
+	// act
+	err := sut.Drain()
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.Error(t, sut.Drain(), "drained again").IsNil()
+}
+
+// Tests that a drainer does not accept work once draining.
+func TestDrainer_Acquire_Draining(t *testing.T) {
+	// arrange
+	sut := NewDrainer(nil, time.Second)
+	_ = sut.Drain()
+
+	// act
+	release, ok := sut.Acquire()
+
+	// assert
+	test.IsFalse(t, ok)
+	test.IsTrue(t, release == nil)
+}
+
+// Tests that a drainer escalates in turn when work remains in flight after the
+// grace period, until the work completes.
+func TestDrainer_Escalate(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		mu    sync.Mutex
+		calls = []string{}
+	)
+	escalate := func(name string) func(int) {
+		return func(inflight int) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, name+": "+strconv.Itoa(inflight))
+		}
+	}
+	sut := NewDrainer(clock, 10*time.Second).
+		Escalate(5*time.Second, escalate("abandon")).
+		Escalate(0, escalate("cancel")).
+		Escalate(time.Minute, escalate("never"))
+	release1, _ := sut.Acquire()
+	release2, _ := sut.Acquire()
+	release1()
+
+	// act
+	result := make(chan error)
+	go func() { result <- sut.Drain() }()
+	waitFor(func() bool { return created(clock) == 1 }) // the grace period
+	clock.AdvanceBy(10 * time.Second)
+	waitFor(func() bool { return created(clock) == 3 }) // "cancel" (expired) and "abandon"
+	clock.AdvanceBy(5 * time.Second)
+	waitFor(func() bool { return created(clock) == 4 }) // "never"
+	release2()
+
+	// assert
+	err := <-result
+	test.Error(t, err).Is(ErrTimeout)
+	test.That(t, err.Error()).Equals("timeout: grace period of 10s expired with 1 in flight")
+	test.That(t, calls).Equals([]string{"cancel: 1", "abandon: 1"})
+}
@@ -0,0 +1,202 @@
+package time
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DurableTimer is a one-shot timer scheduled using DurableTimers, persisted in
+// a DurableTimerStore so that it survives a restart of the process.
+type DurableTimer struct {
+	// ID identifies the timer; scheduling a timer with the ID of a timer that
+	// is already scheduled replaces that timer.
+	ID string
+
+	// Due is the time at which the timer is due to fire.
+	Due time.Time
+
+	// Payload is application-defined data identifying the work to be done
+	// when the timer fires.
+	Payload []byte
+}
+
+// DurableTimerStore is the interface of a persistent store of durable timers,
+// such as a database table, used by DurableTimers.
+type DurableTimerStore interface {
+	// Save saves a timer, replacing any timer with the same ID.
+	Save(ctx context.Context, t DurableTimer) error
+
+	// Delete deletes the timer with a given ID, if it exists.
+	Delete(ctx context.Context, id string) error
+
+	// Load returns all timers in the store.
+	Load(ctx context.Context) ([]DurableTimer, error)
+}
+
+// DurableTimers schedules one-shot timers that are persisted in a store and
+// fired using the timers of a clock.  Since a function cannot be persisted, a
+// single Fire function is called for every timer, with the payload of the
+// timer identifying the work to be done.
+//
+// When the process restarts, Start rehydrates the timers from the store;
+// timers that fell due while the process was not running fire immediately.
+// A timer is deleted from the store once it has fired successfully, so a
+// timer that fails to fire (or fires while the process is terminating) is
+// rehydrated and fired again when next started.
+//
+// A DurableTimers must not be copied after first use.
+type DurableTimers struct {
+	// Clock is the clock used to fire timers.  If nil, the system clock is used.
+	Clock Clock
+
+	// Store is the store in which timers are persisted.
+	Store DurableTimerStore
+
+	// Fire is called, on its own goroutine, when a timer is due.  If it
+	// returns an error the timer is retained in the store.
+	Fire func(ctx context.Context, t DurableTimer) error
+
+	// OnError, if not nil, is called with a timer and the error if firing the
+	// timer (or deleting it from the store once fired) fails.
+	OnError func(t DurableTimer, err error)
+
+	mu    sync.Mutex
+	armed map[string]*armedTimer
+}
+
+// armedTimer is a durable timer that has been armed using a timer of a clock.
+type armedTimer struct {
+	DurableTimer
+	timer *Timer
+}
+
+// Start loads the timers in the store and arms them, returning any error from
+// the store.
+func (d *DurableTimers) Start(ctx context.Context) error {
+	timers, err := d.Store.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, t := range timers {
+		d.arm(t)
+	}
+	return nil
+}
+
+// Schedule saves a timer with a given ID, due time and payload in the store
+// and arms it, replacing any timer with the same ID.
+func (d *DurableTimers) Schedule(ctx context.Context, id string, due time.Time, payload []byte) error {
+	t := DurableTimer{ID: id, Due: due, Payload: payload}
+	if err := d.Store.Save(ctx, t); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.arm(t)
+	return nil
+}
+
+// Cancel disarms the timer with a given ID and deletes it from the store.
+func (d *DurableTimers) Cancel(ctx context.Context, id string) error {
+	d.mu.Lock()
+	if a, ok := d.armed[id]; ok {
+		a.timer.Stop()
+		delete(d.armed, id)
+	}
+	d.mu.Unlock()
+
+	return d.Store.Delete(ctx, id)
+}
+
+// Pending returns the timers that are armed and yet to fire, in order of due
+// time.
+func (d *DurableTimers) Pending() []DurableTimer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := make([]DurableTimer, 0, len(d.armed))
+	for _, a := range d.armed {
+		result = append(result, a.DurableTimer)
+	}
+	slices.SortFunc(result, func(a, b DurableTimer) int {
+		if c := a.Due.Compare(b.Due); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result
+}
+
+// Stop disarms all timers without deleting them from the store, so that they
+// are rehydrated when next started.
+func (d *DurableTimers) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, a := range d.armed {
+		a.timer.Stop()
+		delete(d.armed, id)
+	}
+}
+
+// arm arms a timer, replacing any armed timer with the same ID.
+//
+// This method is not thread-safe and should only be called while the timers
+// are locked.
+func (d *DurableTimers) arm(t DurableTimer) {
+	if d.armed == nil {
+		d.armed = map[string]*armedTimer{}
+	}
+	if a, ok := d.armed[t.ID]; ok {
+		a.timer.Stop()
+	}
+
+	clock := d.Clock
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	a := &armedTimer{DurableTimer: t}
+	d.armed[t.ID] = a
+	a.timer = clock.AfterFunc(clock.Until(t.Due), func() { d.fire(a) })
+}
+
+// fire fires an armed timer, if it has not been disarmed or replaced, deleting
+// it from the store if fired successfully (unless a timer with the same ID was
+// scheduled when it fired).
+func (d *DurableTimers) fire(a *armedTimer) {
+	d.mu.Lock()
+	if d.armed[a.ID] != a {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.armed, a.ID)
+	d.mu.Unlock()
+
+	ctx := context.Background()
+	err := d.Fire(ctx, a.DurableTimer)
+	if err == nil && !d.rescheduled(a.ID) {
+		err = d.Store.Delete(ctx, a.ID)
+	}
+	if err != nil && d.OnError != nil {
+		d.OnError(a.DurableTimer, err)
+	}
+}
+
+// rescheduled returns true if a timer with a given ID is armed.
+func (d *DurableTimers) rescheduled(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.armed[id]
+	return ok
+}
@@ -0,0 +1,154 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// timerStore is an in-memory DurableTimerStore.
+type timerStore struct {
+	mu     sync.Mutex
+	timers map[string]DurableTimer
+}
+
+func (s *timerStore) Save(_ context.Context, t DurableTimer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.timers == nil {
+		s.timers = map[string]DurableTimer{}
+	}
+	s.timers[t.ID] = t
+	return nil
+}
+
+func (s *timerStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.timers, id)
+	return nil
+}
+
+func (s *timerStore) Load(context.Context) ([]DurableTimer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Collect(maps.Values(s.timers)), nil
+}
+
+func (s *timerStore) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Sorted(maps.Keys(s.timers))
+}
+
+// Tests that a scheduled timer fires when due and is then deleted from the store.
+func TestDurableTimers_Schedule(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		store = &timerStore{}
+		fired = make(chan DurableTimer, 1)
+		sut   = &DurableTimers{Clock: clock, Store: store, Fire: func(_ context.Context, t DurableTimer) error {
+			fired <- t
+			return nil
+		}}
+		ctx = context.Background()
+	)
+	test.Error(t, sut.Schedule(ctx, "invoice", time.Unix(60, 0), []byte("42"))).IsNil()
+
+	// act
+	clock.AdvanceBy(time.Minute)
+	result := <-fired
+
+	// assert
+	test.That(t, result.ID).Equals("invoice")
+	test.That(t, string(result.Payload)).Equals("42")
+	waitFor(func() bool { return len(store.ids()) == 0 })
+	test.That(t, len(sut.Pending())).Equals(0)
+}
+
+// Tests that timers are rehydrated from the store when started, with overdue
+// timers fired immediately.
+func TestDurableTimers_Start(t *testing.T) {
+	// arrange
+	var (
+		ctx   = context.Background()
+		clock = NewMockClock(AtTime(time.Unix(100, 0)))
+		store = &timerStore{}
+		fired = make(chan string, 2)
+		sut   = &DurableTimers{Clock: clock, Store: store, Fire: func(_ context.Context, t DurableTimer) error {
+			fired <- t.ID
+			return nil
+		}}
+	)
+	_ = store.Save(ctx, DurableTimer{ID: "overdue", Due: time.Unix(50, 0)})
+	_ = store.Save(ctx, DurableTimer{ID: "later", Due: time.Unix(200, 0)})
+
+	// act
+	err := sut.Start(ctx)
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, <-fired).Equals("overdue")
+	test.That(t, sut.Pending()).Equals([]DurableTimer{{ID: "later", Due: time.Unix(200, 0)}})
+
+	clock.AdvanceBy(100 * time.Second)
+	test.That(t, <-fired).Equals("later")
+}
+
+// Tests that a timer that fails to fire is retained in the store.
+func TestDurableTimers_FireError(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock()
+		store  = &timerStore{}
+		failed = make(chan error, 1)
+		sut    = &DurableTimers{Clock: clock, Store: store,
+			Fire:    func(context.Context, DurableTimer) error { return errors.New("unavailable") },
+			OnError: func(_ DurableTimer, err error) { failed <- err },
+		}
+	)
+	_ = sut.Schedule(context.Background(), "a", time.Unix(1, 0), nil)
+
+	// act
+	clock.AdvanceBy(time.Second)
+	err := <-failed
+
+	// assert
+	test.That(t, err.Error()).Equals("unavailable")
+	test.That(t, store.ids()).Equals([]string{"a"})
+}
+
+// Tests that cancelled and stopped timers do not fire and that only cancelled
+// timers are deleted from the store.
+func TestDurableTimers_CancelAndStop(t *testing.T) {
+	// arrange
+	var (
+		ctx   = context.Background()
+		clock = NewMockClock()
+		store = &timerStore{}
+		fired = make(chan string, 2)
+		sut   = &DurableTimers{Clock: clock, Store: store, Fire: func(_ context.Context, t DurableTimer) error {
+			fired <- t.ID
+			return nil
+		}}
+	)
+	_ = sut.Schedule(ctx, "cancelled", time.Unix(1, 0), nil)
+	_ = sut.Schedule(ctx, "stopped", time.Unix(1, 0), nil)
+
+	// act
+	err := sut.Cancel(ctx, "cancelled")
+	sut.Stop()
+	clock.AdvanceBy(time.Second)
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, len(fired)).Equals(0)
+	test.That(t, store.ids()).Equals([]string{"stopped"})
+}
@@ -0,0 +1,240 @@
+package time
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationFormat configures the formatting of a duration by FormatDuration.
+//
+// The zero value formats a duration using at most two units, truncated to
+// whole seconds and separated by a space, e.g. "1h 2m".
+type DurationFormat struct {
+	// MaxUnits is the maximum number of units in the formatted duration.
+	// Units are counted from the largest unit in the duration, whether or
+	// not smaller units are zero; e.g. with 2 units, 1d 0h 5m is formatted
+	// as "1d".  If zero, 2 units are used.
+	MaxUnits int
+
+	// MinUnit is the smallest unit that may be used in the formatted
+	// duration: one of Day, Hour, Minute, Second, Millisecond, Microsecond
+	// or Nanosecond.  If zero (or not one of these units), Second is used.
+	MinUnit time.Duration
+
+	// Round determines whether the duration is rounded to the smallest unit
+	// formatted (otherwise the duration is truncated).
+	Round bool
+
+	// Separator is placed between the units of the formatted duration.  If
+	// empty, a single space is used.  To format without a separator (e.g.
+	// "1h2m"), use NoSeparator.
+	Separator string
+}
+
+// NoSeparator may be specified as the Separator of a DurationFormat to format
+// durations without any separator between units.
+const NoSeparator = "\x00"
+
+// durationUnits are the units used to format and parse durations, in
+// descending order of size.
+var durationUnits = []struct {
+	symbol string
+	size   time.Duration
+}{
+	{"d", Day},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"µs", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// FormatDuration formats a duration in a readable form suitable for display,
+// e.g. "1h 2m" or "2d 3h", using the largest units in the duration; days are
+// the largest unit used.
+//
+// By comparison, Duration.String() formats durations precisely, e.g.
+// "93h17m0.5s", which is unsuitable for most user interfaces.
+//
+// Zero components are omitted; a duration that is zero (after truncation or
+// rounding) is formatted as zero in the smallest unit, e.g. "0s".
+//
+// The resulting string may be parsed using ParseFormattedDuration.
+func FormatDuration(d time.Duration, opts DurationFormat) string {
+	maxUnits := opts.MaxUnits
+	if maxUnits <= 0 {
+		maxUnits = 2
+	}
+
+	minUnit := len(durationUnits) - 1
+	for minUnit > 0 && durationUnits[minUnit].size != opts.MinUnit {
+		minUnit--
+	}
+	if durationUnits[minUnit].size != opts.MinUnit {
+		minUnit = 3 // seconds
+	}
+
+	sep := opts.Separator
+	switch sep {
+	case "":
+		sep = " "
+	case NoSeparator:
+		sep = ""
+	}
+
+	// the absolute duration is formatted as a uint64 since the absolute value of
+	// the minimum duration cannot be represented as a Duration
+	sign, abs := "", uint64(d)
+	if d < 0 {
+		sign, abs = "-", uint64(-(d+1))+1
+	}
+
+	// identify the largest and smallest units used; rounding may carry into
+	// a larger unit, so the largest unit is identified after rounding
+	first, last := 0, 0
+	for range 2 {
+		first = minUnit
+		for i := 0; i < minUnit; i++ {
+			if abs >= uint64(durationUnits[i].size) {
+				first = i
+				break
+			}
+		}
+		last = min(first+maxUnits-1, minUnit)
+
+		size := uint64(durationUnits[last].size)
+		r := abs % size
+		abs -= r
+		if !opts.Round || r < (size+1)/2 || abs > math.MaxUint64-size {
+			break
+		}
+		abs += size
+	}
+
+	parts := make([]string, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		size := uint64(durationUnits[i].size)
+		if n := abs / size; n > 0 {
+			parts = append(parts, strconv.FormatUint(n, 10)+durationUnits[i].symbol)
+			abs -= n * size
+		}
+	}
+	if len(parts) == 0 {
+		return "0" + durationUnits[minUnit].symbol
+	}
+	return sign + strings.Join(parts, sep)
+}
+
+// ParseFormattedDuration parses a duration formatted by FormatDuration, e.g.
+// "1h 2m", "2d 3h" or "-1d2h".  Units are separated by optional whitespace.
+//
+// In addition to the units produced by FormatDuration, "us" is accepted for
+// microseconds and the quantity of any unit may have a fractional part, so
+// that any duration accepted by time.ParseDuration is also accepted, e.g.
+// "1.5h" or "1h30m0.5s".
+//
+// If the string is not a valid duration an error wrapping ErrInvalidDuration
+// is returned.
+func ParseFormattedDuration(s string) (time.Duration, error) {
+	invalid := func(reason string) (time.Duration, error) {
+		return 0, fmt.Errorf("%w: %q: %s", ErrInvalidDuration, s, reason)
+	}
+
+	rest := strings.TrimSpace(s)
+	neg := false
+	if len(rest) > 0 && (rest[0] == '-' || rest[0] == '+') {
+		neg, rest = rest[0] == '-', strings.TrimSpace(rest[1:])
+	}
+	if rest == "" {
+		return invalid("no duration")
+	}
+	if rest == "0" {
+		return 0, nil
+	}
+
+	// the magnitude of the duration is accumulated in whole nanoseconds, as a
+	// uint64 since the magnitude of the minimum duration cannot be represented
+	// as a Duration
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+
+	var total uint64
+	for rest != "" {
+		i := strings.IndexFunc(rest, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return invalid("quantity expected")
+		}
+		whole, frac, _ := strings.Cut(rest[:i], ".")
+		if (whole == "" && frac == "") || strings.Contains(frac, ".") {
+			return invalid("invalid quantity")
+		}
+		rest = rest[i:]
+
+		j := strings.IndexAny(rest, "0123456789. \t")
+		if j < 0 {
+			j = len(rest)
+		}
+		size, ok := durationUnitSize(rest[:j])
+		if !ok {
+			return invalid("unknown unit")
+		}
+		rest = strings.TrimLeft(rest[j:], " \t")
+
+		n, ok := durationQuantity(whole, frac, uint64(size))
+		if !ok || n > limit-total {
+			return invalid("out of range")
+		}
+		total += n
+	}
+
+	if neg {
+		return time.Duration(-total), nil
+	}
+	return time.Duration(total), nil
+}
+
+// durationQuantity returns the number of nanoseconds in a quantity of a unit
+// of a given size, with whole and fractional parts of the quantity given as
+// strings of decimal digits (either of which may be empty), and true, or false
+// if the quantity exceeds the range of a uint64.  The fractional part is
+// rounded to the nearest nanosecond.
+func durationQuantity(whole, frac string, size uint64) (uint64, bool) {
+	var n uint64
+	if whole != "" {
+		w, err := strconv.ParseUint(whole, 10, 64)
+		if err != nil || w > math.MaxUint64/size {
+			return 0, false
+		}
+		n = w * size
+	}
+	if frac != "" {
+		// the fraction of a unit is less than the size of the unit, which is
+		// represented exactly by a float64
+		f, _ := strconv.ParseFloat("0."+frac, 64)
+		r := uint64(math.Round(f * float64(size)))
+		if r > math.MaxUint64-n {
+			return 0, false
+		}
+		n += r
+	}
+	return n, true
+}
+
+// durationUnitSize returns the size of a unit accepted by ParseFormattedDuration.
+func durationUnitSize(symbol string) (time.Duration, bool) {
+	if symbol == "us" || symbol == "μs" { // U+03BC (Greek mu) is accepted in addition to U+00B5 (micro sign)
+		return time.Microsecond, true
+	}
+	for _, u := range durationUnits {
+		if u.symbol == symbol {
+			return u.size, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,138 @@
+package time
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestFormatDuration(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		d        time.Duration
+		opts     DurationFormat
+		want     string
+	}{
+		{scenario: "zero", d: 0, want: "0s"},
+		{scenario: "zero with min unit", d: 0, opts: DurationFormat{MinUnit: time.Minute}, want: "0m"},
+		{scenario: "seconds", d: 42 * time.Second, want: "42s"},
+		{scenario: "hours and minutes", d: time.Hour + 2*time.Minute + 3*time.Second, want: "1h 2m"},
+		{scenario: "days and hours", d: 51*time.Hour + 17*time.Minute, want: "2d 3h"},
+		{scenario: "standard library example", d: 93*time.Hour + 17*time.Minute + 500*time.Millisecond, want: "3d 21h"},
+		{scenario: "zero components omitted", d: Day + 5*time.Minute, want: "1d"},
+		{scenario: "negative", d: -(90 * time.Minute), want: "-1h 30m"},
+		{scenario: "truncated below min unit", d: 500 * time.Millisecond, want: "0s"},
+		{scenario: "rounded", d: 90*time.Minute + 31*time.Second, opts: DurationFormat{Round: true}, want: "1h 31m"},
+		{scenario: "rounding carries",
+			d:    time.Hour + 59*time.Minute + 59*time.Second,
+			opts: DurationFormat{Round: true},
+			want: "2h",
+		},
+		{scenario: "rounded to min unit", d: 500 * time.Millisecond, opts: DurationFormat{Round: true}, want: "1s"},
+		{scenario: "max units",
+			d:    Day + 2*time.Hour + 3*time.Minute + 4*time.Second,
+			opts: DurationFormat{MaxUnits: 3},
+			want: "1d 2h 3m",
+		},
+		{scenario: "min unit",
+			d:    1500 * time.Microsecond,
+			opts: DurationFormat{MinUnit: time.Microsecond},
+			want: "1ms 500µs",
+		},
+		{scenario: "invalid min unit", d: 1500 * time.Millisecond, opts: DurationFormat{MinUnit: 3}, want: "1s"},
+		{scenario: "separator", d: 90 * time.Minute, opts: DurationFormat{Separator: ", "}, want: "1h, 30m"},
+		{scenario: "no separator", d: 90 * time.Minute, opts: DurationFormat{Separator: NoSeparator}, want: "1h30m"},
+		{scenario: "minimum duration",
+			d:    time.Duration(math.MinInt64),
+			opts: DurationFormat{MaxUnits: 7, MinUnit: time.Nanosecond},
+			want: "-106751d 23h 47m 16s 854ms 775µs 808ns",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := FormatDuration(tc.d, tc.opts)
+
+			// assert
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestParseFormattedDuration(t *testing.T) {
+	testcases := []struct {
+		input string
+		want  time.Duration
+		err   error
+	}{
+		{input: "0", want: 0},
+		{input: "0s", want: 0},
+		{input: "1h 2m", want: time.Hour + 2*time.Minute},
+		{input: "2d 3h", want: 51 * time.Hour},
+		{input: "-1d2h", want: -26 * time.Hour},
+		{input: "+ 30s", want: 30 * time.Second},
+		{input: "1h, 30m", err: ErrInvalidDuration},
+		{input: "1.5h", want: 90 * time.Minute},
+		{input: "93h17m0.5s", want: 93*time.Hour + 17*time.Minute + 500*time.Millisecond},
+		{input: "1ms 500µs", want: 1500 * time.Microsecond},
+		{input: "1ms 500us", want: 1500 * time.Microsecond},
+		{input: "", err: ErrInvalidDuration},
+		{input: "-", err: ErrInvalidDuration},
+		{input: "1", err: ErrInvalidDuration},
+		{input: "h", err: ErrInvalidDuration},
+		{input: "1x", err: ErrInvalidDuration},
+		{input: "1..5h", err: ErrInvalidDuration},
+		{input: "200000d", err: ErrInvalidDuration},
+		{input: "106751d 23h 47m 16s 854ms 775µs 807ns", want: time.Duration(math.MaxInt64)},
+		{input: "106751d 23h 47m 16s 854ms 775µs 808ns", err: ErrInvalidDuration},
+		{input: "-106751d 23h 47m 16s 854ms 775µs 808ns", want: time.Duration(math.MinInt64)},
+		{input: "99999999999999999999ns", err: ErrInvalidDuration},
+		{input: ".5s", want: 500 * time.Millisecond},
+		{input: "1.s", want: time.Second},
+		{input: ".s", err: ErrInvalidDuration},
+		{input: "0.000000001s", want: time.Nanosecond},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			// act
+			result, err := ParseFormattedDuration(tc.input)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+// Tests that durations formatted by FormatDuration are parsed to the formatted value.
+func TestParseFormattedDuration_RoundTrip(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		d        time.Duration
+		format   DurationFormat
+	}{
+		{scenario: "milliseconds",
+			d:      3*Day + 4*time.Hour + 5*time.Minute + 6*time.Second + 7*time.Millisecond,
+			format: DurationFormat{MaxUnits: 5, MinUnit: time.Millisecond},
+		},
+		{scenario: "odd nanoseconds", d: 12345*Day + 987654321, format: DurationFormat{MaxUnits: 7, MinUnit: time.Nanosecond}},
+		{scenario: "negative odd nanoseconds", d: -(3*time.Hour + 1), format: DurationFormat{MaxUnits: 7, MinUnit: time.Nanosecond}},
+		{scenario: "maximum", d: time.Duration(math.MaxInt64), format: DurationFormat{MaxUnits: 7, MinUnit: time.Nanosecond}},
+		{scenario: "minimum", d: time.Duration(math.MinInt64), format: DurationFormat{MaxUnits: 7, MinUnit: time.Nanosecond}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			s := FormatDuration(tc.d, tc.format)
+
+			// act
+			result, err := ParseFormattedDuration(s)
+
+			// assert
+			test.Error(t, err).IsNil()
+			test.That(t, result).Equals(tc.d)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package time
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// EarlyExpiryPolicy decides whether a cached item should be recomputed before
+// it expires, using probabilistic early expiration ("XFetch", as described in
+// "Optimal Probabilistic Cache Stampede Prevention" by Vattani, Chierichetti
+// and Lowenstein) to protect against cache stampedes: rather than every
+// client recomputing an item when it expires, each client decides at random
+// to recompute it early, with a probability that increases as the expiry
+// approaches and with the time taken to recompute it.
+//
+// Decisions are made according to the current time of a clock and, if seeded
+// (see: WithSeed), a deterministic random number generator, so that the
+// refresh behaviour of a cache may be verified using a mock clock.
+type EarlyExpiryPolicy struct {
+	clock Clock
+	ttl   time.Duration
+	beta  float64
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// EarlyExpiry returns an EarlyExpiryPolicy for items cached for a given TTL,
+// according to a clock (or the system clock, if nil).  The beta parameter
+// scales the eagerness of early recomputation: values greater than 1 favour
+// earlier recomputation, values less than 1 later.  If beta is not positive,
+// 1 is used (the value recommended for most caches).
+func EarlyExpiry(clock Clock, ttl time.Duration, beta float64) *EarlyExpiryPolicy {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	if !(beta > 0) {
+		beta = 1
+	}
+	return &EarlyExpiryPolicy{clock: clock, ttl: max(ttl, 0), beta: beta}
+}
+
+// WithSeed sets the policy to make decisions using a random number generator
+// with a given seed, returning the policy, so that the decisions are
+// reproducible (e.g. in a test).  By default, decisions are made using the
+// random number generator of the math/rand/v2 package.
+func (p *EarlyExpiryPolicy) WithSeed(seed uint64) *EarlyExpiryPolicy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rng = rand.New(rand.NewPCG(seed, seed))
+	return p
+}
+
+// Expires returns the time at which an item computed at a given time expires.
+func (p *EarlyExpiryPolicy) Expires(computed time.Time) time.Time {
+	return computed.Add(p.ttl)
+}
+
+// ShouldRefresh returns true if an item computed at a given time, taking a
+// given duration (delta) to recompute, should be recomputed at the current
+// time of the clock.  An item that has expired should always be recomputed;
+// otherwise the item should be recomputed if:
+//
+//	now - delta * beta * ln(rand()) >= expiry
+//
+// where rand() is a random number in the range (0, 1].
+func (p *EarlyExpiryPolicy) ShouldRefresh(computed time.Time, delta time.Duration) bool {
+	now := p.clock.Now()
+	expires := p.Expires(computed)
+	if !now.Before(expires) {
+		return true
+	}
+
+	early := -float64(max(delta, 0)) * p.beta * math.Log(p.random())
+	return float64(expires.Sub(now)) <= early
+}
+
+// random returns a random number in the range (0, 1].
+func (p *EarlyExpiryPolicy) random() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.rng == nil {
+		return 1 - rand.Float64()
+	}
+	return 1 - p.rng.Float64()
+}
@@ -0,0 +1,94 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// decisions returns the decisions of a policy for an item computed at the
+// epoch, taking a given duration to recompute, at each of a number of
+// seconds after the epoch.
+func decisions(clock MockClock, p *EarlyExpiryPolicy, delta time.Duration, seconds ...int) []bool {
+	result := []bool{}
+	for _, s := range seconds {
+		clock.AdvanceTo(time.Unix(int64(s), 0))
+		result = append(result, p.ShouldRefresh(time.Unix(0, 0), delta))
+	}
+	return result
+}
+
+// Tests that an item is recomputed when it has expired and, otherwise, only if
+// recomputing it takes time.
+func TestEarlyExpiry(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		delta    time.Duration
+		seconds  []int
+		result   []bool
+	}{
+		{scenario: "no recompute time",
+			seconds: []int{0, 59, 60, 61},
+			result:  []bool{false, false, true, true},
+		},
+		{scenario: "recompute time",
+			delta:   time.Hour,
+			seconds: []int{59, 60},
+			result:  []bool{true, true},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(Yielding(0))
+			sut := EarlyExpiry(clock, time.Minute, 0).WithSeed(1)
+
+			// act
+			result := decisions(clock, sut, tc.delta, tc.seconds...)
+
+			// assert
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+// Tests that the decisions of policies with the same seed are the same and
+// that early recomputation becomes more likely as the expiry approaches.
+func TestEarlyExpiry_WithSeed(t *testing.T) {
+	// arrange
+	seconds := []int{}
+	for s := range 60 {
+		seconds = append(seconds, s, s, s)
+	}
+	a, b := NewMockClock(Yielding(0)), NewMockClock(Yielding(0))
+
+	// act
+	ra := decisions(a, EarlyExpiry(a, time.Minute, 1).WithSeed(42), 5*time.Second, seconds...)
+	rb := decisions(b, EarlyExpiry(b, time.Minute, 1).WithSeed(42), 5*time.Second, seconds...)
+
+	// assert
+	test.That(t, ra).Equals(rb)
+
+	count := func(r []bool) (n int) {
+		for _, refresh := range r {
+			if refresh {
+				n++
+			}
+		}
+		return n
+	}
+	test.That(t, count(ra[:90])).Equals(0)
+	test.IsTrue(t, count(ra[len(ra)-9:]) > 0, "refreshed before expiry")
+}
+
+func TestEarlyExpiryPolicy_Expires(t *testing.T) {
+	// arrange
+	sut := EarlyExpiry(nil, time.Minute, 1)
+
+	// act
+	result := sut.Expires(time.Unix(0, 0))
+
+	// assert
+	test.That(t, result).Equals(time.Unix(60, 0))
+}
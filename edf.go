@@ -0,0 +1,174 @@
+package time
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TaskReport reports the execution of a task by a DeadlineRunner.
+type TaskReport struct {
+	// Name is the name of the task.
+	Name string
+
+	// Deadline is the deadline of the task, or the zero time if the task has
+	// no deadline.
+	Deadline time.Time
+
+	// Started is the time (according to the clock of the runner) at which the
+	// task started.
+	Started time.Time
+
+	// Finished is the time (according to the clock of the runner) at which
+	// the task finished.
+	Finished time.Time
+
+	// Lateness is the duration by which the task finished after its deadline,
+	// or zero if the task finished by its deadline (or has no deadline).
+	Lateness time.Duration
+}
+
+// Late returns true if the task finished after its deadline.
+func (r TaskReport) Late() bool {
+	return r.Lateness > 0
+}
+
+// String returns a single line description of the report.
+func (r TaskReport) String() string {
+	switch {
+	case r.Deadline.IsZero():
+		return fmt.Sprintf("%s: finished at %s (no deadline)", r.Name, r.Finished.Format(time.RFC3339Nano))
+	case r.Late():
+		return fmt.Sprintf("%s: finished at %s (late by %s)", r.Name, r.Finished.Format(time.RFC3339Nano), r.Lateness)
+	default:
+		return fmt.Sprintf("%s: finished at %s (%s before deadline)", r.Name, r.Finished.Format(time.RFC3339Nano), r.Deadline.Sub(r.Finished))
+	}
+}
+
+// DeadlineRunner is an executor for batch systems, running tasks one at a
+// time in earliest-deadline-first (EDF) order and reporting the lateness of
+// each according to a clock.  Tasks with the same deadline are run in the
+// order in which they were submitted; tasks with no deadline are run after
+// all tasks with a deadline.
+//
+// Since lateness is determined using a clock, the order in which tasks are run
+// and the accounting of their lateness may be tested deterministically using a
+// mock clock (with each task advancing the clock by the time it would take).
+type DeadlineRunner struct {
+	clock Clock
+	mu    sync.Mutex
+	tasks edfHeap
+	seq   int
+}
+
+// edfTask is a task submitted to a DeadlineRunner.
+type edfTask struct {
+	name     string
+	deadline time.Time
+	seq      int
+	fn       func()
+}
+
+// NewDeadlineRunner returns a DeadlineRunner using a given clock (or the system
+// clock, if nil) to determine the lateness of tasks.
+func NewDeadlineRunner(clock Clock) *DeadlineRunner {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &DeadlineRunner{clock: clock}
+}
+
+// Submit submits a named task with a given deadline (the zero time for a task
+// with no deadline).  A task may be submitted while the runner is running,
+// including by a running task.
+func (r *DeadlineRunner) Submit(name string, deadline time.Time, fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	heap.Push(&r.tasks, &edfTask{name: name, deadline: deadline, seq: r.seq, fn: fn})
+}
+
+// SubmitContext submits a named task with the deadline of a given context (if
+// any), calling the function of the task with the context.  The task is run
+// even if the context is done when it is run, so that the function may report
+// the error of the context.
+func (r *DeadlineRunner) SubmitContext(ctx context.Context, name string, fn func(context.Context)) {
+	deadline, _ := ctx.Deadline()
+	r.Submit(name, deadline, func() { fn(ctx) })
+}
+
+// Pending returns the number of tasks submitted that have not yet been run.
+func (r *DeadlineRunner) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tasks.Len()
+}
+
+// Run runs the submitted tasks, in order of their deadlines, until no tasks
+// remain, returning a report of each task in the order in which they were run.
+func (r *DeadlineRunner) Run() []TaskReport {
+	var reports []TaskReport
+	for {
+		task := r.next()
+		if task == nil {
+			return reports
+		}
+
+		report := TaskReport{Name: task.name, Deadline: task.deadline, Started: r.clock.Now()}
+		task.fn()
+		report.Finished = r.clock.Now()
+		if !task.deadline.IsZero() {
+			report.Lateness = max(report.Finished.Sub(task.deadline), 0)
+		}
+		reports = append(reports, report)
+	}
+}
+
+// next removes and returns the task with the earliest deadline, or nil if no
+// tasks remain.
+func (r *DeadlineRunner) next() *edfTask {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tasks.Len() == 0 {
+		return nil
+	}
+	return heap.Pop(&r.tasks).(*edfTask)
+}
+
+// edfHeap is a heap of tasks ordered by deadline (tasks with no deadline last)
+// and then by the order in which they were submitted.
+type edfHeap []*edfTask
+
+func (h edfHeap) Len() int      { return len(h) }
+func (h edfHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h edfHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	switch {
+	case a.deadline.Equal(b.deadline):
+		return a.seq < b.seq
+	case a.deadline.IsZero():
+		return false
+	case b.deadline.IsZero():
+		return true
+	}
+	return a.deadline.Before(b.deadline)
+}
+
+func (h *edfHeap) Push(x any) {
+	*h = append(*h, x.(*edfTask))
+}
+
+func (h *edfHeap) Pop() any {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return t
+}
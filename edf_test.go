@@ -0,0 +1,99 @@
+package time
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that tasks are run in earliest-deadline-first order, reporting their
+// lateness according to the clock.
+func TestDeadlineRunner_Run(t *testing.T) {
+	// arrange
+	clock := NewMockClock(Yielding(0))
+	at := func(s int) time.Time { return time.Unix(int64(s), 0).UTC() }
+	work := func(d time.Duration) func() { return func() { clock.AdvanceBy(d) } }
+
+	sut := NewDeadlineRunner(clock)
+	sut.Submit("c", at(30), work(10*time.Second))
+	sut.Submit("none", time.Time{}, work(time.Second))
+	sut.Submit("a", at(10), work(15*time.Second))
+	sut.Submit("b", at(10), func() {
+		clock.AdvanceBy(5 * time.Second)
+		sut.Submit("d", at(25), work(time.Second))
+	})
+
+	// act
+	result := sut.Run()
+
+	// assert
+	test.That(t, result).Equals([]TaskReport{
+		{Name: "a", Deadline: at(10), Started: at(0), Finished: at(15), Lateness: 5 * time.Second},
+		{Name: "b", Deadline: at(10), Started: at(15), Finished: at(20), Lateness: 10 * time.Second},
+		{Name: "d", Deadline: at(25), Started: at(20), Finished: at(21)},
+		{Name: "c", Deadline: at(30), Started: at(21), Finished: at(31), Lateness: time.Second},
+		{Name: "none", Started: at(31), Finished: at(32)},
+	})
+	test.That(t, sut.Pending()).Equals(0)
+}
+
+func TestDeadlineRunner_SubmitContext(t *testing.T) {
+	// arrange
+	ctx, clock := ContextWithMockClock(context.Background(), Yielding(0))
+	sut := NewDeadlineRunner(clock)
+
+	late, cancel := ContextWithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	early, cancel := ContextWithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var deadlines []time.Time
+	task := func(ctx context.Context) {
+		clock.AdvanceBy(12 * time.Second)
+		deadline, _ := ctx.Deadline()
+		deadlines = append(deadlines, deadline)
+	}
+	sut.SubmitContext(late, "late", task)
+	sut.SubmitContext(early, "early", task)
+
+	// act
+	result := sut.Run()
+
+	// assert
+	test.That(t, len(result)).Equals(2)
+	test.That(t, result[0].String()).Equals("early: finished at 1970-01-01T00:00:12Z (late by 2s)")
+	test.That(t, result[1].String()).Equals("late: finished at 1970-01-01T00:00:24Z (late by 4s)")
+	test.That(t, deadlines).Equals([]time.Time{result[0].Deadline, result[1].Deadline})
+}
+
+func TestTaskReport_String(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		report   TaskReport
+		result   string
+	}{
+		{scenario: "no deadline",
+			report: TaskReport{Name: "task", Finished: time.Unix(10, 0).UTC()},
+			result: "task: finished at 1970-01-01T00:00:10Z (no deadline)",
+		},
+		{scenario: "on time",
+			report: TaskReport{Name: "task", Deadline: time.Unix(15, 0).UTC(), Finished: time.Unix(10, 0).UTC()},
+			result: "task: finished at 1970-01-01T00:00:10Z (5s before deadline)",
+		},
+		{scenario: "late",
+			report: TaskReport{Name: "task", Deadline: time.Unix(5, 0).UTC(), Finished: time.Unix(10, 0).UTC(), Lateness: 5 * time.Second},
+			result: "task: finished at 1970-01-01T00:00:10Z (late by 5s)",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := tc.report.String()
+
+			// assert
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
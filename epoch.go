@@ -0,0 +1,106 @@
+package time
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// EpochUnit is the unit of a timestamp expressed as a number of units since
+// the Unix epoch.
+type EpochUnit int
+
+const (
+	EpochSeconds EpochUnit = iota
+	EpochMillis
+	EpochMicros
+	EpochNanos
+)
+
+// String returns the name of the unit.
+func (u EpochUnit) String() string {
+	switch u {
+	case EpochSeconds:
+		return "EpochSeconds"
+	case EpochMillis:
+		return "EpochMillis"
+	case EpochMicros:
+		return "EpochMicros"
+	case EpochNanos:
+		return "EpochNanos"
+	}
+	return "<invalid EpochUnit(" + strconv.Itoa(int(u)) + ")>"
+}
+
+// Time returns the time of a timestamp of a number of the units since the Unix
+// epoch.  An invalid unit is treated as EpochSeconds.
+func (u EpochUnit) Time(v int64) time.Time {
+	switch u {
+	case EpochMillis:
+		return time.UnixMilli(v)
+	case EpochMicros:
+		return time.UnixMicro(v)
+	case EpochNanos:
+		return time.Unix(0, v)
+	}
+	return time.Unix(v, 0)
+}
+
+// GuessEpochUnit returns the unit of a timestamp since the Unix epoch of
+// unknown unit, determined by its magnitude, for timestamps from systems that
+// variously use seconds, milliseconds, microseconds or nanoseconds.
+//
+// A timestamp of up to 11 digits is taken to be seconds, 12 to 14 digits
+// milliseconds, 15 to 17 digits microseconds and 18 or more digits
+// nanoseconds (consistent with LayoutUnixSeconds and LayoutUnixMillis).  This
+// identifies the unit correctly for any time between 1973 and 5138 (or the
+// same period before the epoch, for negative timestamps).
+func GuessEpochUnit(v int64) EpochUnit {
+	if v < 0 {
+		// the magnitude of math.MinInt64 is not representable but is
+		// nanoseconds in any case
+		v = -max(v, -math.MaxInt64)
+	}
+	switch {
+	case v < 1e11:
+		return EpochSeconds
+	case v < 1e14:
+		return EpochMillis
+	case v < 1e17:
+		return EpochMicros
+	}
+	return EpochNanos
+}
+
+// FromEpoch returns the time of a timestamp since the Unix epoch of unknown
+// unit, together with the unit determined by GuessEpochUnit.
+func FromEpoch(v int64) (time.Time, EpochUnit) {
+	u := GuessEpochUnit(v)
+	return u.Time(v), u
+}
+
+// FromUnixFloat returns the time of a (possibly fractional) number of seconds
+// since the Unix epoch, such as a timestamp from a system that represents
+// times as floating point numbers, rounded to the nearest nanosecond (within
+// the precision of the float).
+//
+// If the number of seconds is not finite or is not within the range of an
+// int64 an error wrapping ErrTimestampOutOfRange is returned.
+func FromUnixFloat(seconds float64) (time.Time, error) {
+	// float64(math.MaxInt64) rounds up to 2^63, which is itself out of range
+	if math.IsNaN(seconds) || seconds < math.MinInt64 || seconds >= math.MaxInt64 {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrTimestampOutOfRange, seconds)
+	}
+
+	sec, frac := math.Modf(seconds)
+	nsec := math.Round(frac * 1e9)
+	return time.Unix(int64(sec), int64(nsec)), nil
+}
+
+// UnixNano returns the time of a number of nanoseconds since the Unix epoch,
+// complementing Unix, UnixMilli and UnixMicro (the standard time package has
+// no equivalent function).
+func UnixNano(nsec int64) time.Time {
+	return time.Unix(0, nsec)
+}
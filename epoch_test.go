@@ -0,0 +1,100 @@
+package time
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestGuessEpochUnit(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		v        int64
+		result   EpochUnit
+	}{
+		{scenario: "zero", v: 0, result: EpochSeconds},
+		{scenario: "seconds", v: 1_700_000_000, result: EpochSeconds},
+		{scenario: "negative seconds", v: -1_700_000_000, result: EpochSeconds},
+		{scenario: "millis", v: 1_700_000_000_000, result: EpochMillis},
+		{scenario: "micros", v: 1_700_000_000_000_000, result: EpochMicros},
+		{scenario: "nanos", v: 1_700_000_000_000_000_000, result: EpochNanos},
+		{scenario: "min int64", v: math.MinInt64, result: EpochNanos},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := GuessEpochUnit(tc.v)
+
+			// assert
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+// Tests that FromEpoch returns the same time for a timestamp in each unit.
+func TestFromEpoch(t *testing.T) {
+	// arrange
+	expected := time.Unix(1_700_000_000, 0)
+
+	for _, v := range []int64{1_700_000_000, 1_700_000_000_000, 1_700_000_000_000_000, 1_700_000_000_000_000_000} {
+		// act
+		result, _ := FromEpoch(v)
+
+		// assert
+		test.That(t, result).Equals(expected)
+	}
+}
+
+func TestFromUnixFloat(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		seconds  float64
+		result   time.Time
+		err      error
+	}{
+		{scenario: "whole seconds", seconds: 1_700_000_000, result: time.Unix(1_700_000_000, 0)},
+		{scenario: "fractional", seconds: 1_700_000_000.25, result: time.Unix(1_700_000_000, 250_000_000)},
+		{scenario: "negative", seconds: -1.5, result: time.Unix(-2, 500_000_000)},
+		{scenario: "NaN", seconds: math.NaN(), err: ErrTimestampOutOfRange},
+		{scenario: "infinite", seconds: math.Inf(1), err: ErrTimestampOutOfRange},
+		{scenario: "too large", seconds: 1e19, err: ErrTimestampOutOfRange},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, err := FromUnixFloat(tc.seconds)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestUnixNano(t *testing.T) {
+	// act
+	result := UnixNano(1_700_000_000_123_456_789)
+
+	// assert
+	test.That(t, result).Equals(time.Unix(1_700_000_000, 123_456_789))
+}
+
+func TestEpochUnit_String(t *testing.T) {
+	testcases := []struct {
+		sut    EpochUnit
+		result string
+	}{
+		{sut: EpochSeconds, result: "EpochSeconds"},
+		{sut: EpochMillis, result: "EpochMillis"},
+		{sut: EpochMicros, result: "EpochMicros"},
+		{sut: EpochNanos, result: "EpochNanos"},
+		{sut: EpochUnit(-1), result: "<invalid EpochUnit(-1)>"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.result, func(t *testing.T) {
+			test.That(t, tc.sut.String()).Equals(tc.result)
+		})
+	}
+}
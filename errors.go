@@ -3,15 +3,42 @@ package time
 import "errors"
 
 var (
-	ErrClockAlreadyExists = errors.New("clock already exists")
-	ErrClockIsRunning     = errors.New("clock is running")
-	ErrClockNotRunning    = errors.New("clock is stopped")
-	ErrNotADelorean       = errors.New("not a DeLorean clock (cannot go back in time)")
+	ErrAdvanceAborted      = errors.New("advance aborted")
+	ErrAdvanceStalled      = errors.New("advance stalled")
+	ErrBatcherClosed       = errors.New("batcher closed")
+	ErrChannelClosed       = errors.New("channel closed")
+	ErrClockAlreadyExists  = errors.New("clock already exists")
+	ErrClockClosed         = errors.New("clock is closed")
+	ErrClockIsRunning      = errors.New("clock is running")
+	ErrClockNotRunning     = errors.New("clock is stopped")
+	ErrFutureTimestamp     = errors.New("timestamp is in the future")
+	ErrInsufficientTime    = errors.New("insufficient time remaining")
+	ErrInvalidDuration     = errors.New("invalid duration")
+	ErrInvalidISOWeekDate  = errors.New("invalid ISO week date")
+	ErrInvalidRelativeTime = errors.New("invalid relative time")
+	ErrInvalidRetryAfter   = errors.New("invalid Retry-After")
+	ErrInvalidSchedule     = errors.New("invalid schedule")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrInvalidTimeOfDay    = errors.New("invalid time of day")
+	ErrLeaseHeld           = errors.New("lease held")
+	ErrLeaseNotHeld        = errors.New("lease not held")
+	ErrNoMatchingLayout    = errors.New("no matching layout")
+	ErrNotADelorean        = errors.New("not a DeLorean clock (cannot go back in time)")
+	ErrStaleTimestamp      = errors.New("timestamp is stale")
+	ErrTimeout             = errors.New("timeout")
+	ErrTimestampOutOfRange = errors.New("timestamp out of range")
+	ErrTokenExpired        = errors.New("token has expired")
+	ErrTokenIssuedInFuture = errors.New("token issued in the future")
+	ErrTokenNotYetValid    = errors.New("token is not yet valid")
+	ErrUnsupportedFormat   = errors.New("unsupported format")
 
 	errClockLocked       = errors.New("clock is locked")
+	errInvalidDrift      = errors.New("invalid drift")
+	errInvalidSpeed      = errors.New("invalid speed")
 	errInvalidState      = errors.New("not a valid state")
 	errInvalidTransition = errors.New("invalid state transition")
 
+	errNegativeQueueLength        = errors.New("time: negative queue length")
 	errNonPositiveInterval        = errors.New("time: non-positive interval")
 	errResetCalledOnUninitialized = errors.New("time: Reset called on uninitialized")
 )
@@ -0,0 +1,140 @@
+package time
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// decay returns the factor by which a value decays over a given elapsed time,
+// with a given half-life.
+func decay(elapsed, halfLife time.Duration) float64 {
+	if elapsed <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(elapsed) / float64(halfLife))
+}
+
+// EWMA is an exponentially weighted moving average of values observed over
+// time, such as latencies used to derive an adaptive timeout.
+//
+// The weight given to each observation decays according to the time elapsed
+// since it was observed (as determined by a clock) rather than the number of
+// observations since, so that the average is unaffected by the rate at which
+// values are observed.  After one half-life an observation contributes half
+// as much to the average as it did when observed.
+type EWMA struct {
+	mu       sync.Mutex
+	clock    Clock
+	halfLife time.Duration
+	value    float64
+	updated  time.Time
+	observed bool
+}
+
+// NewEWMA returns an EWMA with a given half-life using a given clock (or the
+// system clock, if nil).  The half-life must be greater than zero; if
+// halfLife <= 0, NewEWMA will panic.
+func NewEWMA(clock Clock, halfLife time.Duration) *EWMA {
+	if halfLife <= 0 {
+		panic(fmt.Errorf("%w for NewEWMA", errNonPositiveInterval))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &EWMA{clock: clock, halfLife: halfLife}
+}
+
+// Observe updates the average with a value observed at the current time of
+// the clock.  The first value observed initialises the average.
+func (e *EWMA) Observe(v float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.clock.Now()
+	if !e.observed {
+		e.value, e.updated, e.observed = v, now, true
+		return
+	}
+	w := decay(now.Sub(e.updated), e.halfLife)
+	e.value = e.value*w + v*(1-w)
+	e.updated = now
+}
+
+// ObserveDuration updates the average with a duration, in nanoseconds.
+func (e *EWMA) ObserveDuration(d time.Duration) {
+	e.Observe(float64(d))
+}
+
+// Value returns the average, or zero if no value has been observed.
+func (e *EWMA) Value() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.value
+}
+
+// Duration returns the average as a duration, for an average of durations.
+func (e *EWMA) Duration() time.Duration {
+	return time.Duration(math.Round(e.Value()))
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// RateEstimator estimates the rate at which events occur (such as requests
+// or bytes transferred), giving exponentially decaying weight to past events
+// according to the time elapsed since they occurred (as determined by a
+// clock).
+//
+// For events occurring at a constant rate the estimate converges on that
+// rate; when events cease the estimate halves with each half-life.
+type RateEstimator struct {
+	mu       sync.Mutex
+	clock    Clock
+	halfLife time.Duration
+	count    float64
+	updated  time.Time
+}
+
+// NewRateEstimator returns a RateEstimator with a given half-life using a
+// given clock (or the system clock, if nil).  The half-life must be greater
+// than zero; if halfLife <= 0, NewRateEstimator will panic.
+func NewRateEstimator(clock Clock, halfLife time.Duration) *RateEstimator {
+	if halfLife <= 0 {
+		panic(fmt.Errorf("%w for NewRateEstimator", errNonPositiveInterval))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &RateEstimator{clock: clock, halfLife: halfLife, updated: clock.Now()}
+}
+
+// Add records n events at the current time of the clock.
+func (r *RateEstimator) Add(n float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decay()
+	r.count += n
+}
+
+// Rate returns the estimated number of events per second at the current time
+// of the clock.
+func (r *RateEstimator) Rate() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decay()
+	return r.count * math.Ln2 / r.halfLife.Seconds()
+}
+
+// decay decays the count of events to the current time of the clock.
+//
+// This method is not thread-safe and should only be called while the
+// estimator is locked.
+func (r *RateEstimator) decay() {
+	now := r.clock.Now()
+	r.count *= decay(now.Sub(r.updated), r.halfLife)
+	r.updated = now
+}
@@ -0,0 +1,78 @@
+package time
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that observations are weighted according to the time elapsed.
+func TestEWMA(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = NewEWMA(clock, 10*time.Second)
+	)
+
+	testcases := []struct {
+		scenario string
+		advance  time.Duration
+		value    float64
+		result   float64
+	}{
+		{scenario: "first observation", value: 100, result: 100},
+		{scenario: "same instant", value: 0, result: 100},
+		{scenario: "after one half-life", advance: 10 * time.Second, value: 0, result: 50},
+		{scenario: "after two half-lives", advance: 20 * time.Second, value: 100, result: 87.5},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			clock.AdvanceBy(tc.advance)
+			sut.Observe(tc.value)
+
+			// assert
+			test.That(t, sut.Value()).Equals(tc.result)
+		})
+	}
+}
+
+// Tests an average of durations.
+func TestEWMA_Duration(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewEWMA(clock, time.Minute)
+	sut.ObserveDuration(200 * time.Millisecond)
+
+	// act
+	clock.AdvanceBy(time.Minute)
+	sut.ObserveDuration(100 * time.Millisecond)
+
+	// assert
+	test.That(t, sut.Duration()).Equals(150 * time.Millisecond)
+}
+
+// Tests that a rate estimate converges on a constant rate and decays when
+// events cease.
+func TestRateEstimator(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock(Yielding(0))
+		sut   = NewRateEstimator(clock, time.Second)
+	)
+
+	// act
+	for range 1000 {
+		clock.AdvanceBy(10 * time.Millisecond)
+		sut.Add(1)
+	}
+	steady := sut.Rate()
+	clock.AdvanceBy(time.Second)
+	decayed := sut.Rate()
+
+	// assert
+	test.IsTrue(t, math.Abs(steady-100) < 1, "steady rate")
+	test.That(t, decayed).Equals(steady / 2)
+}
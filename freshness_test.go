@@ -0,0 +1,77 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestValidateFreshness(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	testcases := []struct {
+		scenario string
+		ts       time.Time
+		err      error
+	}{
+		{scenario: "current", ts: now},
+		{scenario: "at max age", ts: now.Add(-5 * time.Minute)},
+		{scenario: "within skew of max age", ts: now.Add(-5*time.Minute - 30*time.Second)},
+		{scenario: "stale", ts: now.Add(-5*time.Minute - 31*time.Second), err: ErrStaleTimestamp},
+		{scenario: "within skew in the future", ts: now.Add(30 * time.Second)},
+		{scenario: "in the future", ts: now.Add(31 * time.Second), err: ErrFutureTimestamp},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(now))
+
+			// act
+			err := ValidateFreshness(clock, tc.ts, 5*time.Minute, 30*time.Second)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+		})
+	}
+}
+
+func TestVerifySignedTimestamp(t *testing.T) {
+	var (
+		key = []byte("secret")
+		msg = []byte(`{"amount":100}`)
+		now = time.Unix(1000, 0)
+	)
+
+	testcases := []struct {
+		scenario string
+		signed   string
+		msg      []byte
+		advance  time.Duration
+		err      error
+	}{
+		{scenario: "valid", signed: SignTimestamp(key, now, msg), msg: msg},
+		{scenario: "different message", signed: SignTimestamp(key, now, msg), msg: []byte(`{"amount":1000}`), err: ErrInvalidSignature},
+		{scenario: "different key", signed: SignTimestamp([]byte("other"), now, msg), msg: msg, err: ErrInvalidSignature},
+		{scenario: "malformed", signed: "1000", msg: msg, err: ErrInvalidSignature},
+		{scenario: "malformed timestamp", signed: "x.00", msg: msg, err: ErrInvalidSignature},
+		{scenario: "malformed signature", signed: "1000.xyz", msg: msg, err: ErrInvalidSignature},
+		{scenario: "replayed later", signed: SignTimestamp(key, now, msg), msg: msg, advance: 10 * time.Minute, err: ErrStaleTimestamp},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(now))
+			clock.AdvanceBy(tc.advance)
+
+			// act
+			ts, err := VerifySignedTimestamp(clock, key, tc.signed, tc.msg, 5*time.Minute, 30*time.Second)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			if tc.err == nil {
+				test.That(t, ts).Equals(now)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package time
+
+import (
+	"context"
+	"sync"
+)
+
+// Future is the result of an asynchronous operation, which is either
+// completed with a value or failed with an error.  The result may be awaited,
+// subject to the cancellation, deadline or timeout of a context; timeouts are
+// those of the Clock in the context, so that awaiting a result is
+// deterministic when using a mock clock.
+//
+// A Future is resolved only once; attempts to complete or fail a Future that
+// has already been resolved are ignored.
+type Future[T any] struct {
+	once  sync.Once
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// NewFuture returns a new, unresolved Future.
+func NewFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// Complete resolves the Future with a given value, returning true if the
+// Future was resolved or false if it had already been resolved.
+func (f *Future[T]) Complete(v T) bool {
+	return f.resolve(v, nil)
+}
+
+// Fail resolves the Future with a given error, returning true if the Future
+// was resolved or false if it had already been resolved.
+func (f *Future[T]) Fail(err error) bool {
+	var zero T
+	return f.resolve(zero, err)
+}
+
+// Done returns a channel that is closed when the Future is resolved.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await waits for the Future to be resolved, returning the value or error
+// with which it was resolved.  If the context is done before the Future is
+// resolved, the zero value is returned with the error of the context.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+	}
+
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// AwaitTimeout waits for the Future to be resolved, as for Await, for no
+// longer than a timeout elapsing according to the Clock in the context
+// (see: ContextWithTimeout).  If the timeout elapses first, the zero value is
+// returned with context.DeadlineExceeded.
+func (f *Future[T]) AwaitTimeout(ctx context.Context, d Duration) (T, error) {
+	ctx, cancel := ContextWithTimeout(ctx, d)
+	defer cancel()
+
+	return f.Await(ctx)
+}
+
+// resolve resolves the Future with a given value and error, returning true if
+// the Future was resolved or false if it had already been resolved.
+func (f *Future[T]) resolve(v T, err error) bool {
+	resolved := false
+	f.once.Do(func() {
+		f.value, f.err = v, err
+		close(f.done)
+		resolved = true
+	})
+	return resolved
+}
@@ -0,0 +1,104 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestFuture(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		exec     func(t *testing.T)
+	}{
+		{scenario: "completed",
+			exec: func(t *testing.T) {
+				// arrange
+				sut := NewFuture[int]()
+
+				// act
+				ok := sut.Complete(42)
+				result, err := sut.Await(context.Background())
+
+				// assert
+				test.IsTrue(t, ok)
+				test.Error(t, err).IsNil()
+				test.That(t, result).Equals(42)
+			},
+		},
+		{scenario: "failed",
+			exec: func(t *testing.T) {
+				// arrange
+				errFailed := errors.New("failed")
+				sut := NewFuture[int]()
+
+				// act
+				ok := sut.Fail(errFailed)
+				_, err := sut.Await(context.Background())
+
+				// assert
+				test.IsTrue(t, ok)
+				test.Error(t, err).Is(errFailed)
+			},
+		},
+		{scenario: "already resolved",
+			exec: func(t *testing.T) {
+				// arrange
+				sut := NewFuture[int]()
+				sut.Complete(1)
+
+				// act
+				completed := sut.Complete(2)
+				failed := sut.Fail(errors.New("failed"))
+				result, err := sut.Await(context.Background())
+
+				// assert
+				test.IsFalse(t, completed)
+				test.IsFalse(t, failed)
+				test.Error(t, err).IsNil()
+				test.That(t, result).Equals(1)
+			},
+		},
+		{scenario: "resolved while awaited",
+			exec: func(t *testing.T) {
+				// arrange
+				sut := NewFuture[string]()
+				go sut.Complete("done")
+
+				// act
+				result, err := sut.Await(context.Background())
+
+				// assert
+				test.Error(t, err).IsNil()
+				test.That(t, result).Equals("done")
+				_, open := <-sut.Done()
+				test.IsFalse(t, open)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			tc.exec(t)
+		})
+	}
+}
+
+// Tests that AwaitTimeout times out according to the clock in the context.
+func TestFuture_AwaitTimeout(t *testing.T) {
+	// arrange
+	ctx, clock := ContextWithMockClock(context.Background())
+	sut := NewFuture[int]()
+	go func() {
+		waitFor(func() bool { return created(clock) > 0 })
+		clock.AdvanceBy(time.Second)
+	}()
+
+	// act
+	_, err := sut.AwaitTimeout(ctx, time.Second)
+
+	// assert
+	test.Error(t, err).Is(context.DeadlineExceeded)
+}
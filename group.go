@@ -0,0 +1,183 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// WaitTimeout waits for a WaitGroup to complete or for a timeout to elapse
+// according to a given clock, returning nil if the WaitGroup completed or
+// ErrTimeout if the timeout elapsed first.
+//
+// If the timeout elapses the WaitGroup continues to be waited on by a
+// goroutine which ends when the WaitGroup completes.
+func WaitTimeout(clock Clock, wg *sync.WaitGroup, d Duration) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wg.Wait()
+	}()
+
+	return waitDone(clock, done, d)
+}
+
+// waitDone waits for a channel to be closed or for a timeout to elapse
+// according to a given clock, returning ErrTimeout if the timeout elapsed
+// first.
+func waitDone(clock Clock, done <-chan struct{}, d Duration) error {
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w: waited %s", ErrTimeout, d)
+	}
+}
+
+// Group is a collection of goroutines working on subtasks of a common task,
+// similar to errgroup.Group, for which Wait may be bounded by a deadline or
+// timeout according to a clock.
+//
+// A Group may be created using NewGroup or GroupWithContext; the zero value
+// is a valid Group using the system clock.  A Group must not be reused: Go
+// must not be called once all functions in the group have returned.
+type Group struct {
+	clock  Clock
+	cancel context.CancelCauseFunc
+
+	// deadline is the context with the timeout of a group created using
+	// GroupWithTimeout (and stop its cancel function); nil otherwise
+	deadline context.Context
+	stop     context.CancelFunc
+
+	wg   sync.WaitGroup
+	once sync.Once
+	err  error
+	done chan struct{}
+	init sync.Once
+}
+
+// NewGroup returns a new Group using a given clock for deadlines and timeouts
+// of Wait.  If the clock is nil the system clock is used.
+func NewGroup(clock Clock) *Group {
+	return &Group{clock: clock}
+}
+
+// GroupWithContext returns a new Group using the clock in a given context
+// (see: ClockFromContext) and a context derived from the given context, which
+// is cancelled when a function passed to Go first returns an error or when
+// Wait returns (other than on a timeout), whichever occurs first.
+func GroupWithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{clock: ClockFromContext(ctx), cancel: cancel}, ctx
+}
+
+// GroupWithTimeout returns a new Group as for GroupWithContext, with a context
+// derived from the given context with a timeout using the clock in the given
+// context (see: ContextWithTimeout).
+//
+// If the timeout elapses before any function passed to Go returns an error,
+// the error returned by Wait wraps ErrTimeout (and the first error returned by
+// a function, typically the error of the context), distinguishing a group
+// terminated by its deadline from one terminated by the error of a function.
+func GroupWithTimeout(ctx context.Context, d Duration) (*Group, context.Context) {
+	deadline, stop := ContextWithTimeout(ctx, d)
+	g, ctx := GroupWithContext(deadline)
+	g.deadline, g.stop = deadline, stop
+	return g, ctx
+}
+
+// Go calls a function in a new goroutine.  The first error returned by a
+// function in the group is returned by Wait and, if the group was created
+// using GroupWithContext, cancels the context of the group.
+func (g *Group) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if err := fn(); err != nil {
+			g.once.Do(func() {
+				if g.deadline != nil && errors.Is(g.deadline.Err(), context.DeadlineExceeded) {
+					err = fmt.Errorf("%w: %w", ErrTimeout, err)
+				}
+				g.err = err
+				if g.cancel != nil {
+					g.cancel(err)
+				}
+			})
+		}
+	}()
+}
+
+// Wait blocks until all functions passed to Go have returned, returning the
+// first error (if any) returned by them.
+func (g *Group) Wait() error {
+	<-g.completed()
+	return g.result()
+}
+
+// WaitTimeout blocks until all functions passed to Go have returned or a
+// timeout has elapsed according to the clock of the group.  It returns the
+// first error (if any) returned by the functions, or ErrTimeout if the
+// timeout elapsed before all of the functions returned.
+//
+// After a timeout the functions in the group continue to run; Wait (or
+// WaitTimeout) may be called again to wait for them.
+func (g *Group) WaitTimeout(d Duration) error {
+	if err := waitDone(g.clockOrDefault(), g.completed(), d); err != nil {
+		return err
+	}
+	return g.result()
+}
+
+// WaitUntil blocks until all functions passed to Go have returned or a
+// deadline has been reached according to the clock of the group, as for
+// WaitTimeout.
+func (g *Group) WaitUntil(deadline Time) error {
+	return g.WaitTimeout(g.clockOrDefault().Until(deadline))
+}
+
+// clockOrDefault returns the clock of the group or the system clock if the
+// group has no clock.
+func (g *Group) clockOrDefault() Clock {
+	if g.clock == nil {
+		return SystemClock()
+	}
+	return g.clock
+}
+
+// completed returns a channel that is closed when all functions passed to Go
+// have returned.  The channel is established on the first call.
+func (g *Group) completed() <-chan struct{} {
+	g.init.Do(func() {
+		g.done = make(chan struct{})
+		go func() {
+			defer close(g.done)
+			g.wg.Wait()
+		}()
+	})
+	return g.done
+}
+
+// result returns the first error returned by a function in the group,
+// cancelling the context of the group (if any) and releasing the timeout of
+// a group created using GroupWithTimeout.
+func (g *Group) result() error {
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	if g.stop != nil {
+		g.stop()
+	}
+	return g.err
+}
@@ -0,0 +1,192 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestWaitTimeout(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		exec     func(t *testing.T)
+	}{
+		{scenario: "completed",
+			exec: func(t *testing.T) {
+				// arrange
+				wg := &sync.WaitGroup{}
+				wg.Add(1)
+				go wg.Done()
+
+				// act
+				err := WaitTimeout(NewMockClock(), wg, time.Second)
+
+				// assert
+				test.Error(t, err).IsNil()
+			},
+		},
+		{scenario: "timed out",
+			exec: func(t *testing.T) {
+				// arrange
+				clock := NewMockClock()
+				wg := &sync.WaitGroup{}
+				wg.Add(1)
+				defer wg.Done()
+				go func() {
+					waitFor(func() bool { return created(clock) > 0 })
+					clock.AdvanceBy(time.Second)
+				}()
+
+				// act
+				err := WaitTimeout(clock, wg, time.Second)
+
+				// assert
+				test.Error(t, err).Is(ErrTimeout)
+				test.That(t, err.Error()).Equals("timeout: waited 1s")
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			tc.exec(t)
+		})
+	}
+}
+
+// Tests that Wait returns the first error returned by a function in the group.
+func TestGroup_Wait(t *testing.T) {
+	// arrange
+	var (
+		errFailed = errors.New("failed")
+		sut       = NewGroup(nil)
+	)
+	sut.Go(func() error { return nil })
+	sut.Go(func() error { return errFailed })
+
+	// act
+	err := sut.Wait()
+
+	// assert
+	test.Error(t, err).Is(errFailed)
+}
+
+// Tests that WaitTimeout times out when the clock is advanced past the
+// timeout and that the group may then be waited on again.
+func TestGroup_WaitTimeout(t *testing.T) {
+	// arrange
+	var (
+		clock   = NewMockClock()
+		sut     = NewGroup(clock)
+		release = make(chan struct{})
+	)
+	sut.Go(func() error { <-release; return nil })
+	go func() {
+		waitFor(func() bool { return created(clock) > 0 })
+		clock.AdvanceBy(5 * time.Second)
+	}()
+
+	// act
+	err := sut.WaitTimeout(5 * time.Second)
+
+	// assert
+	test.Error(t, err).Is(ErrTimeout)
+
+	close(release)
+	test.Error(t, sut.WaitTimeout(5*time.Second)).IsNil()
+}
+
+// Tests that WaitUntil waits until a deadline according to the clock.
+func TestGroup_WaitUntil(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = NewGroup(clock)
+	)
+	sut.Go(func() error { clock.Sleep(time.Minute); return nil })
+	go func() {
+		waitFor(func() bool { return created(clock) > 1 })
+		clock.AdvanceBy(30 * time.Second)
+	}()
+
+	// act
+	err := sut.WaitUntil(time.Unix(30, 0))
+
+	// assert
+	test.Error(t, err).Is(ErrTimeout)
+}
+
+// Tests that the context of a group is cancelled by the first error.
+func TestGroupWithContext(t *testing.T) {
+	// arrange
+	var (
+		errFailed = errors.New("failed")
+		clock     = NewMockClock()
+		sut, ctx  = GroupWithContext(ContextWithClock(context.Background(), clock))
+	)
+	sut.Go(func() error { <-ctx.Done(); return ctx.Err() })
+	sut.Go(func() error { return errFailed })
+
+	// act
+	err := sut.Wait()
+
+	// assert
+	test.Error(t, err).Is(errFailed)
+	test.Error(t, context.Cause(ctx)).Is(errFailed)
+	test.IsTrue(t, sut.clock == clock)
+}
+
+// Tests that the error of a group created using GroupWithTimeout identifies
+// whether the group was terminated by its deadline or by the error of a
+// function.
+func TestGroupWithTimeout(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	t.Run("terminated by deadline", func(t *testing.T) {
+		// arrange
+		ctx, clock := ContextWithMockClock(context.Background())
+		sut, ctx := GroupWithTimeout(ctx, time.Second)
+		sut.Go(func() error { <-ctx.Done(); return ctx.Err() })
+
+		// act
+		clock.AdvanceBy(time.Second)
+		err := sut.Wait()
+
+		// assert
+		test.Error(t, err).Is(ErrTimeout)
+		test.Error(t, err).Is(context.DeadlineExceeded)
+	})
+
+	t.Run("terminated by error", func(t *testing.T) {
+		// arrange
+		ctx, clock := ContextWithMockClock(context.Background())
+		sut, ctx := GroupWithTimeout(ctx, time.Second)
+		sut.Go(func() error { <-ctx.Done(); return ctx.Err() })
+		sut.Go(func() error { return errFailed })
+
+		// act
+		err := sut.Wait()
+
+		// assert
+		test.Error(t, err).Is(errFailed)
+		test.IsFalse(t, errors.Is(err, ErrTimeout), "is ErrTimeout")
+		test.That(t, len(clock.Timers())).Equals(1)
+		test.That(t, clock.Timers()[0].State).Equals("stopped")
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		// arrange
+		ctx, _ := ContextWithMockClock(context.Background())
+		sut, _ := GroupWithTimeout(ctx, time.Second)
+		sut.Go(func() error { return nil })
+
+		// act
+		err := sut.Wait()
+
+		// assert
+		test.Error(t, err).IsNil()
+	})
+}
@@ -0,0 +1,257 @@
+package time
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheDecision is the use that a cache may make of a stored HTTP response
+// (see: CacheFreshness.Decision).
+type CacheDecision int
+
+const (
+	// CacheFresh is a response that is fresh and may be used without being
+	// revalidated.
+	CacheFresh CacheDecision = iota
+
+	// CacheStaleWhileRevalidate is a response that is stale but may be used
+	// while it is revalidated in the background (stale-while-revalidate).
+	CacheStaleWhileRevalidate
+
+	// CacheRevalidate is a response that must be revalidated before it is
+	// used.
+	CacheRevalidate
+
+	// CacheNoStore is a response that must not be stored by the cache.
+	CacheNoStore
+)
+
+// String returns the name of the decision.
+func (d CacheDecision) String() string {
+	switch d {
+	case CacheFresh:
+		return "CacheFresh"
+	case CacheStaleWhileRevalidate:
+		return "CacheStaleWhileRevalidate"
+	case CacheRevalidate:
+		return "CacheRevalidate"
+	case CacheNoStore:
+		return "CacheNoStore"
+	}
+	return "<invalid CacheDecision(" + strconv.Itoa(int(d)) + ")>"
+}
+
+// CachedResponse identifies a stored HTTP response, for the computation of
+// its freshness (see: Freshness).
+type CachedResponse struct {
+	// Header is the header of the response.
+	Header http.Header
+
+	// RequestTime is the time at which the request resulting in the response
+	// was made and ResponseTime the time at which the response was received,
+	// according to the clock of the cache.  If RequestTime is zero it is
+	// taken to be the ResponseTime.
+	RequestTime  time.Time
+	ResponseTime time.Time
+
+	// Shared indicates that the cache is a shared cache (such as a proxy)
+	// rather than a private cache (such as that of a browser).
+	Shared bool
+}
+
+// CacheFreshness describes the freshness of a stored HTTP response at a given
+// time, as determined by RFC 9111.
+type CacheFreshness struct {
+	// Lifetime is the freshness lifetime of the response.
+	Lifetime time.Duration
+
+	// Heuristic indicates that the freshness lifetime was not specified by
+	// the response and has been determined heuristically, from the time at
+	// which the response was last modified.
+	Heuristic bool
+
+	// Age is the current age of the response.
+	Age time.Duration
+
+	// NoStore indicates that the response must not be stored by the cache.
+	NoStore bool
+
+	// NoCache indicates that the response must be revalidated whenever it is
+	// used, even if fresh.
+	NoCache bool
+
+	// MustRevalidate indicates that the response must not be used once stale
+	// without being revalidated.
+	MustRevalidate bool
+
+	// StaleWhileRevalidate is the duration for which the response may be used
+	// once stale while it is revalidated in the background, and StaleIfError
+	// the duration for which it may be used once stale if revalidation fails.
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+}
+
+// Freshness returns the freshness of the response at the current time of a
+// clock.
+//
+// The current age is computed from the Date and Age headers of the response
+// and the times at which it was requested and received (RFC 9111, 4.2.3).
+// The freshness lifetime is determined from the s-maxage (for a shared cache)
+// or max-age directives of the Cache-Control header or, in their absence, the
+// Expires header (relative to the Date of the response).  If none of these is
+// present, a heuristic lifetime of 10% of the time since the Last-Modified
+// time of the response is used (RFC 9111, 4.2.2).
+//
+// An invalid Expires header is treated as a time in the past; an invalid Date
+// header is treated as if the response had no Date, taking the date to be the
+// ResponseTime.
+func (r CachedResponse) Freshness(clock Clock) CacheFreshness {
+	cc := parseCacheControl(r.Header.Values("Cache-Control"))
+
+	result := CacheFreshness{
+		NoStore:              cc.has("no-store") || (r.Shared && cc.has("private")),
+		NoCache:              cc.has("no-cache"),
+		MustRevalidate:       cc.has("must-revalidate") || (r.Shared && cc.has("proxy-revalidate")),
+		StaleWhileRevalidate: cc.seconds("stale-while-revalidate"),
+		StaleIfError:         cc.seconds("stale-if-error"),
+	}
+
+	date := r.ResponseTime
+	if d, err := ParseHTTPDate(r.Header.Get("Date")); err == nil {
+		date = d
+	}
+
+	result.Lifetime, result.Heuristic = r.lifetime(cc, date)
+	result.Age = r.age(clock.Now(), date)
+	return result
+}
+
+// lifetime returns the freshness lifetime of the response and true if the
+// lifetime was determined heuristically.
+func (r CachedResponse) lifetime(cc cacheControl, date time.Time) (time.Duration, bool) {
+	// an invalid s-maxage or max-age is treated as a lifetime of zero (stale)
+	if r.Shared && cc.has("s-maxage") {
+		return cc.seconds("s-maxage"), false
+	}
+	if cc.has("max-age") {
+		return cc.seconds("max-age"), false
+	}
+	if h := r.Header.Get("Expires"); h != "" {
+		expires, err := ParseHTTPDate(h)
+		if err != nil {
+			return 0, false
+		}
+		return max(expires.Sub(date), 0), false
+	}
+	if modified, err := ParseHTTPDate(r.Header.Get("Last-Modified")); err == nil && modified.Before(date) {
+		return (date.Sub(modified) / 10).Truncate(time.Second), true
+	}
+	return 0, false
+}
+
+// age returns the current age of the response at a given time.
+func (r CachedResponse) age(now, date time.Time) time.Duration {
+	requested := r.RequestTime
+	if requested.IsZero() {
+		requested = r.ResponseTime
+	}
+
+	ageValue := time.Duration(0)
+	if h := strings.TrimSpace(r.Header.Get("Age")); isDigits(h, 1, 10) {
+		secs, _ := strconv.ParseInt(h, 10, 64)
+		ageValue = time.Duration(secs) * time.Second
+	}
+
+	apparent := max(r.ResponseTime.Sub(date), 0)
+	corrected := ageValue + r.ResponseTime.Sub(requested)
+	return max(apparent, corrected) + now.Sub(r.ResponseTime)
+}
+
+// IsFresh returns true if the response is fresh: its age is less than its
+// freshness lifetime.
+func (f CacheFreshness) IsFresh() bool {
+	return f.Lifetime > f.Age
+}
+
+// Remaining returns the duration for which the response remains fresh, or
+// zero if the response is stale.
+func (f CacheFreshness) Remaining() time.Duration {
+	return max(f.Lifetime-f.Age, 0)
+}
+
+// Staleness returns the duration for which the response has been stale, or
+// zero if the response is fresh.
+func (f CacheFreshness) Staleness() time.Duration {
+	return max(f.Age-f.Lifetime, 0)
+}
+
+// Decision returns the use that a cache may make of the response.
+func (f CacheFreshness) Decision() CacheDecision {
+	switch {
+	case f.NoStore:
+		return CacheNoStore
+	case f.NoCache:
+		return CacheRevalidate
+	case f.IsFresh():
+		return CacheFresh
+	case !f.MustRevalidate && f.Staleness() < f.StaleWhileRevalidate:
+		return CacheStaleWhileRevalidate
+	}
+	return CacheRevalidate
+}
+
+// UseOnError returns true if the response may be used when an attempt to
+// revalidate it has failed (stale-if-error).
+func (f CacheFreshness) UseOnError() bool {
+	return !f.NoStore && (f.IsFresh() || (!f.MustRevalidate && f.Staleness() < f.StaleIfError))
+}
+
+// MaxAge returns the value of a Cache-Control header for a response that is
+// fresh until a given time, according to the current time of a clock, e.g.
+// "max-age=3600".  A time that has already passed results in "max-age=0".
+func MaxAge(clock Clock, until time.Time) string {
+	secs := max(clock.Until(until), 0) / time.Second
+	return "max-age=" + strconv.FormatInt(int64(secs), 10)
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// cacheControl holds the directives of a Cache-Control header, keyed by the
+// (lower case) name of each directive.
+type cacheControl map[string]string
+
+// parseCacheControl parses the directives of the values of a Cache-Control
+// header.  Directives are comma separated and may have a value, which may be
+// quoted; directive names are case-insensitive.
+func parseCacheControl(values []string) cacheControl {
+	cc := cacheControl{}
+	for _, v := range values {
+		for _, directive := range strings.Split(v, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name = strings.ToLower(strings.TrimSpace(name)); name == "" {
+				continue
+			}
+			cc[name] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return cc
+}
+
+// has returns true if the header has a directive with a given name.
+func (cc cacheControl) has(name string) bool {
+	_, ok := cc[name]
+	return ok
+}
+
+// seconds returns the value of a directive as a number of seconds, or zero if
+// the directive is not present or its value is not a valid number of seconds.
+func (cc cacheControl) seconds(name string) time.Duration {
+	v := cc[name]
+	if !isDigits(v, 1, 10) {
+		return 0
+	}
+	secs, _ := strconv.ParseInt(v, 10, 64)
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,218 @@
+package time
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestCachedResponse_Freshness(t *testing.T) {
+	received := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	date := FormatHTTPDate(received)
+
+	testcases := []struct {
+		scenario string
+		header   http.Header
+		shared   bool
+		elapsed  time.Duration
+		lifetime time.Duration
+		age      time.Duration
+		decision CacheDecision
+	}{
+		{scenario: "max-age fresh",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"max-age=60"}},
+			elapsed:  30 * time.Second,
+			lifetime: time.Minute, age: 30 * time.Second, decision: CacheFresh,
+		},
+		{scenario: "max-age stale",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"max-age=60"}},
+			elapsed:  time.Minute,
+			lifetime: time.Minute, age: time.Minute, decision: CacheRevalidate,
+		},
+		{scenario: "age header",
+			header:   http.Header{"Date": {date}, "Age": {"50"}, "Cache-Control": {"max-age=60"}},
+			elapsed:  10 * time.Second,
+			lifetime: time.Minute, age: time.Minute, decision: CacheRevalidate,
+		},
+		{scenario: "s-maxage ignored by private cache",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"max-age=60, s-maxage=10"}},
+			elapsed:  30 * time.Second,
+			lifetime: time.Minute, age: 30 * time.Second, decision: CacheFresh,
+		},
+		{scenario: "s-maxage in shared cache",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"max-age=60, s-maxage=10"}},
+			shared:   true,
+			elapsed:  30 * time.Second,
+			lifetime: 10 * time.Second, age: 30 * time.Second, decision: CacheRevalidate,
+		},
+		{scenario: "invalid max-age",
+			header:  http.Header{"Date": {date}, "Cache-Control": {"max-age=soon"}, "Expires": {FormatHTTPDate(received.Add(time.Hour))}},
+			elapsed: 0, lifetime: 0, age: 0, decision: CacheRevalidate,
+		},
+		{scenario: "expires",
+			header:   http.Header{"Date": {date}, "Expires": {FormatHTTPDate(received.Add(time.Hour))}},
+			elapsed:  time.Minute,
+			lifetime: time.Hour, age: time.Minute, decision: CacheFresh,
+		},
+		{scenario: "invalid expires",
+			header:  http.Header{"Date": {date}, "Expires": {"0"}},
+			elapsed: 0, lifetime: 0, age: 0, decision: CacheRevalidate,
+		},
+		{scenario: "heuristic",
+			header:   http.Header{"Date": {date}, "Last-Modified": {FormatHTTPDate(received.Add(-10 * time.Hour))}},
+			elapsed:  30 * time.Minute,
+			lifetime: time.Hour, age: 30 * time.Minute, decision: CacheFresh,
+		},
+		{scenario: "stale while revalidate",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"max-age=60, stale-while-revalidate=30"}},
+			elapsed:  80 * time.Second,
+			lifetime: time.Minute, age: 80 * time.Second, decision: CacheStaleWhileRevalidate,
+		},
+		{scenario: "stale beyond stale-while-revalidate",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"max-age=60, stale-while-revalidate=30"}},
+			elapsed:  90 * time.Second,
+			lifetime: time.Minute, age: 90 * time.Second, decision: CacheRevalidate,
+		},
+		{scenario: "must-revalidate",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"max-age=60, must-revalidate, stale-while-revalidate=30"}},
+			elapsed:  80 * time.Second,
+			lifetime: time.Minute, age: 80 * time.Second, decision: CacheRevalidate,
+		},
+		{scenario: "no-cache",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"No-Cache", "max-age=60"}},
+			lifetime: time.Minute, decision: CacheRevalidate,
+		},
+		{scenario: "no-store",
+			header:   http.Header{"Date": {date}, "Cache-Control": {"no-store"}},
+			decision: CacheNoStore,
+		},
+		{scenario: "private in shared cache",
+			header:   http.Header{"Date": {date}, "Cache-Control": {`private="Set-Cookie", max-age=60`}},
+			shared:   true,
+			lifetime: time.Minute, decision: CacheNoStore,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(received))
+			sut := CachedResponse{Header: tc.header, ResponseTime: received, Shared: tc.shared}
+			clock.AdvanceBy(tc.elapsed)
+
+			// act
+			result := sut.Freshness(clock)
+
+			// assert
+			test.That(t, result.Lifetime, "lifetime").Equals(tc.lifetime)
+			test.That(t, result.Age, "age").Equals(tc.age)
+			test.That(t, result.Decision(), "decision").Equals(tc.decision)
+		})
+	}
+}
+
+// Tests that the age of a response accounts for the delay in receiving it and
+// for a Date header later than the time it was received.
+func TestCachedResponse_Freshness_Age(t *testing.T) {
+	// arrange
+	received := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewMockClock(AtTime(received))
+
+	testcases := []struct {
+		scenario string
+		sut      CachedResponse
+		result   time.Duration
+	}{
+		{scenario: "response delay",
+			sut: CachedResponse{
+				Header:       http.Header{"Date": {FormatHTTPDate(received)}, "Age": {"10"}},
+				RequestTime:  received.Add(-2 * time.Second),
+				ResponseTime: received,
+			},
+			result: 12 * time.Second,
+		},
+		{scenario: "apparent age",
+			sut: CachedResponse{
+				Header:       http.Header{"Date": {FormatHTTPDate(received.Add(-time.Minute))}},
+				ResponseTime: received,
+			},
+			result: time.Minute,
+		},
+		{scenario: "date in the future",
+			sut: CachedResponse{
+				Header:       http.Header{"Date": {FormatHTTPDate(received.Add(time.Minute))}},
+				ResponseTime: received,
+			},
+			result: 0,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := tc.sut.Freshness(clock)
+
+			// assert
+			test.That(t, result.Age).Equals(tc.result)
+		})
+	}
+}
+
+func TestCacheFreshness_UseOnError(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      CacheFreshness
+		result   bool
+	}{
+		{scenario: "fresh", sut: CacheFreshness{Lifetime: time.Minute}, result: true},
+		{scenario: "stale", sut: CacheFreshness{Lifetime: time.Minute, Age: 2 * time.Minute}},
+		{scenario: "stale if error", sut: CacheFreshness{Lifetime: time.Minute, Age: 2 * time.Minute, StaleIfError: 2 * time.Minute}, result: true},
+		{scenario: "must revalidate", sut: CacheFreshness{Lifetime: time.Minute, Age: 2 * time.Minute, StaleIfError: 2 * time.Minute, MustRevalidate: true}},
+		{scenario: "no store", sut: CacheFreshness{Lifetime: time.Minute, NoStore: true}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.That(t, tc.sut.UseOnError()).Equals(tc.result)
+		})
+	}
+}
+
+func TestCacheFreshness_Remaining(t *testing.T) {
+	// arrange
+	sut := CacheFreshness{Lifetime: time.Minute, Age: 20 * time.Second}
+
+	// act/assert
+	test.That(t, sut.Remaining()).Equals(40 * time.Second)
+	test.That(t, sut.Staleness()).Equals(time.Duration(0))
+
+	sut.Age = 90 * time.Second
+	test.That(t, sut.Remaining()).Equals(time.Duration(0))
+	test.That(t, sut.Staleness()).Equals(30 * time.Second)
+}
+
+func TestCacheDecision_String(t *testing.T) {
+	testcases := []struct {
+		sut    CacheDecision
+		result string
+	}{
+		{sut: CacheFresh, result: "CacheFresh"},
+		{sut: CacheStaleWhileRevalidate, result: "CacheStaleWhileRevalidate"},
+		{sut: CacheRevalidate, result: "CacheRevalidate"},
+		{sut: CacheNoStore, result: "CacheNoStore"},
+		{sut: CacheDecision(-1), result: "<invalid CacheDecision(-1)>"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.result, func(t *testing.T) {
+			test.That(t, tc.sut.String()).Equals(tc.result)
+		})
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	// act/assert
+	test.That(t, MaxAge(clock, time.Unix(3600, 500))).Equals("max-age=3600")
+	test.That(t, MaxAge(clock, time.Unix(-1, 0))).Equals("max-age=0")
+}
@@ -0,0 +1,114 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HTTPDateLayout is the layout of the preferred format for dates in HTTP
+	// headers (the IMF-fixdate format of RFC 7231), e.g.
+	// "Sun, 06 Nov 1994 08:49:37 GMT".
+	//
+	// Times must be in UTC when formatted using this layout; FormatHTTPDate
+	// ensures this.
+	HTTPDateLayout = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+	// obsolete formats which must be accepted by recipients of HTTP dates (RFC 7231)
+	httpDateRFC850 = "Monday, 02-Jan-06 15:04:05 GMT"
+	httpDateANSIC  = "Mon Jan _2 15:04:05 2006"
+)
+
+// FormatHTTPDate formats a time as an HTTP date (RFC 7231 IMF-fixdate), e.g.
+// "Sun, 06 Nov 1994 08:49:37 GMT".  The time is converted to UTC and any
+// fractional seconds are discarded.
+func FormatHTTPDate(t time.Time) string {
+	return t.UTC().Format(HTTPDateLayout)
+}
+
+// ParseHTTPDate parses an HTTP date in any of the three formats allowed by
+// RFC 7231: IMF-fixdate (preferred), the obsolete RFC 850 format and the
+// ANSI C asctime() format.  The time returned is in UTC.
+//
+// If the string cannot be parsed in any of these formats an error wrapping
+// ErrNoMatchingLayout is returned.
+func ParseHTTPDate(s string) (time.Time, error) {
+	t, _, err := ParseWithLayouts(s, HTTPDateLayout, httpDateRFC850, httpDateANSIC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: not an HTTP date: %q", ErrNoMatchingLayout, s)
+	}
+	return t.UTC(), nil
+}
+
+// HTTPDate returns the current time of a clock formatted as an HTTP date,
+// for use in the Date header of an HTTP response.
+func HTTPDate(clock Clock) string {
+	return FormatHTTPDate(clock.Now())
+}
+
+// Expires returns the value of an Expires header for a response that is fresh
+// for a given duration from the current time of a clock.
+func Expires(clock Clock, d time.Duration) string {
+	return FormatHTTPDate(clock.Now().Add(d))
+}
+
+// LastModified returns the value of a Last-Modified header for a resource
+// modified at a given time.  A Last-Modified time must not be later than the
+// Date of the response (RFC 7232), so a time later than the current time of
+// the clock is replaced by the current time.
+func LastModified(clock Clock, t time.Time) string {
+	return FormatHTTPDate(MinTime(t, clock.Now()))
+}
+
+// Age returns the age of a response, computed from the value of its Date header
+// and the current time of a clock, truncated to whole seconds.  A Date later
+// than the current time results in an age of zero.
+//
+// The age is the apparent age of the response described by RFC 7234; it does
+// not account for any Age header provided with the response.
+//
+// If the date cannot be parsed an error wrapping ErrNoMatchingLayout is returned.
+func Age(clock Clock, date string) (time.Duration, error) {
+	t, err := ParseHTTPDate(date)
+	if err != nil {
+		return 0, err
+	}
+	return MaxDuration(0, clock.Since(t)).Truncate(time.Second), nil
+}
+
+// FormatAge formats a duration as the value of an Age header: a non-negative
+// integer number of seconds.  Negative durations are formatted as zero.
+func FormatAge(d time.Duration) string {
+	return strconv.FormatInt(int64(MaxDuration(0, d)/time.Second), 10)
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, returning the
+// duration to wait before retrying a request.
+//
+// The header may specify either a number of seconds (delay-seconds) or an
+// HTTP date; a date is resolved relative to the current time of the clock.
+// A date that has already passed results in a duration of zero.
+//
+// If the header is empty or is neither a non-negative integer nor an HTTP
+// date, an error wrapping ErrInvalidRetryAfter is returned.
+func ParseRetryAfter(header string, clock Clock) (time.Duration, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, fmt.Errorf("%w: empty header", ErrInvalidRetryAfter)
+	}
+
+	// delay-seconds are limited to 10 digits (over 300 years) to avoid
+	// overflowing a Duration
+	if isDigits(header, 1, 10) {
+		secs, _ := strconv.ParseInt(header, 10, 64)
+		return time.Duration(secs) * time.Second, nil
+	}
+
+	t, err := ParseHTTPDate(header)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q: not a delay or HTTP date", ErrInvalidRetryAfter, header)
+	}
+	return MaxDuration(0, clock.Until(t)), nil
+}
@@ -0,0 +1,154 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestFormatHTTPDate(t *testing.T) {
+	// arrange
+	sut := time.Date(1994, time.November, 6, 9, 49, 37, 500, time.FixedZone("CET", 3600))
+
+	// act
+	result := FormatHTTPDate(sut)
+
+	// assert
+	test.That(t, result).Equals("Sun, 06 Nov 1994 08:49:37 GMT")
+}
+
+func TestParseHTTPDate(t *testing.T) {
+	want := time.Date(1994, time.November, 6, 8, 49, 37, 0, time.UTC)
+
+	testcases := []struct {
+		scenario string
+		input    string
+		err      error
+	}{
+		{scenario: "IMF-fixdate", input: "Sun, 06 Nov 1994 08:49:37 GMT"},
+		{scenario: "RFC 850", input: "Sunday, 06-Nov-94 08:49:37 GMT"},
+		{scenario: "asctime", input: "Sun Nov  6 08:49:37 1994"},
+		{scenario: "not an HTTP date", input: "1994-11-06T08:49:37Z", err: ErrNoMatchingLayout},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, err := ParseHTTPDate(tc.input)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			if tc.err == nil {
+				test.That(t, result).Equals(want)
+			}
+		})
+	}
+}
+
+func TestHTTPDate(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Date(1994, time.November, 6, 8, 49, 37, 0, time.UTC)))
+
+	// act
+	result := HTTPDate(clock)
+
+	// assert
+	test.That(t, result).Equals("Sun, 06 Nov 1994 08:49:37 GMT")
+}
+
+func TestExpires(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Date(1994, time.November, 6, 8, 49, 37, 0, time.UTC)))
+
+	// act
+	result := Expires(clock, time.Hour)
+
+	// assert
+	test.That(t, result).Equals("Sun, 06 Nov 1994 09:49:37 GMT")
+}
+
+func TestLastModified(t *testing.T) {
+	// arrange
+	now := time.Date(1994, time.November, 6, 8, 49, 37, 0, time.UTC)
+	clock := NewMockClock(AtTime(now))
+
+	testcases := []struct {
+		scenario string
+		modified time.Time
+		want     string
+	}{
+		{scenario: "in the past", modified: now.Add(-time.Hour), want: "Sun, 06 Nov 1994 07:49:37 GMT"},
+		{scenario: "in the future", modified: now.Add(time.Hour), want: "Sun, 06 Nov 1994 08:49:37 GMT"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := LastModified(clock, tc.modified)
+
+			// assert
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Date(1994, time.November, 6, 8, 50, 0, 0, time.UTC)))
+
+	testcases := []struct {
+		scenario string
+		date     string
+		want     time.Duration
+		err      error
+	}{
+		{scenario: "in the past", date: "Sun, 06 Nov 1994 08:49:37 GMT", want: 23 * time.Second},
+		{scenario: "in the future", date: "Sun, 06 Nov 1994 08:51:00 GMT", want: 0},
+		{scenario: "invalid date", date: "yesterday", err: ErrNoMatchingLayout},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, err := Age(clock, tc.date)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	test.That(t, FormatAge(90*time.Second+500*time.Millisecond)).Equals("90")
+	test.That(t, FormatAge(-time.Second)).Equals("0")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Date(1994, time.November, 6, 8, 49, 37, 0, time.UTC)))
+
+	testcases := []struct {
+		scenario string
+		header   string
+		want     time.Duration
+		err      error
+	}{
+		{scenario: "delay-seconds", header: "120", want: 2 * time.Minute},
+		{scenario: "zero delay-seconds", header: " 0 ", want: 0},
+		{scenario: "HTTP date", header: "Sun, 06 Nov 1994 08:50:07 GMT", want: 30 * time.Second},
+		{scenario: "HTTP date in the past", header: "Sun, 06 Nov 1994 08:00:00 GMT", want: 0},
+		{scenario: "empty", header: "", err: ErrInvalidRetryAfter},
+		{scenario: "negative", header: "-1", err: ErrInvalidRetryAfter},
+		{scenario: "too many digits", header: "12345678901", err: ErrInvalidRetryAfter},
+		{scenario: "invalid", header: "soon", err: ErrInvalidRetryAfter},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, err := ParseRetryAfter(tc.header, clock)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
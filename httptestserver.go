@@ -0,0 +1,71 @@
+package time
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// HTTPTestServer is an httptest.Server for which the context of each request
+// contains a mock clock, so that handlers using the clock in the context of
+// the request (see: ClockFromContext) run on virtual time.  Timeouts derived
+// from the context of a request (see: ContextWithTimeout), rate limits and
+// caching headers (see: HTTPDate, Expires and CachedResponse) may then be
+// exercised end-to-end, by advancing the clock of the server.
+//
+// The timeouts of the http.Server itself (such as ReadTimeout) and of the
+// client remain those of the system clock.
+type HTTPTestServer struct {
+	*httptest.Server
+
+	// Clock is the mock clock in the context of each request.
+	Clock MockClock
+
+	cancel context.CancelFunc
+}
+
+// NewHTTPTestServer starts and returns an HTTPTestServer serving a given
+// handler with a mock clock created with any options specified.  The server
+// is closed when the test (and its subtests) complete.
+func NewHTTPTestServer(t testing.TB, h http.Handler, opts ...ClockOption) *HTTPTestServer {
+	t.Helper()
+
+	clock := NewMockClock(opts...)
+	ctx, cancel := context.WithCancel(ContextWithClock(context.Background(), clock))
+
+	srv := &HTTPTestServer{
+		Server: httptest.NewUnstartedServer(h),
+		Clock:  clock,
+		cancel: cancel,
+	}
+	srv.Config.BaseContext = func(net.Listener) context.Context { return ctx }
+	srv.Start()
+
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// AdvanceBy advances the clock of the server by a given duration (see:
+// MockClock.AdvanceBy).
+func (srv *HTTPTestServer) AdvanceBy(d time.Duration) {
+	srv.Clock.AdvanceBy(d)
+}
+
+// AdvanceTo advances the clock of the server to a given time (see:
+// MockClock.AdvanceTo).
+func (srv *HTTPTestServer) AdvanceTo(t time.Time) {
+	srv.Clock.AdvanceTo(t)
+}
+
+// Close cancels the context of any outstanding requests, so that handlers
+// waiting on virtual time (and the context of the request) do not prevent the
+// server from closing, then shuts down the server and closes its clock.  Calling Close more than once has no
+// effect.
+func (srv *HTTPTestServer) Close() {
+	srv.cancel()
+	srv.Server.Close()
+	_ = srv.Clock.Close()
+}
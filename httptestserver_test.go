@@ -0,0 +1,87 @@
+package time
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a timeout derived from the context of a request expires when the
+// clock of the server is advanced.
+func TestHTTPTestServer_Timeout(t *testing.T) {
+	// arrange
+	srv := NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := ContextWithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		<-ctx.Done()
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+
+	status := make(chan int)
+	go func() {
+		resp, err := srv.Client().Get(srv.URL)
+		if err != nil {
+			status <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	test.IsTrue(t, waitFor(func() bool { return len(srv.Clock.Timers()) == 1 }), "request timeout created")
+
+	// act
+	srv.AdvanceBy(5 * time.Second)
+
+	// assert
+	test.That(t, <-status).Equals(http.StatusGatewayTimeout)
+}
+
+// Tests that caching headers are computed from the clock of the server.
+func TestHTTPTestServer_Headers(t *testing.T) {
+	// arrange
+	srv := NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Expires", Expires(ClockFromContext(r.Context()), time.Hour))
+	}), AtTime(time.Unix(0, 0)))
+
+	// act
+	srv.AdvanceTo(time.Unix(60, 0))
+	resp, err := srv.Client().Get(srv.URL)
+
+	// assert
+	test.Error(t, err).IsNil()
+	_ = resp.Body.Close()
+	test.That(t, resp.Header.Get("Expires")).Equals(FormatHTTPDate(time.Unix(3660, 0)))
+}
+
+// Tests that the server may be closed while a handler is waiting on virtual
+// time.
+func TestHTTPTestServer_Close(t *testing.T) {
+	// arrange
+	srv := NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-ClockFromContext(r.Context()).After(time.Hour):
+		case <-r.Context().Done():
+		}
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		if resp, err := srv.Client().Get(srv.URL); err == nil {
+			_ = resp.Body.Close()
+		}
+		close(done)
+	}()
+	test.IsTrue(t, waitFor(func() bool { return len(srv.Clock.Timers()) == 1 }), "handler waiting")
+
+	// act
+	srv.Close()
+
+	// assert
+	<-done
+
+	defer test.ExpectPanic(ErrClockClosed).Assert(t)
+	srv.AdvanceBy(time.Second)
+}
@@ -0,0 +1,8 @@
+package id
+
+import "errors"
+
+var (
+	ErrClockMovedBackwards = errors.New("clock moved backwards")
+	ErrInvalidConfig       = errors.New("invalid configuration")
+)
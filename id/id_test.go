@@ -0,0 +1,25 @@
+package id
+
+import (
+	"github.com/blugnu/time"
+)
+
+// zeroReader is a deterministic source of "randomness", reading only zeros.
+type zeroReader struct{}
+
+func (zeroReader) Read(b []byte) (int, error) {
+	clear(b)
+	return len(b), nil
+}
+
+// fixedClock is a Clock for which Now returns a time that may be changed
+// arbitrarily, including backwards.
+type fixedClock struct {
+	time.Clock
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time { return c.now }
+
+// Sleep "sleeps" by advancing the time of the clock.
+func (c *fixedClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
@@ -0,0 +1,144 @@
+package id
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/blugnu/time"
+)
+
+const (
+	// DefaultNodeBits is the number of bits of a Snowflake id identifying
+	// the node, if not specified.
+	DefaultNodeBits = 10
+
+	// DefaultSequenceBits is the number of bits of a Snowflake id ordering the
+	// ids generated by a node in the same millisecond, if not specified.
+	DefaultSequenceBits = 12
+)
+
+// Snowflake generates 63-bit Snowflake ids, comprising (from most to least
+// significant) a timestamp in milliseconds since an epoch, the id of the node
+// generating the id and a sequence number ordering ids generated by the node in
+// the same millisecond.  The timestamp is obtained from a clock.
+//
+// If the sequence is exhausted in a millisecond, the generator sleeps (using
+// the clock) until the next millisecond.  If the clock goes backwards by no
+// more than MaxBackwards, the generator sleeps until the clock has caught up
+// with the last id generated; otherwise ErrClockMovedBackwards is returned.
+// Both behaviours may be exercised in tests using a mock clock.
+//
+// The zero value is a valid generator for node 0 using the system clock, the
+// Unix epoch and the default numbers of node and sequence bits.  A Snowflake
+// must not be copied after first use.
+type Snowflake struct {
+	// Clock is the clock providing the timestamp of each id.  If nil, the
+	// system clock is used.
+	Clock time.Clock
+
+	// Epoch is the time from which timestamps are measured.  If zero, the
+	// Unix epoch is used.
+	Epoch time.Time
+
+	// Node identifies the node generating ids; it must be representable in
+	// NodeBits bits.
+	Node int64
+
+	// NodeBits is the number of bits identifying the node.  If zero,
+	// DefaultNodeBits is used.
+	NodeBits uint
+
+	// SequenceBits is the number of bits of the sequence.  If zero,
+	// DefaultSequenceBits is used.
+	SequenceBits uint
+
+	// MaxBackwards is the maximum duration by which the clock may go
+	// backwards without an error being returned; the generator sleeps until
+	// the clock has caught up.  If zero, an error is returned whenever the
+	// clock goes backwards.
+	MaxBackwards time.Duration
+
+	mu   sync.Mutex
+	last int64
+	seq  int64
+}
+
+// Next returns the next id.
+//
+// An error is returned if the configuration of the generator is invalid or
+// if the clock has gone backwards by more than MaxBackwards.
+func (s *Snowflake) Next() (int64, error) {
+	nodeBits, seqBits, err := s.bits()
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clock := clockOf(s.Clock)
+	for {
+		ms := s.timestamp(clock.Now())
+		switch {
+		case ms < s.last:
+			behind := time.Duration(s.last-ms) * time.Millisecond
+			if behind > s.MaxBackwards {
+				return 0, fmt.Errorf("%w by %s", ErrClockMovedBackwards, behind)
+			}
+			clock.Sleep(behind)
+			continue
+
+		case ms == s.last && s.seq < 1<<seqBits-1:
+			s.seq++
+
+		case ms == s.last:
+			// the sequence is exhausted; wait for the next millisecond
+			clock.Sleep(time.Millisecond)
+			continue
+
+		default:
+			s.last, s.seq = ms, 0
+		}
+
+		return s.last<<(nodeBits+seqBits) | s.Node<<seqBits | s.seq, nil
+	}
+}
+
+// Time returns the time encoded in an id generated by the generator.
+func (s *Snowflake) Time(id int64) time.Time {
+	nodeBits, seqBits, _ := s.bits()
+	return s.epoch().Add(time.Duration(id>>(nodeBits+seqBits)) * time.Millisecond)
+}
+
+// bits returns the numbers of node and sequence bits of the generator,
+// returning ErrInvalidConfig if they (or the node id) are not valid.
+func (s *Snowflake) bits() (node, seq uint, err error) {
+	node, seq = s.NodeBits, s.SequenceBits
+	if node == 0 {
+		node = DefaultNodeBits
+	}
+	if seq == 0 {
+		seq = DefaultSequenceBits
+	}
+
+	switch {
+	case node+seq > 22:
+		return 0, 0, fmt.Errorf("%w: %d node bits and %d sequence bits leave fewer than 41 timestamp bits", ErrInvalidConfig, node, seq)
+	case s.Node < 0 || s.Node >= 1<<node:
+		return 0, 0, fmt.Errorf("%w: node %d cannot be represented in %d bits", ErrInvalidConfig, s.Node, node)
+	}
+	return node, seq, nil
+}
+
+// epoch returns the epoch of the generator.
+func (s *Snowflake) epoch() time.Time {
+	if s.Epoch.IsZero() {
+		return time.UnixMilli(0)
+	}
+	return s.Epoch
+}
+
+// timestamp returns the number of milliseconds from the epoch to a given time.
+func (s *Snowflake) timestamp(t time.Time) int64 {
+	return t.Sub(s.epoch()).Milliseconds()
+}
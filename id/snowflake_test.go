@@ -0,0 +1,115 @@
+package id
+
+import (
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+func TestSnowflake(t *testing.T) {
+	// arrange
+	epoch := time.UnixMilli(1288834974657)
+	clock := time.NewMockClock(time.AtTime(epoch.Add(time.Second)))
+	sut := &Snowflake{Clock: clock, Epoch: epoch, Node: 5}
+
+	// act
+	first, err1 := sut.Next()
+	second, err2 := sut.Next()
+
+	// assert
+	test.Error(t, err1).IsNil()
+	test.Error(t, err2).IsNil()
+	test.That(t, first).Equals(int64(1000<<22 | 5<<12))
+	test.That(t, second).Equals(int64(1000<<22 | 5<<12 | 1))
+	test.That(t, sut.Time(second)).Equals(epoch.Add(time.Second))
+}
+
+// Tests that a generator waits for the next millisecond when the sequence is
+// exhausted.
+func TestSnowflake_SequenceExhausted(t *testing.T) {
+	// arrange
+	clock := time.NewMockClock(time.AtTime(time.UnixMilli(1000)))
+	sut := &Snowflake{Clock: clock, NodeBits: 1, SequenceBits: 1}
+	_, _ = sut.Next()
+	_, _ = sut.Next()
+	go func() {
+		for len(clock.Timers()) == 0 {
+			time.SystemClock().Sleep(time.Millisecond)
+		}
+		clock.AdvanceBy(time.Millisecond)
+	}()
+
+	// act
+	result, err := sut.Next()
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, result).Equals(int64(1001 << 2))
+}
+
+// Tests the behaviour of a generator when the clock goes backwards.
+func TestSnowflake_ClockMovedBackwards(t *testing.T) {
+	testcases := []struct {
+		scenario     string
+		maxBackwards time.Duration
+		exec         func(t *testing.T, id int64, err error)
+	}{
+		{scenario: "within tolerance",
+			maxBackwards: 5 * time.Millisecond,
+			exec: func(t *testing.T, id int64, err error) {
+				test.Error(t, err).IsNil()
+				test.That(t, id).Equals(int64(1000<<22 | 1))
+			},
+		},
+		{scenario: "beyond tolerance",
+			maxBackwards: time.Millisecond,
+			exec: func(t *testing.T, id int64, err error) {
+				test.Error(t, err).Is(ErrClockMovedBackwards)
+				test.That(t, err.Error()).Equals("clock moved backwards by 5ms")
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := &fixedClock{now: time.UnixMilli(1000)}
+			sut := &Snowflake{Clock: clock, MaxBackwards: tc.maxBackwards}
+			_, _ = sut.Next()
+			clock.now = clock.now.Add(-5 * time.Millisecond)
+
+			// act
+			id, err := sut.Next()
+
+			// assert
+			tc.exec(t, id, err)
+		})
+	}
+}
+
+func TestSnowflake_InvalidConfig(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      *Snowflake
+		err      string
+	}{
+		{scenario: "too many bits",
+			sut: &Snowflake{NodeBits: 12, SequenceBits: 12},
+			err: "invalid configuration: 12 node bits and 12 sequence bits leave fewer than 41 timestamp bits",
+		},
+		{scenario: "node out of range",
+			sut: &Snowflake{Node: 1024},
+			err: "invalid configuration: node 1024 cannot be represented in 10 bits",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			_, err := tc.sut.Next()
+
+			// assert
+			test.Error(t, err).Is(ErrInvalidConfig)
+			test.That(t, err.Error()).Equals(tc.err)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package id
+
+import (
+	"io"
+	"sync"
+
+	"github.com/blugnu/time"
+)
+
+// crockford is the Crockford base32 alphabet used to encode a ULID.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID is a 128-bit universally unique lexicographically sortable identifier,
+// comprising a 48-bit Unix timestamp in milliseconds and 80 bits of
+// randomness.
+type ULID [16]byte
+
+// String returns the canonical 26 character Crockford base32 encoding of the
+// ULID.
+func (u ULID) String() string {
+	buf := make([]byte, 26)
+
+	// the 128 bits are encoded as 26 5-bit groups, the first group having
+	// only 3 significant bits
+	var acc uint32
+	bits, out := 2, 0 // 2 bits of padding precede the first byte
+	for _, b := range u {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			buf[out] = crockford[(acc>>bits)&0x1f]
+			out++
+		}
+	}
+	return string(buf)
+}
+
+// Time returns the time encoded in the ULID, with millisecond precision, in
+// UTC.
+func (u ULID) Time() time.Time {
+	return time.UnixMilli(timestamp(u[:6])).UTC()
+}
+
+// ULIDGenerator generates monotonic ULIDs with a timestamp obtained from a
+// clock.  ULIDs generated in the same millisecond increment the random
+// component of the previous ULID; if the random component is exhausted (or if
+// the clock goes backwards) the timestamp of the previous ULID is advanced by
+// a millisecond, so that ULIDs from a generator are strictly increasing.
+//
+// The zero value is a valid generator using the system clock and crypto/rand.
+// A ULIDGenerator must not be copied after first use.
+type ULIDGenerator struct {
+	// Clock is the clock providing the timestamp of each ULID.  If nil, the
+	// system clock is used.
+	Clock time.Clock
+
+	// Rand is the source of the random component of each ULID.  If nil,
+	// crypto/rand is used.
+	Rand io.Reader
+
+	mu      sync.Mutex
+	started bool
+	last    ULID
+}
+
+// New returns a new ULID.
+//
+// New panics if the source of randomness fails.
+func (g *ULIDGenerator) New() ULID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	u := g.last
+	ms := clockOf(g.Clock).Now().UnixMilli()
+	switch last := timestamp(u[:6]); {
+	case ms > last || !g.started:
+		putTimestamp(u[:6], ms)
+		readRandom(g.Rand, u[6:])
+	case increment(u[6:]):
+		// same (or earlier) millisecond: the incremented random component
+		// orders this ULID after the previous one
+	default:
+		putTimestamp(u[:6], last+1)
+		readRandom(g.Rand, u[6:])
+	}
+
+	g.last, g.started = u, true
+	return u
+}
+
+// increment increments a big-endian unsigned integer in place, returning
+// false if the integer overflowed.
+func increment(buf []byte) bool {
+	for i := len(buf) - 1; i >= 0; i-- {
+		if buf[i]++; buf[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
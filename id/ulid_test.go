@@ -0,0 +1,49 @@
+package id
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+func TestULIDGenerator(t *testing.T) {
+	// arrange
+	clock := time.NewMockClock(time.AtTime(time.UnixMilli(1469922850259)))
+	sut := &ULIDGenerator{Clock: clock, Rand: zeroReader{}}
+
+	// act
+	first := sut.New()
+	second := sut.New()
+
+	// assert
+	test.That(t, first.String()).Equals("01ARZ3NDEK0000000000000000")
+	test.That(t, second.String()).Equals("01ARZ3NDEK0000000000000001")
+	test.That(t, first.Time()).Equals(time.UnixMilli(1469922850259).UTC())
+}
+
+// Tests that ULIDs remain strictly increasing if the random component is
+// exhausted or the clock goes backwards.
+func TestULIDGenerator_Monotonic(t *testing.T) {
+	// arrange
+	clock := &fixedClock{now: time.UnixMilli(1469922850259)}
+	sut := &ULIDGenerator{Clock: clock}
+
+	// act
+	result := []string{}
+	for range 100 {
+		result = append(result, sut.New().String())
+	}
+	putTimestamp(sut.last[:6], 1469922850259)
+	copy(sut.last[6:], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	exhausted := sut.New()
+	clock.now = clock.now.Add(-time.Second)
+	backwards := sut.New()
+
+	// assert
+	test.IsTrue(t, slices.IsSorted(result))
+	test.That(t, len(slices.Compact(result))).Equals(100)
+	test.That(t, exhausted.Time()).Equals(time.UnixMilli(1469922850260).UTC())
+	test.IsTrue(t, backwards.String() > exhausted.String())
+}
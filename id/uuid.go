@@ -0,0 +1,139 @@
+// Package id provides generators of time-ordered identifiers (UUIDv7, ULID and
+// Snowflake ids) whose timestamp component is obtained from a Clock.
+//
+// Identifiers generated in the same millisecond are ordered using a monotonic
+// counter, so that identifiers from a generator are strictly increasing even
+// if the clock does not advance (or goes backwards).  Using a mock clock (and
+// a deterministic source of randomness) tests can assert deterministic,
+// ordered identifiers:
+//
+//	clock := time.NewMockClock(time.AtTime(start))
+//	gen := &id.UUIDGenerator{Clock: clock}
+//	a, b := gen.New(), gen.New() // a < b, both with the timestamp of start
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"sync"
+
+	"github.com/blugnu/time"
+)
+
+// UUID is a 128-bit universally unique identifier (RFC 9562).
+type UUID [16]byte
+
+// String returns the canonical (hyphenated, lower-case hex) form of the UUID.
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+// Version returns the version of the UUID.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time returns the time encoded in a version 7 UUID, with millisecond
+// precision, in UTC.
+func (u UUID) Time() time.Time {
+	return time.UnixMilli(timestamp(u[:6])).UTC()
+}
+
+// UUIDGenerator generates version 7 UUIDs (RFC 9562), comprising a 48-bit
+// Unix timestamp in milliseconds obtained from a clock, a 12-bit counter
+// ordering UUIDs generated in the same millisecond and 62 random bits.
+//
+// The counter is reset to zero each millisecond; if it is exhausted (or if
+// the clock goes backwards) the timestamp of the previous UUID is advanced by
+// a millisecond, so that UUIDs from a generator are strictly increasing.
+//
+// The zero value is a valid generator using the system clock and crypto/rand.
+// A UUIDGenerator must not be copied after first use.
+type UUIDGenerator struct {
+	// Clock is the clock providing the timestamp of each UUID.  If nil, the
+	// system clock is used.
+	Clock time.Clock
+
+	// Rand is the source of the random bits of each UUID.  If nil,
+	// crypto/rand is used.
+	Rand io.Reader
+
+	mu      sync.Mutex
+	started bool
+	ms      int64
+	seq     uint16
+}
+
+// New returns a new UUID.
+//
+// New panics if the source of randomness fails.
+func (g *UUIDGenerator) New() UUID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := clockOf(g.Clock).Now().UnixMilli()
+	switch {
+	case ms > g.ms || !g.started:
+		g.ms, g.seq, g.started = ms, 0, true
+	case g.seq < 0xfff:
+		g.seq++
+	default:
+		g.ms, g.seq = g.ms+1, 0
+	}
+
+	var u UUID
+	readRandom(g.Rand, u[8:])
+	putTimestamp(u[:6], g.ms)
+	binary.BigEndian.PutUint16(u[6:8], 0x7000|g.seq)
+	u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+
+	return u
+}
+
+// clockOf returns a given clock or the system clock if nil.
+func clockOf(c time.Clock) time.Clock {
+	if c == nil {
+		return time.SystemClock()
+	}
+	return c
+}
+
+// readRandom fills a buffer from a given source of randomness, or from
+// crypto/rand if nil, panicking if the source fails.
+func readRandom(r io.Reader, buf []byte) {
+	if r == nil {
+		r = rand.Reader
+	}
+	if _, err := io.ReadFull(r, buf); err != nil {
+		panic(err)
+	}
+}
+
+// putTimestamp writes a 48-bit big-endian timestamp to a 6 byte buffer.
+func putTimestamp(buf []byte, ms int64) {
+	for i := 5; i >= 0; i-- {
+		buf[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// timestamp reads a 48-bit big-endian timestamp from a 6 byte buffer.
+func timestamp(buf []byte) int64 {
+	var ms int64
+	for _, b := range buf[:6] {
+		ms = ms<<8 | int64(b)
+	}
+	return ms
+}
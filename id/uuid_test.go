@@ -0,0 +1,64 @@
+package id
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/blugnu/test"
+	"github.com/blugnu/time"
+)
+
+func TestUUIDGenerator(t *testing.T) {
+	// arrange
+	clock := time.NewMockClock(time.AtTime(time.UnixMilli(1700000000000)))
+	sut := &UUIDGenerator{Clock: clock, Rand: zeroReader{}}
+
+	// act
+	first := sut.New()
+	second := sut.New()
+	clock.AdvanceBy(time.Millisecond)
+	third := sut.New()
+
+	// assert
+	test.That(t, first.String()).Equals("018bcfe5-6800-7000-8000-000000000000")
+	test.That(t, second.String()).Equals("018bcfe5-6800-7001-8000-000000000000")
+	test.That(t, third.String()).Equals("018bcfe5-6801-7000-8000-000000000000")
+	test.That(t, first.Version()).Equals(7)
+	test.That(t, first.Time()).Equals(time.UnixMilli(1700000000000).UTC())
+}
+
+// Tests that UUIDs remain strictly increasing if the counter is exhausted or
+// the clock goes backwards.
+func TestUUIDGenerator_Monotonic(t *testing.T) {
+	// arrange
+	clock := &fixedClock{now: time.UnixMilli(1700000000000)}
+	sut := &UUIDGenerator{Clock: clock}
+
+	// act
+	result := []string{}
+	for range 5000 {
+		result = append(result, sut.New().String())
+	}
+	clock.now = clock.now.Add(-time.Second)
+	backwards := sut.New()
+	result = append(result, backwards.String())
+
+	// assert
+	test.IsTrue(t, slices.IsSorted(result))
+	test.That(t, len(slices.Compact(result))).Equals(5001)
+	test.That(t, backwards.Time()).Equals(time.UnixMilli(1700000000001).UTC())
+}
+
+// Tests that the zero value generator uses the system clock.
+func TestUUIDGenerator_ZeroValue(t *testing.T) {
+	// arrange
+	sut := &UUIDGenerator{}
+	before := time.SystemClock().Now().Truncate(time.Millisecond)
+
+	// act
+	result := sut.New()
+
+	// assert
+	test.IsFalse(t, result.Time().Before(before))
+	test.That(t, result.Version()).Equals(7)
+}
@@ -0,0 +1,90 @@
+package time
+
+import (
+	"iter"
+	"time"
+)
+
+// DateFilter is a function used to filter the times yielded by the Days, Weeks
+// and Months iterators; only times for which every filter returns true are
+// yielded.
+type DateFilter func(time.Time) bool
+
+// BusinessDays is a DateFilter accepting times on Monday to Friday (in the
+// location of the time).
+func BusinessDays(t time.Time) bool {
+	wd := t.Weekday()
+	return wd != time.Saturday && wd != time.Sunday
+}
+
+// ExcludingDates returns a DateFilter rejecting any time falling on the same
+// calendar date as any of the given dates (e.g. public holidays).  Dates are
+// compared in the location of the time being filtered.
+func ExcludingDates(dates ...time.Time) DateFilter {
+	return func(t time.Time) bool {
+		y, m, d := t.Date()
+		for _, date := range dates {
+			if dy, dm, dd := date.In(t.Location()).Date(); dy == y && dm == m && dd == d {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Days returns an iterator yielding the time from and the same time of day
+// on each following calendar day, in the location of from, for all days
+// before to.
+//
+// Days are calendar days, not 24 hour periods: the time of day is preserved
+// across changes in daylight saving time.
+func Days(from, to time.Time, filters ...DateFilter) iter.Seq[time.Time] {
+	return calendarSeq(from, to, filters, func(n int) time.Time {
+		return from.AddDate(0, 0, n)
+	})
+}
+
+// Weeks returns an iterator yielding the time from and the same time of day
+// and day of the week in each following week, in the location of from, for
+// all weeks before to.
+func Weeks(from, to time.Time, filters ...DateFilter) iter.Seq[time.Time] {
+	return calendarSeq(from, to, filters, func(n int) time.Time {
+		return from.AddDate(0, 0, n*7)
+	})
+}
+
+// Months returns an iterator yielding the time from and the same time of day
+// and day of the month in each following month, in the location of from, for
+// all months before to.
+//
+// If the day of the month of from does not exist in a month the last day of
+// that month is yielded (see: AddMonths and ClampToMonthEnd); a sequence from
+// 31st January yields 28th (or 29th) February, then 31st March.
+func Months(from, to time.Time, filters ...DateFilter) iter.Seq[time.Time] {
+	return calendarSeq(from, to, filters, func(n int) time.Time {
+		return AddMonths(from, n, ClampToMonthEnd)
+	})
+}
+
+// calendarSeq returns an iterator yielding the nth time of a sequence for
+// n = 0, 1, 2... until a time is not before to.  Each time is calculated
+// from the start of the sequence to avoid any accumulated drift.
+func calendarSeq(from, to time.Time, filters []DateFilter, nth func(int) time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+	next:
+		for n := 0; ; n++ {
+			t := nth(n)
+			if !t.Before(to) {
+				return
+			}
+			for _, accept := range filters {
+				if !accept(t) {
+					continue next
+				}
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
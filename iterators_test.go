@@ -0,0 +1,121 @@
+package time
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestDays(t *testing.T) {
+	// arrange
+	from := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	// act
+	result := slices.Collect(Days(from, to))
+
+	// assert
+	test.That(t, result).Equals([]time.Time{
+		time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 3, 9, 0, 0, 0, time.UTC),
+	})
+}
+
+// Tests that Days preserves the time of day across a change in daylight
+// saving time.
+func TestDays_DaylightSaving(t *testing.T) {
+	// arrange
+	loc, err := time.LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+	from := time.Date(2024, 3, 30, 9, 0, 0, 0, loc)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, loc)
+
+	// act
+	result := slices.Collect(Days(from, to))
+
+	// assert
+	test.That(t, len(result)).Equals(2)
+	test.That(t, result[1].Hour()).Equals(9)
+	test.That(t, result[1].Sub(result[0])).Equals(23 * time.Hour)
+}
+
+func TestDays_BusinessDays(t *testing.T) {
+	// arrange: Fri 29th Mar 2024 to Thu 4th Apr, excluding Easter Monday
+	from := time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 4, 0, 0, 0, 0, time.UTC)
+	holidays := ExcludingDates(time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC))
+
+	// act
+	result := slices.Collect(Days(from, to, BusinessDays, holidays))
+
+	// assert
+	test.That(t, result).Equals([]time.Time{
+		time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestDays_Break(t *testing.T) {
+	// arrange
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	n := 0
+
+	// act
+	for range Days(from, from.AddDate(1, 0, 0)) {
+		if n++; n == 3 {
+			break
+		}
+	}
+
+	// assert
+	test.That(t, n).Equals(3)
+}
+
+func TestWeeks(t *testing.T) {
+	// arrange
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC)
+
+	// act
+	result := slices.Collect(Weeks(from, to))
+
+	// assert
+	test.That(t, result).Equals([]time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+// Tests that Months clamps to the end of shorter months without drifting.
+func TestMonths(t *testing.T) {
+	// arrange
+	from := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// act
+	result := slices.Collect(Months(from, to))
+
+	// assert
+	test.That(t, result).Equals([]time.Time{
+		time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+func TestMonths_Empty(t *testing.T) {
+	// arrange
+	from := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// act
+	result := slices.Collect(Months(from, from))
+
+	// assert
+	test.That(t, len(result)).Equals(0)
+}
@@ -0,0 +1,204 @@
+package time
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LeaseStore is the interface of a store of leases, such as a distributed
+// lock service, used by a Lease.
+type LeaseStore interface {
+	// Acquire acquires the lease for a given duration.
+	Acquire(ctx context.Context, ttl time.Duration) error
+
+	// Renew extends a held lease to expire after a given duration.
+	Renew(ctx context.Context, ttl time.Duration) error
+
+	// Release releases a held lease.
+	Release(ctx context.Context) error
+}
+
+// Lease manages a lease obtained from a LeaseStore, renewing the lease
+// automatically using the timers of a clock.
+//
+// Once acquired, the lease is renewed at intervals until released.  If a
+// renewal fails it is retried at the same interval until the lease expires,
+// at which point the lease is lost.  Callbacks are called on renewal, failure
+// of a renewal and loss of the lease, on the goroutine of the renewal timer.
+//
+// Since renewals are scheduled using a clock, the timing of renewal failure
+// and loss of a lease may be tested by advancing a mock clock.
+//
+// A Lease must not be copied after first use.
+type Lease struct {
+	// Clock is the clock used to schedule renewals and determine expiry.  If
+	// nil, the system clock is used.
+	Clock Clock
+
+	// Store is the store from which the lease is obtained.
+	Store LeaseStore
+
+	// TTL is the duration for which the lease is acquired or renewed.
+	TTL time.Duration
+
+	// RenewInterval is the interval at which the lease is renewed.  If not
+	// positive (or not less than TTL), a third of the TTL is used.
+	RenewInterval time.Duration
+
+	// OnRenewed, if not nil, is called with the new expiry time of the lease
+	// when the lease is renewed automatically.
+	OnRenewed func(expires time.Time)
+
+	// OnRenewFailed, if not nil, is called with the error when an automatic
+	// renewal of the lease fails.
+	OnRenewFailed func(err error)
+
+	// OnLost, if not nil, is called when the lease expires without having
+	// been renewed.
+	OnLost func()
+
+	mu         sync.Mutex
+	held       bool
+	expires    time.Time
+	timer      *Timer
+	generation int
+}
+
+// Acquire acquires the lease from the store and starts automatic renewal.
+// It is an error to acquire a lease that is already held.
+func (l *Lease) Acquire(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held {
+		return fmt.Errorf("%w: lease is already held", ErrLeaseHeld)
+	}
+	if err := l.Store.Acquire(ctx, l.TTL); err != nil {
+		return err
+	}
+
+	l.held = true
+	l.generation++
+	l.expires = l.clock().Now().Add(l.TTL)
+	l.schedule(l.generation, l.interval())
+	return nil
+}
+
+// Renew renews the lease immediately, returning an error if the lease is not
+// held or the renewal fails.  Automatic renewal continues from the time of
+// the renewal.
+func (l *Lease) Renew(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held {
+		return ErrLeaseNotHeld
+	}
+	if err := l.Store.Renew(ctx, l.TTL); err != nil {
+		return err
+	}
+
+	l.expires = l.clock().Now().Add(l.TTL)
+	l.timer.Stop()
+	l.schedule(l.generation, l.interval())
+	return nil
+}
+
+// Release stops automatic renewal and releases the lease, returning an error
+// if the lease is not held.
+func (l *Lease) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held {
+		return ErrLeaseNotHeld
+	}
+
+	l.held = false
+	l.timer.Stop()
+	return l.Store.Release(ctx)
+}
+
+// Held returns true if the lease is held and has not expired.
+func (l *Lease) Held() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.held && l.clock().Now().Before(l.expires)
+}
+
+// Expires returns the time at which the lease expires unless renewed.  If the
+// lease is not held, the zero time is returned.
+func (l *Lease) Expires() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held {
+		return time.Time{}
+	}
+	return l.expires
+}
+
+// clock returns the clock of the lease or the system clock if none is specified.
+func (l *Lease) clock() Clock {
+	if l.Clock == nil {
+		return SystemClock()
+	}
+	return l.Clock
+}
+
+// interval returns the renewal interval of the lease.
+func (l *Lease) interval() time.Duration {
+	if l.RenewInterval <= 0 || l.RenewInterval >= l.TTL {
+		return l.TTL / 3
+	}
+	return l.RenewInterval
+}
+
+// schedule schedules an automatic renewal of the lease after a given duration.
+//
+// This method is not thread-safe and should only be called while the lease is
+// locked.
+func (l *Lease) schedule(generation int, d time.Duration) {
+	l.timer = l.clock().AfterFunc(d, func() { l.renew(generation) })
+}
+
+// renew performs an automatic renewal of the lease, if the lease is still held
+// with the given generation, declaring the lease lost if it has expired.
+func (l *Lease) renew(generation int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held || l.generation != generation {
+		return
+	}
+
+	clock := l.clock()
+	if !clock.Now().Before(l.expires) {
+		l.held = false
+		if l.OnLost != nil {
+			l.OnLost()
+		}
+		return
+	}
+
+	ctx, cancel := clock.ContextWithDeadline(context.Background(), l.expires)
+	err := l.Store.Renew(ctx, l.TTL)
+	cancel()
+
+	if err == nil {
+		l.expires = clock.Now().Add(l.TTL)
+		l.schedule(generation, l.interval())
+		if l.OnRenewed != nil {
+			l.OnRenewed(l.expires)
+		}
+		return
+	}
+
+	if l.OnRenewFailed != nil {
+		l.OnRenewFailed(err)
+	}
+	l.schedule(generation, min(l.interval(), clock.Until(l.expires)))
+}
@@ -0,0 +1,140 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// leaseStore is a LeaseStore that counts renewals and fails renewals while
+// an error is set.
+type leaseStore struct {
+	mu       sync.Mutex
+	renewals int
+	released bool
+	err      error
+}
+
+func (s *leaseStore) Acquire(context.Context, time.Duration) error { return nil }
+
+func (s *leaseStore) Renew(context.Context, time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.renewals++
+	return s.err
+}
+
+func (s *leaseStore) Release(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.released = true
+	return nil
+}
+
+func (s *leaseStore) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.renewals
+}
+
+// Tests that a lease is renewed automatically at the renewal interval.
+func TestLease_AutoRenew(t *testing.T) {
+	// arrange
+	var (
+		clock   = NewMockClock()
+		store   = &leaseStore{}
+		renewed = make(chan time.Time, 2)
+		sut     = &Lease{Clock: clock, Store: store, TTL: 30 * time.Second, OnRenewed: func(exp time.Time) { renewed <- exp }}
+	)
+	test.Error(t, sut.Acquire(context.Background())).IsNil()
+
+	// act
+	clock.AdvanceBy(10 * time.Second)
+	first := <-renewed
+	clock.AdvanceBy(10 * time.Second)
+	second := <-renewed
+
+	// assert
+	test.That(t, first).Equals(time.Unix(40, 0).UTC())
+	test.That(t, second).Equals(time.Unix(50, 0).UTC())
+	test.That(t, sut.Expires()).Equals(second)
+	test.IsTrue(t, sut.Held())
+}
+
+// Tests that failed renewals are retried and reported, and that the lease
+// is lost when it expires without having been renewed.
+func TestLease_Lost(t *testing.T) {
+	// arrange
+	var (
+		clock    = NewMockClock()
+		store    = &leaseStore{err: errors.New("store unavailable")}
+		failures = make(chan error, 3)
+		lost     = make(chan time.Time, 1)
+		sut      = &Lease{Clock: clock, Store: store, TTL: 25 * time.Second, RenewInterval: 10 * time.Second,
+			OnRenewFailed: func(err error) { failures <- err },
+			OnLost:        func() { lost <- clock.Now() },
+		}
+	)
+	test.Error(t, sut.Acquire(context.Background())).IsNil()
+
+	// act
+	clock.AdvanceBy(30 * time.Second)
+	result := <-lost
+
+	// assert
+	test.That(t, result).Equals(time.Unix(25, 0).UTC())
+	test.That(t, len(failures)).Equals(2)
+	test.That(t, store.count()).Equals(2)
+	test.IsFalse(t, sut.Held())
+	test.Error(t, sut.Renew(context.Background())).Is(ErrLeaseNotHeld)
+}
+
+// Tests that a released lease is no longer renewed.
+func TestLease_Release(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		store = &leaseStore{}
+		sut   = &Lease{Clock: clock, Store: store, TTL: 30 * time.Second}
+		ctx   = context.Background()
+	)
+	test.Error(t, sut.Acquire(ctx)).IsNil()
+	test.Error(t, sut.Acquire(ctx)).Is(ErrLeaseHeld)
+
+	// act
+	err := sut.Release(ctx)
+	clock.AdvanceBy(time.Minute)
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.IsTrue(t, store.released)
+	test.That(t, store.count()).Equals(0)
+	test.IsFalse(t, sut.Held())
+	test.Error(t, sut.Release(ctx)).Is(ErrLeaseNotHeld)
+}
+
+// Tests that a manual renewal extends the lease and reschedules automatic
+// renewal.
+func TestLease_Renew(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		store = &leaseStore{}
+		sut   = &Lease{Clock: clock, Store: store, TTL: 30 * time.Second}
+		ctx   = context.Background()
+	)
+	test.Error(t, sut.Acquire(ctx)).IsNil()
+	clock.AdvanceBy(5 * time.Second)
+
+	// act
+	err := sut.Renew(ctx)
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, sut.Expires()).Equals(time.Unix(35, 0).UTC())
+	test.That(t, clock.Timers()[1].Next).Equals(time.Unix(15, 0).UTC())
+}
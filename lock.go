@@ -0,0 +1,72 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Acquirer is the interface of a semaphore that may be acquired subject to
+// the cancellation of a context.  It is satisfied by *semaphore.Weighted from
+// golang.org/x/sync/semaphore.
+type Acquirer interface {
+	Acquire(ctx context.Context, n int64) error
+}
+
+// AcquireTimeout acquires a semaphore (with a weight of 1), waiting no longer
+// than a timeout elapsing according to a given clock.  It returns nil if the
+// semaphore was acquired, or ErrTimeout if the timeout elapsed first; any
+// other error returned by the semaphore is returned as-is.
+//
+// The timeout is applied using a context obtained from the clock, so that a
+// wait for a semaphore may be timed out by advancing a mock clock.
+func AcquireTimeout(clock Clock, sem Acquirer, d Duration) error {
+	ctx, cancel := clock.ContextWithTimeout(context.Background(), d)
+	defer cancel()
+
+	if err := sem.Acquire(ctx, 1); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("%w: semaphore not acquired after %s", ErrTimeout, d)
+		}
+		return err
+	}
+	return nil
+}
+
+// TryLockFor attempts to lock a given lock, waiting no longer than a timeout
+// elapsing according to a given clock.  It returns true if the lock was
+// obtained, false if the timeout elapsed first.
+//
+// If the timeout elapses, the lock continues to be waited for by a goroutine
+// which releases the lock as soon as it is obtained.
+func TryLockFor(clock Clock, mu sync.Locker, d Duration) bool {
+	if tl, ok := mu.(interface{ TryLock() bool }); ok && tl.TryLock() {
+		return true
+	}
+
+	var (
+		acquired  = make(chan struct{})
+		abandoned = make(chan struct{})
+	)
+	go func() {
+		mu.Lock()
+		select {
+		case acquired <- struct{}{}:
+			// the lock is handed over to the caller
+		case <-abandoned:
+			mu.Unlock()
+		}
+	}()
+
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-acquired:
+		return true
+	case <-timer.C:
+		close(abandoned)
+		return false
+	}
+}
@@ -0,0 +1,152 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// chanSemaphore is a channel-based semaphore implementing Acquirer.
+type chanSemaphore chan struct{}
+
+func (s chanSemaphore) Acquire(ctx context.Context, n int64) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// failingSemaphore is an Acquirer that always fails with a given error.
+type failingSemaphore struct{ err error }
+
+func (s failingSemaphore) Acquire(context.Context, int64) error { return s.err }
+
+func TestAcquireTimeout(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		exec     func(t *testing.T)
+	}{
+		{scenario: "acquired",
+			exec: func(t *testing.T) {
+				// arrange
+				sem := make(chanSemaphore, 1)
+
+				// act
+				err := AcquireTimeout(NewMockClock(), sem, time.Second)
+
+				// assert
+				test.Error(t, err).IsNil()
+				test.That(t, len(sem)).Equals(1)
+			},
+		},
+		{scenario: "timed out",
+			exec: func(t *testing.T) {
+				// arrange
+				clock := NewMockClock()
+				sem := make(chanSemaphore, 1)
+				sem <- struct{}{}
+				go func() {
+					waitFor(func() bool { return created(clock) > 0 })
+					clock.AdvanceBy(time.Second)
+				}()
+
+				// act
+				err := AcquireTimeout(clock, sem, time.Second)
+
+				// assert
+				test.Error(t, err).Is(ErrTimeout)
+				test.That(t, err.Error()).Equals("timeout: semaphore not acquired after 1s")
+			},
+		},
+		{scenario: "semaphore error",
+			exec: func(t *testing.T) {
+				// arrange
+				errFailed := errors.New("failed")
+
+				// act
+				err := AcquireTimeout(NewMockClock(), failingSemaphore{errFailed}, time.Second)
+
+				// assert
+				test.Error(t, err).Is(errFailed)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			tc.exec(t)
+		})
+	}
+}
+
+func TestTryLockFor(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		exec     func(t *testing.T)
+	}{
+		{scenario: "unlocked",
+			exec: func(t *testing.T) {
+				// arrange
+				mu := &sync.Mutex{}
+
+				// act
+				result := TryLockFor(NewMockClock(), mu, time.Second)
+
+				// assert
+				test.IsTrue(t, result)
+				test.IsFalse(t, mu.TryLock())
+			},
+		},
+		{scenario: "unlocked before timeout",
+			exec: func(t *testing.T) {
+				// arrange
+				clock := NewMockClock()
+				mu := &sync.Mutex{}
+				mu.Lock()
+				go func() {
+					waitFor(func() bool { return created(clock) > 0 })
+					mu.Unlock()
+				}()
+
+				// act
+				result := TryLockFor(clock, mu, time.Second)
+
+				// assert
+				test.IsTrue(t, result)
+				test.IsFalse(t, mu.TryLock())
+			},
+		},
+		{scenario: "timed out",
+			exec: func(t *testing.T) {
+				// arrange
+				clock := NewMockClock()
+				mu := &sync.Mutex{}
+				mu.Lock()
+				go func() {
+					waitFor(func() bool { return created(clock) > 0 })
+					clock.AdvanceBy(time.Second)
+				}()
+
+				// act
+				result := TryLockFor(clock, mu, time.Second)
+
+				// assert
+				test.IsFalse(t, result)
+
+				// the abandoned attempt releases the lock once it is obtained
+				mu.Unlock()
+				test.IsTrue(t, waitFor(mu.TryLock))
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			tc.exec(t)
+		})
+	}
+}
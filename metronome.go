@@ -0,0 +1,110 @@
+package time
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Metronome calls a function at a fixed rate, with each beat scheduled at an
+// absolute time (a whole number of intervals after the metronome was
+// started) rather than relative to the previous beat.  Unlike a sequence of
+// sleeps, or a Ticker delivering to a slow receiver, the beats of a
+// Metronome do not drift as a result of the time taken by the function or
+// scheduling delays.
+//
+// If the function takes longer than an interval, any beats that are missed
+// are skipped (and counted; see Skipped); the next beat is that of the next
+// interval.
+//
+// A Metronome must be stopped when no longer required.
+type Metronome struct {
+	mu       sync.Mutex
+	clock    Clock
+	interval time.Duration
+	fn       func(beat int64, at time.Time)
+	start    time.Time
+	timer    *Timer
+	skipped  int64
+	stopped  bool
+}
+
+// NewMetronome returns a started Metronome using a given clock (or the system
+// clock, if nil) that calls a function with the number of each beat (the
+// first beat is beat 1) and the time at which the beat was scheduled, at each
+// interval.  The interval must be greater than zero; if interval <= 0,
+// NewMetronome will panic.
+//
+// The function is called on its own goroutine; calls are not concurrent.
+func NewMetronome(clock Clock, interval time.Duration, fn func(beat int64, at time.Time)) *Metronome {
+	if interval <= 0 {
+		panic(fmt.Errorf("%w for NewMetronome", errNonPositiveInterval))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	m := &Metronome{clock: clock, interval: interval, fn: fn, start: clock.Now()}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedule(1)
+
+	return m
+}
+
+// Skipped returns the number of beats that have been skipped because the
+// function was still running (or had not been scheduled) when they were due.
+func (m *Metronome) Skipped() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.skipped
+}
+
+// Stop stops the metronome; no further beats are started.  Stop does not wait
+// for a call of the function that is in progress to return.
+func (m *Metronome) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stopped = true
+	m.timer.Stop()
+}
+
+// schedule schedules a given beat, or the next beat that is not yet overdue.
+//
+// This method is not thread-safe and should only be called while the
+// metronome is locked.
+func (m *Metronome) schedule(beat int64) {
+	if overdue := int64(m.clock.Since(m.start)/m.interval) + 1; overdue > beat {
+		m.skipped += overdue - beat
+		beat = overdue
+	}
+
+	at := m.start.Add(time.Duration(beat) * m.interval)
+	m.timer = m.clock.AfterFunc(m.clock.Until(at), func() { m.beat(beat, at) })
+}
+
+// beat calls the function for a beat and schedules the next.
+func (m *Metronome) beat(beat int64, at time.Time) {
+	if m.isStopped() {
+		return
+	}
+
+	m.fn(beat, at)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.stopped {
+		m.schedule(beat + 1)
+	}
+}
+
+// isStopped returns true if the metronome has been stopped.
+func (m *Metronome) isStopped() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.stopped
+}
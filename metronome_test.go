@@ -0,0 +1,87 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that beats are scheduled at whole intervals after the start.
+func TestMetronome(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		beats = make(chan int64, 3)
+		sut   = NewMetronome(clock, time.Second, func(beat int64, at time.Time) {
+			test.That(t, at).Equals(time.Unix(beat, 0).UTC())
+			beats <- beat
+		})
+	)
+	defer sut.Stop()
+
+	// act
+	result := []int64{}
+	for i := range 3 {
+		waitFor(func() bool { return created(clock) > i })
+		clock.AdvanceBy(time.Second)
+		result = append(result, <-beats)
+	}
+
+	// assert
+	test.That(t, result).Equals([]int64{1, 2, 3})
+	test.That(t, sut.Skipped()).Equals(int64(0))
+}
+
+// Tests that beats missed while the function is running are skipped.
+func TestMetronome_Skipped(t *testing.T) {
+	// arrange
+	var (
+		clock   = NewMockClock()
+		release = make(chan struct{})
+		beats   = make(chan time.Time, 2)
+		sut     = NewMetronome(clock, time.Second, func(beat int64, at time.Time) {
+			if beat == 1 {
+				<-release
+			}
+			beats <- at
+		})
+	)
+	defer sut.Stop()
+
+	// act
+	clock.AdvanceBy(2500 * time.Millisecond)
+	close(release)
+	first := <-beats
+	waitFor(func() bool { return created(clock) > 1 })
+	clock.AdvanceBy(500 * time.Millisecond)
+	next := <-beats
+
+	// assert
+	test.That(t, first).Equals(time.Unix(1, 0).UTC())
+	test.That(t, next).Equals(time.Unix(3, 0).UTC())
+	test.That(t, sut.Skipped()).Equals(int64(1))
+}
+
+// Tests that a stopped metronome does not beat.
+func TestMetronome_Stop(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		beats = make(chan int64, 1)
+		sut   = NewMetronome(clock, time.Second, func(beat int64, _ time.Time) { beats <- beat })
+	)
+
+	// act
+	sut.Stop()
+	clock.AdvanceBy(2 * time.Second)
+
+	// assert
+	test.That(t, len(beats)).Equals(0)
+}
+
+// Tests that a non-positive interval panics.
+func TestNewMetronome_NonPositiveInterval(t *testing.T) {
+	defer test.ExpectPanic(errNonPositiveInterval).Assert(t)
+	_ = NewMetronome(NewMockClock(), 0, func(int64, time.Time) {})
+}
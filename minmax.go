@@ -0,0 +1,63 @@
+package time
+
+import (
+	"time"
+)
+
+// MinTime returns the earliest of the given times.
+func MinTime(t time.Time, others ...time.Time) time.Time {
+	for _, o := range others {
+		if o.Before(t) {
+			t = o
+		}
+	}
+	return t
+}
+
+// MaxTime returns the latest of the given times.
+func MaxTime(t time.Time, others ...time.Time) time.Time {
+	for _, o := range others {
+		if o.After(t) {
+			t = o
+		}
+	}
+	return t
+}
+
+// ClampTime returns t limited to the range lo to hi (inclusive).  If t is
+// before lo, lo is returned; if t is after hi, hi is returned.
+//
+// The result is undefined if hi is before lo.
+func ClampTime(t, lo, hi time.Time) time.Time {
+	switch {
+	case t.Before(lo):
+		return lo
+	case t.After(hi):
+		return hi
+	}
+	return t
+}
+
+// MinDuration returns the shortest of the given durations.
+func MinDuration(d time.Duration, others ...time.Duration) time.Duration {
+	for _, o := range others {
+		d = min(d, o)
+	}
+	return d
+}
+
+// MaxDuration returns the longest of the given durations.
+func MaxDuration(d time.Duration, others ...time.Duration) time.Duration {
+	for _, o := range others {
+		d = max(d, o)
+	}
+	return d
+}
+
+// ClampDuration returns d limited to the range lo to hi (inclusive).  If d is
+// less than lo, lo is returned; if d is greater than hi, hi is returned.
+//
+// The result is undefined if hi is less than lo.
+func ClampDuration(d, lo, hi time.Duration) time.Duration {
+	return min(max(d, lo), hi)
+}
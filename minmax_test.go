@@ -0,0 +1,48 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestMinTime(t *testing.T) {
+	a, b, c := time.Unix(1, 0), time.Unix(2, 0), time.Unix(3, 0)
+
+	test.That(t, MinTime(a)).Equals(a)
+	test.That(t, MinTime(b, c, a)).Equals(a)
+	test.That(t, MinTime(a, b, c)).Equals(a)
+}
+
+func TestMaxTime(t *testing.T) {
+	a, b, c := time.Unix(1, 0), time.Unix(2, 0), time.Unix(3, 0)
+
+	test.That(t, MaxTime(a)).Equals(a)
+	test.That(t, MaxTime(b, c, a)).Equals(c)
+	test.That(t, MaxTime(c, b, a)).Equals(c)
+}
+
+func TestClampTime(t *testing.T) {
+	lo, hi := time.Unix(10, 0), time.Unix(20, 0)
+
+	test.That(t, ClampTime(time.Unix(5, 0), lo, hi)).Equals(lo)
+	test.That(t, ClampTime(time.Unix(15, 0), lo, hi)).Equals(time.Unix(15, 0))
+	test.That(t, ClampTime(time.Unix(25, 0), lo, hi)).Equals(hi)
+}
+
+func TestMinDuration(t *testing.T) {
+	test.That(t, MinDuration(time.Second)).Equals(time.Second)
+	test.That(t, MinDuration(time.Second, time.Minute, time.Millisecond)).Equals(time.Millisecond)
+}
+
+func TestMaxDuration(t *testing.T) {
+	test.That(t, MaxDuration(time.Second)).Equals(time.Second)
+	test.That(t, MaxDuration(time.Second, time.Minute, time.Millisecond)).Equals(time.Minute)
+}
+
+func TestClampDuration(t *testing.T) {
+	test.That(t, ClampDuration(time.Millisecond, time.Second, time.Minute)).Equals(time.Second)
+	test.That(t, ClampDuration(time.Second*30, time.Second, time.Minute)).Equals(time.Second * 30)
+	test.That(t, ClampDuration(time.Hour, time.Second, time.Minute)).Equals(time.Minute)
+}
@@ -0,0 +1,73 @@
+package time
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Eventually advances a mock clock in steps of duration poll, evaluating a
+// condition before the first advance and after each step, until either the
+// condition is satisfied or the clock has been advanced by the duration within.
+//
+// If the condition is not satisfied within the given (mock) duration, the test
+// fails.  The function returns true if the condition was satisfied.
+//
+// This provides a deterministic alternative to polling a condition in real-time
+// (as provided by testify's assert.Eventually, for example) when the code under
+// test uses the mock clock.
+//
+// The function panics if poll is zero or negative.
+func Eventually(t testing.TB, clock MockClock, within, poll time.Duration, cond func() bool) bool {
+	t.Helper()
+
+	if poll <= 0 {
+		panic(fmt.Errorf("%w for Eventually", errNonPositiveInterval))
+	}
+
+	deadline := clock.Now().Add(within)
+	for {
+		if cond() {
+			return true
+		}
+
+		now := clock.Now()
+		if !now.Before(deadline) {
+			t.Errorf("condition not satisfied within %s", within)
+			return false
+		}
+		clock.AdvanceTo(MinTime(now.Add(poll), deadline))
+	}
+}
+
+// Consistently advances a mock clock in steps of duration poll, evaluating
+// a condition before the first advance and after each step, until the clock
+// has been advanced by the duration within.
+//
+// If the condition is not satisfied at any step, the test fails and no further
+// steps are taken.  The function returns true if the condition was satisfied
+// at every step.
+//
+// The function panics if poll is zero or negative.
+func Consistently(t testing.TB, clock MockClock, within, poll time.Duration, cond func() bool) bool {
+	t.Helper()
+
+	if poll <= 0 {
+		panic(fmt.Errorf("%w for Consistently", errNonPositiveInterval))
+	}
+
+	start := clock.Now()
+	deadline := start.Add(within)
+	for {
+		now := clock.Now()
+		if !cond() {
+			t.Errorf("condition not satisfied after %s", now.Sub(start))
+			return false
+		}
+
+		if !now.Before(deadline) {
+			return true
+		}
+		clock.AdvanceTo(MinTime(now.Add(poll), deadline))
+	}
+}
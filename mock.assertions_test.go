@@ -0,0 +1,112 @@
+package time
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// fakeTB is a testing.TB which records any errors reported.
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+// Tests that Eventually advances the clock until a condition is satisfied.
+func TestEventually(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		done  atomic.Bool
+		tb    = &fakeTB{}
+	)
+	clock.AfterFunc(3*time.Second, func() { done.Store(true) })
+
+	// act
+	result := Eventually(tb, clock, 10*time.Second, time.Second, done.Load)
+
+	// assert
+	test.IsTrue(t, result)
+	test.That(t, len(tb.errors)).Equals(0)
+	test.That(t, clock.SinceCreated()).Equals(3 * time.Second)
+}
+
+// Tests that Eventually fails the test if a condition is not satisfied
+// within the specified duration.
+func TestEventually_NotSatisfied(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		tb    = &fakeTB{}
+	)
+
+	// act
+	result := Eventually(tb, clock, 2500*time.Millisecond, time.Second, func() bool { return false })
+
+	// assert
+	test.IsFalse(t, result)
+	test.That(t, tb.errors).Equals([]string{"condition not satisfied within 2.5s"})
+	test.That(t, clock.SinceCreated()).Equals(2500 * time.Millisecond)
+}
+
+// Tests that Eventually panics if the poll interval is not positive.
+func TestEventually_NonPositivePoll(t *testing.T) {
+	defer test.ExpectPanic(errNonPositiveInterval).Assert(t)
+
+	Eventually(t, NewMockClock(), time.Second, 0, func() bool { return true })
+}
+
+// Tests that Consistently advances the clock for the specified duration
+// while a condition is satisfied.
+func TestConsistently(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		tb    = &fakeTB{}
+		n     int
+	)
+
+	// act
+	result := Consistently(tb, clock, 3*time.Second, time.Second, func() bool { n++; return true })
+
+	// assert
+	test.IsTrue(t, result)
+	test.That(t, len(tb.errors)).Equals(0)
+	test.That(t, n).Equals(4)
+	test.That(t, clock.SinceCreated()).Equals(3 * time.Second)
+}
+
+// Tests that Consistently fails the test when a condition is not satisfied.
+func TestConsistently_NotSatisfied(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		fired atomic.Bool
+		tb    = &fakeTB{}
+	)
+	clock.AfterFunc(2*time.Second, func() { fired.Store(true) })
+
+	// act
+	result := Consistently(tb, clock, 10*time.Second, time.Second, func() bool { return !fired.Load() })
+
+	// assert
+	test.IsFalse(t, result)
+	test.That(t, tb.errors).Equals([]string{"condition not satisfied after 2s"})
+	test.That(t, clock.SinceCreated()).Equals(2 * time.Second)
+}
+
+// Tests that Consistently panics if the poll interval is not positive.
+func TestConsistently_NonPositivePoll(t *testing.T) {
+	defer test.ExpectPanic(errNonPositiveInterval).Assert(t)
+
+	Consistently(t, NewMockClock(), time.Second, -1, func() bool { return true })
+}
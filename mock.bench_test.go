@@ -0,0 +1,145 @@
+package time
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The benchmarks in this file measure the cost of the operations of a mock
+// clock, so that changes to the scheduling of timers and tickers may be
+// evaluated objectively.  Clocks are created with Yielding(0) so that the
+// benchmarks measure the clock rather than the yields of an advance.
+//
+// To compare a change against a baseline:
+//
+//	go test -run '^$' -bench 'BenchmarkMock' -count 10 . > old.txt
+//	(apply the change)
+//	go test -run '^$' -bench 'BenchmarkMock' -count 10 . > new.txt
+//	benchstat old.txt new.txt
+
+// timerCounts are the numbers of timers (or tickers) for which benchmarks
+// that scale with the number of timers are run.
+var timerCounts = []int{10, 100, 1000}
+
+// Benchmarks Now of a stopped and of a running clock.
+func BenchmarkMock_Now(b *testing.B) {
+	b.Run("stopped", func(b *testing.B) {
+		clock := NewMockClock(Yielding(0))
+		for range b.N {
+			_ = clock.Now()
+		}
+	})
+
+	b.Run("running", func(b *testing.B) {
+		clock := NewMockClock(Yielding(0), StartRunning())
+		for range b.N {
+			_ = clock.Now()
+		}
+	})
+}
+
+// Benchmarks advancing a clock with a number of timers that are not due.
+func BenchmarkMock_AdvanceBy(b *testing.B) {
+	for _, n := range timerCounts {
+		b.Run("timers="+strconv.Itoa(n), func(b *testing.B) {
+			clock := NewMockClock(Yielding(0))
+			for range n {
+				_ = clock.NewTimer(time.Duration(b.N+1) * time.Hour)
+			}
+
+			b.ResetTimer()
+			for range b.N {
+				clock.AdvanceBy(time.Millisecond)
+			}
+		})
+	}
+}
+
+// Benchmarks advancing a clock to the next tick of a number of tickers, each
+// received by its own goroutine.
+func BenchmarkMock_TickerFanOut(b *testing.B) {
+	for _, n := range timerCounts {
+		b.Run("tickers="+strconv.Itoa(n), func(b *testing.B) {
+			clock := NewMockClock(Yielding(0))
+			wg := sync.WaitGroup{}
+			tickers := make([]*Ticker, n)
+			for i := range tickers {
+				tickers[i] = clock.NewTicker(time.Second)
+				wg.Add(1)
+				go func(t *Ticker) {
+					defer wg.Done()
+					for range b.N {
+						<-t.C
+					}
+				}(tickers[i])
+			}
+
+			b.ResetTimer()
+			for range b.N {
+				clock.AdvanceBy(time.Second)
+			}
+			wg.Wait()
+			b.StopTimer()
+
+			for _, t := range tickers {
+				t.Stop()
+			}
+		})
+	}
+}
+
+// Benchmarks creating, resetting and stopping timers created by AfterFunc
+// with a number of other timers pending.
+func BenchmarkMock_AfterFuncChurn(b *testing.B) {
+	for _, n := range timerCounts {
+		b.Run("timers="+strconv.Itoa(n), func(b *testing.B) {
+			clock := NewMockClock(Yielding(0))
+			for i := range n {
+				_ = clock.AfterFunc(time.Duration(i+1)*time.Minute, func() {})
+			}
+
+			b.ResetTimer()
+			for range b.N {
+				t := clock.AfterFunc(time.Second, func() {})
+				_ = t.Reset(2 * time.Second)
+				_ = t.Stop()
+			}
+		})
+	}
+}
+
+// Benchmarks contention for a mock clock shared by parallel goroutines.
+func BenchmarkMock_Parallel(b *testing.B) {
+	b.Run("Now", func(b *testing.B) {
+		clock := NewMockClock()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = clock.Now()
+			}
+		})
+	})
+
+	b.Run("NewTimer/Stop", func(b *testing.B) {
+		clock := NewMockClock()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				clock.NewTimer(time.Hour).Stop()
+			}
+		})
+	})
+
+	b.Run("Now+NewTimer/Stop", func(b *testing.B) {
+		clock := NewMockClock()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if i%10 == 0 {
+					clock.NewTimer(time.Hour).Stop()
+					continue
+				}
+				_ = clock.Now()
+			}
+		})
+	})
+}
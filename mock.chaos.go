@@ -0,0 +1,134 @@
+package time
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ChaosConfig configures the disruption of timer and ticker deliveries by a
+// mock clock created with the WithChaos option.
+//
+// Each probability is in the range 0 (never) to 1 (always).
+type ChaosConfig struct {
+	// LateProbability is the probability that the firing of a timer or the
+	// tick of a ticker is delivered late.
+	LateProbability float64
+
+	// MaxLateness is the maximum duration by which a late delivery is
+	// delayed; the lateness of each late delivery is chosen at random, up
+	// to this maximum.  The tick of a ticker is not delayed beyond the
+	// interval of the ticker.
+	MaxLateness time.Duration
+
+	// CoalesceProbability is the probability that a ticker that would tick
+	// more than once in a single advance of the clock coalesces those ticks,
+	// as if the clock had been created with the CoalescesTicks option.
+	CoalesceProbability float64
+
+	// ReorderProbability is the probability that, where more than one timer
+	// or ticker is due at the same instant, the first to fire is chosen at
+	// random rather than in the order in which they were scheduled.
+	ReorderProbability float64
+}
+
+// chaos holds the configuration and random number generator used to disrupt
+// the deliveries of a mock clock (see: WithChaos).
+//
+// All methods may be called on a nil *chaos, disrupting nothing.
+type chaos struct {
+	sync.Mutex
+	cfg ChaosConfig
+	rng *rand.Rand
+}
+
+// newChaos returns a chaos with a given configuration and a random number
+// generator with a given seed.
+func newChaos(seed uint64, cfg ChaosConfig) *chaos {
+	return &chaos{
+		cfg: cfg,
+		rng: rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// chance returns true with a given probability.
+//
+// This method is not thread-safe and should only be called while the chaos
+// is locked.
+func (c *chaos) chance(p float64) bool {
+	return p > 0 && c.rng.Float64() < p
+}
+
+// lateness returns a random duration by which a delivery is to be delayed,
+// or zero if the delivery is not to be late.
+func (c *chaos) lateness() time.Duration {
+	if c == nil || c.cfg.MaxLateness <= 0 {
+		return 0
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.chance(c.cfg.LateProbability) {
+		return 0
+	}
+	return 1 + time.Duration(c.rng.Int64N(int64(c.cfg.MaxLateness)))
+}
+
+// coalesces returns true if the ticks of a ticker are to be coalesced.
+func (c *chaos) coalesces() bool {
+	if c == nil {
+		return false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	return c.chance(c.cfg.CoalesceProbability)
+}
+
+// first returns the timer or ticker to fire first from a list of active
+// timers and tickers, sorted by next tick time.  This is the first in the
+// list unless more than one is due at the same instant, in which case it may
+// be one of those chosen at random.
+func (c *chaos) first(active tickables) tickable {
+	if c == nil {
+		return active[0]
+	}
+
+	n := 1
+	for n < len(active) && active[n].nextTick().Equal(active[0].nextTick()) {
+		n++
+	}
+	if n == 1 {
+		return active[0]
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.chance(c.cfg.ReorderProbability) {
+		return active[0]
+	}
+	return active[c.rng.IntN(n)]
+}
+
+// postpone decides whether the next delivery of a due timer or ticker is to
+// be late, delaying it if so.  Each delivery is considered only once, so that
+// a late delivery is not delayed again.
+//
+// It returns true if the delivery was delayed.
+func (m *mockClock) postpone(t tickable) bool {
+	if m.chaos == nil || eval(m, t.postponed) {
+		return false
+	}
+
+	late := m.chaos.lateness()
+	m.withLock(func(m *mockClock) {
+		t.postpone(late)
+		if late > 0 {
+			m.tracef(m.now, "late: %s, next: %s", t.describe(), t.nextTick().Format(time.RFC3339Nano))
+		}
+	})
+	return late > 0
+}
@@ -0,0 +1,113 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a timer may be delivered late, by no more than the maximum lateness.
+func TestMock_WithChaos_Late(t *testing.T) {
+	// arrange
+	clock := NewMockClock(WithChaos(1, ChaosConfig{LateProbability: 1, MaxLateness: 100 * time.Millisecond}))
+	timer := clock.NewTimer(time.Second)
+
+	// act
+	clock.AdvanceBy(2 * time.Second)
+	result := <-timer.C
+
+	// assert
+	test.IsTrue(t, result.After(time.Unix(1, 0)))
+	test.IsFalse(t, result.After(time.Unix(1, int64(100*time.Millisecond))))
+}
+
+// Tests that a timer delivered late is not delivered until the clock reaches
+// the postponed time.
+func TestMock_WithChaos_Late_NotYetDue(t *testing.T) {
+	// arrange
+	var (
+		rec   = &ScheduleRecorder{}
+		clock = NewMockClock(WithRecorder(rec), WithChaos(1, ChaosConfig{LateProbability: 1, MaxLateness: time.Second}))
+	)
+	_ = clock.AfterFunc(time.Second, func() {})
+
+	// act
+	clock.AdvanceBy(time.Second)
+
+	// assert
+	test.That(t, len(rec.Trace())).Equals(1)
+	test.That(t, clock.Timers()[0].State).Equals("active")
+
+	clock.AdvanceBy(time.Second)
+	test.That(t, clock.Timers()[0].State).Equals("expired")
+}
+
+// Tests that the same seed produces the same disruption.
+func TestMock_WithChaos_Deterministic(t *testing.T) {
+	// arrange
+	deliveries := func(seed uint64) []time.Time {
+		clock := NewMockClock(WithChaos(seed, ChaosConfig{LateProbability: 0.5, MaxLateness: 500 * time.Millisecond}))
+		ticker := clock.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		result := []time.Time{}
+		for range 5 {
+			clock.AdvanceBy(time.Second)
+			select {
+			case tick := <-ticker.C:
+				result = append(result, tick)
+			case <-time.After(10 * time.Millisecond):
+				// a late tick will be delivered on a later advance
+			}
+		}
+		return result
+	}
+
+	// act
+	first := deliveries(42)
+	second := deliveries(42)
+
+	// assert
+	test.That(t, second).Equals(first)
+}
+
+// Tests that the ticks of a ticker may be coalesced.
+func TestMock_WithChaos_Coalesce(t *testing.T) {
+	// arrange
+	clock := NewMockClock(WithChaos(1, ChaosConfig{CoalesceProbability: 1}))
+	ticker := clock.NewTicker(300 * time.Millisecond)
+	defer ticker.Stop()
+
+	// act
+	clock.AdvanceBy(time.Second)
+	result := <-ticker.C
+
+	// assert
+	test.That(t, result).Equals(time.Unix(0, int64(900*time.Millisecond)).UTC())
+	test.That(t, ticker.Skipped()).Equals(2)
+}
+
+// Tests that timers due at the same instant may be reordered.
+func TestMock_WithChaos_Reorder(t *testing.T) {
+	// arrange
+	firstToFire := func(seed uint64) int {
+		rec := &ScheduleRecorder{}
+		clock := NewMockClock(WithRecorder(rec), WithChaos(seed, ChaosConfig{ReorderProbability: 1}))
+		_ = clock.AfterFunc(time.Second, func() {})
+		_ = clock.AfterFunc(time.Second, func() {})
+		_ = clock.AfterFunc(time.Second, func() {})
+
+		clock.AdvanceBy(time.Second)
+		return rec.Trace()[3].ID
+	}
+
+	// act
+	result := map[int]bool{}
+	for seed := range uint64(20) {
+		result[firstToFire(seed)] = true
+	}
+
+	// assert
+	test.That(t, len(result)).Equals(3)
+}
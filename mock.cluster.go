@@ -0,0 +1,171 @@
+package time
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// NodeConfig configures the clock of a node in a Cluster.
+type NodeConfig struct {
+	// Skew is the constant offset of the time of the node from the time of
+	// the cluster; a positive skew is a node clock that is ahead.
+	Skew time.Duration
+
+	// Drift is the rate at which the time of the node drifts from the time of
+	// the cluster, as a fraction of elapsed time: the time of the node advances
+	// by (1 + Drift) times the time by which the cluster is advanced.  e.g. a
+	// Drift of 0.001 is a node clock that gains 1ms per second.
+	//
+	// Drift must be greater than -1.
+	Drift float64
+
+	// Latency is the delay of messages delivered to the node (see:
+	// Cluster.Deliver).
+	Latency time.Duration
+}
+
+// Cluster is a set of mock clocks, one for each node of a simulated distributed
+// system, sharing a single virtual timeline.  Each node may be configured with
+// a skew, drift and latency, relative to that timeline, to deterministically
+// test code that must tolerate bounded clock skew between nodes (e.g. for
+// consensus or leases).
+//
+// The clocks of the nodes must not be advanced individually; they are
+// advanced together by advancing the cluster.
+type Cluster struct {
+	mu     sync.Mutex
+	start  time.Time
+	now    time.Time
+	nodes  []*mockClock
+	config []NodeConfig
+}
+
+// NewCluster returns a Cluster with a node for each of the given configurations,
+// the timeline of the cluster starting at a given time.  The clock of each
+// node is created with the given options, followed by an AtTime option setting
+// the initial time of the node.
+//
+// The function panics if the Drift of any node is not greater than -1.
+func NewCluster(start time.Time, nodes []NodeConfig, opts ...ClockOption) *Cluster {
+	c := &Cluster{
+		start:  start,
+		now:    start,
+		nodes:  make([]*mockClock, len(nodes)),
+		config: nodes,
+	}
+	for i, cfg := range nodes {
+		if cfg.Drift <= -1 {
+			panic(fmt.Errorf("%w: node %d: drift must be greater than -1", errInvalidDrift, i))
+		}
+		c.nodes[i] = NewMockClock(append(opts, AtTime(c.nodeTime(i, start)))...).(*mockClock)
+	}
+	return c
+}
+
+// Len returns the number of nodes in the cluster.
+func (c *Cluster) Len() int {
+	return len(c.nodes)
+}
+
+// Node returns the clock of the node with a given index.
+func (c *Cluster) Node(i int) MockClock {
+	return c.nodes[i]
+}
+
+// Now returns the current time of the cluster timeline.
+func (c *Cluster) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Deliver calls a function in its own goroutine after the latency of the node
+// with a given index has elapsed on the clock of that node, simulating the
+// delivery of a message to the node.
+func (c *Cluster) Deliver(to int, fn func()) *Timer {
+	return c.nodes[to].AfterFunc(c.config[to].Latency, fn)
+}
+
+// AdvanceBy advances the cluster timeline by a given duration.
+func (c *Cluster) AdvanceBy(d time.Duration) {
+	c.AdvanceTo(c.Now().Add(d))
+}
+
+// AdvanceTo advances the cluster timeline to a given time, advancing the
+// clocks of all nodes accordingly.
+//
+// The clocks are advanced together in steps, to the time of each timer or
+// ticker of any node in turn, so that timers and tickers fire across all nodes
+// in the order of the cluster timeline.
+//
+// Calling AdvanceTo with a time earlier than the current time of the cluster
+// will result in a panic.
+func (c *Cluster) AdvanceTo(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.now.After(t) {
+		panic(ErrNotADelorean)
+	}
+
+	for {
+		next, owner := c.nextDeadline()
+		if owner < 0 || next.After(t) {
+			break
+		}
+		c.advanceNodes(next, owner)
+	}
+	c.advanceNodes(t, -1)
+}
+
+// nextDeadline returns the time on the cluster timeline of the earliest next
+// timer or ticker of any node, and the index of that node; if there are no
+// active timers or tickers the index is -1.
+func (c *Cluster) nextDeadline() (time.Time, int) {
+	next, owner := time.Time{}, -1
+	for i, node := range c.nodes {
+		at, ok := node.nextDeadline()
+		if !ok {
+			continue
+		}
+		if ct := c.clusterTime(i, at); owner < 0 || ct.Before(next) {
+			next, owner = ct, i
+		}
+	}
+	return next, owner
+}
+
+// advanceNodes advances the clocks of all nodes to a given time on the cluster
+// timeline.  The node with a given index (if any) is advanced to at least its
+// next deadline, ensuring progress where the conversion between the times of
+// the cluster and the node is inexact.
+func (c *Cluster) advanceNodes(t time.Time, owner int) {
+	c.now = MaxTime(c.now, t)
+	for i, node := range c.nodes {
+		target := MaxTime(c.nodeTime(i, c.now), node.Now())
+		if i == owner {
+			if at, ok := node.nextDeadline(); ok {
+				target = MaxTime(target, at)
+			}
+		}
+		node.AdvanceTo(target)
+	}
+}
+
+// nodeTime returns the time of the node with a given index corresponding to
+// a given time on the cluster timeline.
+func (c *Cluster) nodeTime(i int, t time.Time) time.Time {
+	cfg := c.config[i]
+	elapsed := float64(t.Sub(c.start)) * (1 + cfg.Drift)
+	return c.start.Add(cfg.Skew + time.Duration(elapsed))
+}
+
+// clusterTime returns the time on the cluster timeline corresponding to a
+// given time of the node with a given index, rounded up to the nanosecond.
+func (c *Cluster) clusterTime(i int, t time.Time) time.Time {
+	cfg := c.config[i]
+	elapsed := float64(t.Sub(c.start)-cfg.Skew) / (1 + cfg.Drift)
+	return c.start.Add(time.Duration(math.Ceil(elapsed)))
+}
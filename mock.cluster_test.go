@@ -0,0 +1,134 @@
+package time
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestNewCluster(t *testing.T) {
+	// arrange
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// act
+	sut := NewCluster(start, []NodeConfig{{}, {Skew: time.Second}, {Skew: -time.Second}})
+
+	// assert
+	test.That(t, sut.Len()).Equals(3)
+	test.That(t, sut.Now()).Equals(start)
+	test.That(t, sut.Node(0).Now()).Equals(start)
+	test.That(t, sut.Node(1).Now()).Equals(start.Add(time.Second))
+	test.That(t, sut.Node(2).Now()).Equals(start.Add(-time.Second))
+}
+
+func TestNewCluster_InvalidDrift(t *testing.T) {
+	defer test.ExpectPanic(errInvalidDrift).Assert(t)
+
+	_ = NewCluster(time.Unix(0, 0), []NodeConfig{{Drift: -1}})
+}
+
+func TestCluster_AdvanceBy(t *testing.T) {
+	// arrange
+	start := time.Unix(0, 0).UTC()
+	sut := NewCluster(start, []NodeConfig{{}, {Skew: time.Second, Drift: 0.01}, {Drift: -0.5}})
+
+	// act
+	sut.AdvanceBy(100 * time.Second)
+
+	// assert
+	test.That(t, sut.Now()).Equals(start.Add(100 * time.Second))
+	test.That(t, sut.Node(0).Now()).Equals(start.Add(100 * time.Second))
+	test.That(t, sut.Node(1).Now()).Equals(start.Add(102 * time.Second))
+	test.That(t, sut.Node(2).Now()).Equals(start.Add(50 * time.Second))
+}
+
+// Tests that timers fire across nodes in the order of the cluster timeline.
+func TestCluster_AdvanceTo_TimerOrder(t *testing.T) {
+	// arrange: node 0 is 5s ahead, node 1 runs at half speed
+	start := time.Unix(0, 0).UTC()
+	sut := NewCluster(start, []NodeConfig{{Skew: 5 * time.Second}, {Drift: -0.5}, {}})
+
+	var (
+		mu    sync.Mutex
+		fired []int
+	)
+	record := func(i int) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			fired = append(fired, i)
+		}
+	}
+
+	// node 0 fires at cluster +10s, node 1 at cluster +6s, node 2 at cluster +8s
+	_ = sut.Node(0).AfterFunc(10*time.Second, record(0))
+	_ = sut.Node(1).AfterFunc(3*time.Second, record(1))
+	_ = sut.Node(2).AfterFunc(8*time.Second, record(2))
+
+	// act
+	sut.AdvanceTo(start.Add(time.Minute))
+
+	// assert
+	mu.Lock()
+	defer mu.Unlock()
+	test.Slice(t, fired).Equals([]int{1, 2, 0})
+}
+
+// Tests that tickers tick on each node while the cluster is advanced.
+func TestCluster_AdvanceBy_Tickers(t *testing.T) {
+	// arrange
+	sut := NewCluster(time.Unix(0, 0), []NodeConfig{{}, {Drift: 1}})
+	ticker := sut.Node(1).NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var ticks int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 10 {
+			<-ticker.C
+			ticks++
+		}
+	}()
+
+	// act: 5s of cluster time is 10s on node 1
+	sut.AdvanceBy(5 * time.Second)
+	<-done
+
+	// assert
+	test.That(t, ticks).Equals(10)
+}
+
+func TestCluster_AdvanceTo_Backwards(t *testing.T) {
+	// arrange
+	sut := NewCluster(time.Unix(10, 0), []NodeConfig{{}})
+	defer test.ExpectPanic(ErrNotADelorean).Assert(t)
+
+	// act
+	sut.AdvanceTo(time.Unix(5, 0))
+}
+
+func TestCluster_Deliver(t *testing.T) {
+	// arrange
+	sut := NewCluster(time.Unix(0, 0), []NodeConfig{{}, {Latency: 50 * time.Millisecond}})
+	delivered := make(chan time.Time, 1)
+
+	// act
+	_ = sut.Deliver(1, func() { delivered <- sut.Node(1).Now() })
+	sut.AdvanceBy(40 * time.Millisecond)
+
+	// assert: not delivered before the latency has elapsed
+	select {
+	case <-delivered:
+		t.Fatal("delivered early")
+	default:
+	}
+
+	// act
+	sut.AdvanceBy(10 * time.Millisecond)
+
+	// assert
+	test.That(t, <-delivered).Equals(time.Unix(0, 0).Add(50 * time.Millisecond).UTC())
+}
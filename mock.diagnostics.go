@@ -0,0 +1,259 @@
+package time
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TimerInfo describes the state of a timer or ticker on a mock clock.
+//
+// It is provided for diagnostic purposes, to identify timers and tickers
+// that are active or which have fired without the fired time being received
+// (or, for an AfterFunc, without the function returning).
+type TimerInfo struct {
+	// ID is the id of the timer or ticker; ids are assigned in order of
+	// creation and are unique to the clock.
+	ID int `json:"id"`
+
+	// Name is the name given to the timer or ticker when it was created,
+	// if any (see NewTimerNamed and NewTickerNamed).
+	Name string `json:"name,omitempty"`
+
+	// Kind identifies the type of timer: "Timer", "AfterFunc" or "Ticker".
+	Kind string `json:"kind"`
+
+	// State is the current state of the timer or ticker: "active",
+	// "expired" or "stopped".
+	State string `json:"state"`
+
+	// Next is the time at which the timer or ticker is next due to fire.
+	// For an expired or stopped timer, this is the time at which the timer
+	// was last due to fire.
+	Next time.Time `json:"next"`
+
+	// Interval is the duration between ticks of a ticker (zero for a timer).
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// Pending is the number of times that the timer or ticker has fired
+	// without the time being received from its channel or, for an AfterFunc,
+	// without the function having returned.
+	Pending int `json:"pending,omitempty"`
+
+	// Stack is the call stack at which the timer or ticker was created, with
+	// the innermost call first.  The stack is recorded only if the clock was
+	// created with the WithCallerTracking option; otherwise it is empty.
+	Stack string `json:"stack,omitempty"`
+}
+
+// String returns a single line description of the timer or ticker.
+func (info TimerInfo) String() string {
+	s := info.describe() + ": " + info.State + ", next: " + info.Next.Format(time.RFC3339Nano)
+	if info.Interval > 0 {
+		s += ", interval: " + info.Interval.String()
+	}
+	if info.Pending > 0 {
+		s += fmt.Sprintf(", pending: %d", info.Pending)
+	}
+	return s
+}
+
+// describe identifies the timer or ticker by kind, name (if any) and id.
+func (info TimerInfo) describe() string {
+	if info.Name != "" {
+		return fmt.Sprintf("%s %q (#%d)", info.Kind, info.Name, info.ID)
+	}
+	return fmt.Sprintf("%s #%d", info.Kind, info.ID)
+}
+
+// Timers returns information about all timers and tickers created by the
+// clock (including any that have expired or been stopped) in order of
+// creation.
+//
+// A timer or ticker that has expired or been stopped is omitted once the
+// Timer or Ticker is no longer reachable and has been garbage collected.
+func (m *mockClock) Timers() []TimerInfo {
+	return eval(m, m.timers)
+}
+
+// timers returns information about all timers and tickers created by the
+// clock in order of creation.
+//
+// This method is not thread-safe and should only be called while the clock
+// is locked.
+func (m *mockClock) timers() []TimerInfo {
+	inactive := m.tickers.inactive.all()
+	result := make([]TimerInfo, 0, len(m.tickers.active)+len(inactive))
+	for _, t := range m.tickers.active {
+		result = append(result, t.info())
+	}
+	for _, t := range inactive {
+		result = append(result, t.info())
+	}
+	slices.SortFunc(result, func(a, b TimerInfo) int { return a.ID - b.ID })
+	return result
+}
+
+// callerStack returns the call stack of the code creating a timer or ticker,
+// if the clock is tracking callers, otherwise an empty string.
+//
+// Frames in this package (other than in tests) at the top of the stack are
+// omitted, so that the stack identifies the code that called the clock
+// (directly or through a context function such as ContextWithTimeout).
+func (m *mockClock) callerStack() string {
+	if !m.tracksCallers {
+		return ""
+	}
+
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+
+	sb := &strings.Builder{}
+	internal := true
+	for {
+		frame, more := frames.Next()
+		if internal && !isInternalFrame(frame) {
+			internal = false
+		}
+		if !internal {
+			fmt.Fprintf(sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// isInternalFrame returns true if a given frame is in this package, excluding
+// any frames in test files.
+func isInternalFrame(frame runtime.Frame) bool {
+	return strings.HasPrefix(frame.Function, "github.com/blugnu/time.") &&
+		!strings.HasSuffix(frame.File, "_test.go")
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// StallReport describes an advance of a mock clock that has not completed
+// within the threshold configured using the WithStallDetection option.
+type StallReport struct {
+	// Target is the time to which the clock is being advanced.
+	Target time.Time
+
+	// Elapsed is the real time that has elapsed since the advance started.
+	Elapsed time.Duration
+
+	// Locked is true if the clock was locked when the report was made, in
+	// which case Now and Timers are not available.
+	Locked bool
+
+	// Now is the time of the clock when the report was made.
+	Now time.Time
+
+	// Timers identifies the timers and tickers that are active or which have
+	// fired with a pending delivery when the report was made.
+	Timers []TimerInfo
+}
+
+// String returns a multi-line description of the stalled advance.
+func (r StallReport) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "mock clock: advance to %s stalled for %s", r.Target.Format(time.RFC3339Nano), r.Elapsed)
+	if r.Locked {
+		sb.WriteString("\n  clock is locked: timers are not available")
+		return sb.String()
+	}
+	fmt.Fprintf(sb, "\n  now: %s", r.Now.Format(time.RFC3339Nano))
+	if len(r.Timers) == 0 {
+		sb.WriteString("\n  no active or pending timers")
+	}
+	for _, t := range r.Timers {
+		sb.WriteString("\n  " + t.String())
+		if t.Stack != "" {
+			sb.WriteString("\n    created by:")
+			for _, line := range strings.Split(strings.TrimSuffix(t.Stack, "\n"), "\n") {
+				sb.WriteString("\n      " + line)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// StallHandler is a function that is called with a StallReport when an advance
+// of a mock clock stalls.
+//
+// The handler is called in its own goroutine while the advance remains
+// stalled; it is not possible to recover from a panic in a StallHandler.
+type StallHandler func(StallReport)
+
+// PanicOnStall is a StallHandler that panics with ErrAdvanceStalled, wrapped with
+// the details of the stalled advance.
+//
+// Since a StallHandler is called on its own goroutine this will terminate the
+// process, ending a test that would otherwise hang until it times out.
+func PanicOnStall(r StallReport) {
+	panic(fmt.Errorf("%w: %s", ErrAdvanceStalled, r))
+}
+
+// stallDetection holds the configuration of stall detection on a mock clock.
+type stallDetection struct {
+	threshold time.Duration
+	handlers  []StallHandler
+}
+
+// writeStallReport returns a StallHandler that writes a StallReport to a given writer.
+func writeStallReport(w io.Writer) StallHandler {
+	return func(r StallReport) {
+		_, _ = fmt.Fprintln(w, r)
+	}
+}
+
+// startStallDetection starts a watchdog for an advance of the clock to a given
+// time, returning a function that must be called when the advance completes.
+//
+// If stall detection is not enabled the returned function is a no-op.
+func (m *mockClock) startStallDetection(target time.Time) (stop func()) {
+	if m.stalls.threshold <= 0 {
+		return func() { /* NO-OP */ }
+	}
+
+	started := time.Now()
+	watchdog := time.AfterFunc(m.stalls.threshold, func() {
+		report := m.stallReport(target, time.Since(started))
+
+		handlers := m.stalls.handlers
+		if len(handlers) == 0 {
+			handlers = []StallHandler{writeStallReport(os.Stderr)}
+		}
+		for _, fn := range handlers {
+			fn(report)
+		}
+	})
+
+	return func() { watchdog.Stop() }
+}
+
+// stallReport returns a StallReport for a stalled advance.  The clock may be
+// locked by the stalled goroutine so the lock is not waited for; if the lock
+// cannot be obtained the report indicates that the clock is locked.
+func (m *mockClock) stallReport(target time.Time, elapsed time.Duration) StallReport {
+	report := StallReport{Target: target, Elapsed: elapsed}
+	if !m.TryRLock() {
+		report.Locked = true
+		return report
+	}
+	defer m.RUnlock()
+
+	report.Now = m.now
+	for _, t := range m.timers() {
+		if t.State == tsActive.String() || t.Pending > 0 {
+			report.Timers = append(report.Timers, t)
+		}
+	}
+	return report
+}
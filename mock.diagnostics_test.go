@@ -0,0 +1,275 @@
+package time
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that Timers returns information about all timers and tickers in
+// order of creation.
+func TestMock_Timers(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	_ = clock.NewTimerNamed(10*time.Second, "rebalance")
+	ticker := clock.NewTicker(time.Second)
+	_ = clock.AfterFunc(5*time.Second, func() {})
+	ticker.Stop()
+
+	// act
+	result := clock.Timers()
+
+	// assert
+	test.That(t, result).Equals([]TimerInfo{
+		{ID: 0, Name: "rebalance", Kind: "Timer", State: "active", Next: time.Unix(10, 0).UTC()},
+		{ID: 1, Kind: "Ticker", State: "stopped", Next: time.Unix(1, 0).UTC(), Interval: time.Second},
+		{ID: 2, Kind: "AfterFunc", State: "active", Next: time.Unix(5, 0).UTC()},
+	})
+}
+
+// Tests that timers and tickers that are no longer reachable are not retained
+// by the clock once expired or stopped, and that active timers are retained.
+func TestMock_Timers_Abandoned(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	retained := clock.NewTimer(time.Second)
+	retained.Stop()
+	after := clock.After(time.Minute)
+	for range 10 {
+		clock.NewTimer(time.Second).Stop()
+		clock.NewTicker(time.Second).Stop()
+		_ = clock.AfterFunc(time.Second, func() {})
+	}
+	clock.AdvanceBy(time.Second)
+
+	// act
+	for range 100 {
+		if len(clock.Timers()) == 2 {
+			break
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+
+	// assert
+	result := clock.Timers()
+	test.That(t, len(result)).Equals(2)
+	test.That(t, result[0].ID).Equals(0)
+	test.That(t, result[1].State).Equals("active")
+
+	clock.AdvanceBy(time.Minute)
+	test.That(t, <-after).Equals(time.Unix(60, 0).UTC())
+	runtime.KeepAlive(retained)
+}
+
+// Tests that an unread timer channel is reported as pending.
+func TestMock_Timers_Pending(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	timer := clock.NewTimer(time.Second)
+
+	// act
+	clock.AdvanceBy(time.Second)
+
+	// assert
+	test.That(t, clock.Timers()[0].Pending).Equals(1)
+	<-timer.C
+	time.Sleep(time.Millisecond)
+	test.That(t, clock.Timers()[0].Pending).Equals(0)
+}
+
+func TestTimerInfo_String(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      TimerInfo
+		want     string
+	}{
+		{scenario: "anonymous timer",
+			sut:  TimerInfo{ID: 1, Kind: "Timer", State: "active", Next: time.Unix(10, 0).UTC()},
+			want: "Timer #1: active, next: 1970-01-01T00:00:10Z",
+		},
+		{scenario: "named ticker",
+			sut:  TimerInfo{ID: 2, Name: "poll", Kind: "Ticker", State: "active", Next: time.Unix(10, 0).UTC(), Interval: time.Second},
+			want: `Ticker "poll" (#2): active, next: 1970-01-01T00:00:10Z, interval: 1s`,
+		},
+		{scenario: "pending",
+			sut:  TimerInfo{ID: 3, Kind: "Timer", State: "expired", Next: time.Unix(10, 0).UTC(), Pending: 1},
+			want: "Timer #3: expired, next: 1970-01-01T00:00:10Z, pending: 1",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.That(t, tc.sut.String()).Equals(tc.want)
+		})
+	}
+}
+
+func TestStallReport_String(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      StallReport
+		want     string
+	}{
+		{scenario: "locked",
+			sut: StallReport{Target: time.Unix(10, 0).UTC(), Elapsed: time.Second, Locked: true},
+			want: "mock clock: advance to 1970-01-01T00:00:10Z stalled for 1s\n" +
+				"  clock is locked: timers are not available",
+		},
+		{scenario: "no timers",
+			sut: StallReport{Target: time.Unix(10, 0).UTC(), Elapsed: time.Second, Now: time.Unix(5, 0).UTC()},
+			want: "mock clock: advance to 1970-01-01T00:00:10Z stalled for 1s\n" +
+				"  now: 1970-01-01T00:00:05Z\n" +
+				"  no active or pending timers",
+		},
+		{scenario: "with timers",
+			sut: StallReport{Target: time.Unix(10, 0).UTC(), Elapsed: time.Second, Now: time.Unix(5, 0).UTC(),
+				Timers: []TimerInfo{{ID: 1, Name: "rebalance", Kind: "Timer", State: "expired", Next: time.Unix(5, 0).UTC(), Pending: 1}},
+			},
+			want: "mock clock: advance to 1970-01-01T00:00:10Z stalled for 1s\n" +
+				"  now: 1970-01-01T00:00:05Z\n" +
+				`  Timer "rebalance" (#1): expired, next: 1970-01-01T00:00:05Z, pending: 1`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.That(t, tc.sut.String()).Equals(tc.want)
+		})
+	}
+}
+
+// Tests that a stalled advance is reported while the clock is locked.
+func TestMock_StallDetection_Locked(t *testing.T) {
+	// arrange
+	var (
+		reports = make(chan StallReport, 1)
+		clock   = NewMockClock(WithStallDetection(10*time.Millisecond, func(r StallReport) { reports <- r })).(*mockClock)
+		advance WaitFuncs
+	)
+	clock.Lock()
+
+	// act
+	advance.Go(func() { clock.AdvanceTo(time.Unix(10, 0)) })
+	report := <-reports
+	clock.Unlock()
+	advance.Wait()
+
+	// assert
+	test.IsTrue(t, report.Locked)
+	test.IsTrue(t, report.Target.Equal(time.Unix(10, 0)))
+	test.IsTrue(t, report.Elapsed >= 10*time.Millisecond)
+}
+
+// Tests that a stalled advance reports active and pending timers.
+func TestMock_StallDetection_ReportsTimers(t *testing.T) {
+	// arrange: a yield longer than the threshold guarantees a "stall"
+	var (
+		reports = make(chan StallReport, 1)
+		clock   = NewMockClock(
+			Yielding(50*time.Millisecond),
+			WithStallDetection(10*time.Millisecond, func(r StallReport) { reports <- r }),
+		)
+	)
+	_ = clock.NewTimerNamed(time.Second, "unread")
+	_ = clock.NewTimerNamed(time.Hour, "later")
+
+	// act
+	clock.AdvanceBy(time.Second)
+	report := <-reports
+
+	// assert
+	test.IsFalse(t, report.Locked)
+	test.That(t, len(report.Timers)).Equals(2)
+	test.That(t, report.Timers[0].Name).Equals("unread")
+	test.That(t, report.Timers[1].Name).Equals("later")
+}
+
+// Tests that no report is made for an advance which does not stall.
+func TestMock_StallDetection_NoStall(t *testing.T) {
+	// arrange
+	var (
+		reports = make(chan StallReport, 1)
+		clock   = NewMockClock(WithStallDetection(time.Second, func(r StallReport) { reports <- r }))
+	)
+
+	// act
+	clock.AdvanceBy(time.Second)
+
+	// assert
+	select {
+	case <-reports:
+		t.Error("unexpected stall report")
+	default:
+	}
+}
+
+func TestWriteStallReport(t *testing.T) {
+	// arrange
+	buf := &bytes.Buffer{}
+	report := StallReport{Target: time.Unix(10, 0).UTC(), Elapsed: time.Second, Locked: true}
+
+	// act
+	writeStallReport(buf)(report)
+
+	// assert
+	test.That(t, buf.String()).Equals(report.String() + "\n")
+}
+
+func TestPanicOnStall(t *testing.T) {
+	defer test.ExpectPanic(ErrAdvanceStalled).Assert(t)
+
+	PanicOnStall(StallReport{})
+}
+
+// Tests that the creation stack of a timer is recorded when caller tracking
+// is enabled, omitting frames internal to the package.
+func TestMock_CallerStack(t *testing.T) {
+	// arrange
+	clock := NewMockClock(WithCallerTracking())
+
+	// act
+	_ = clock.NewTimer(time.Second)
+	_, _ = clock.ContextWithTimeout(context.Background(), time.Second)
+
+	// assert
+	timers := clock.Timers()
+	for _, info := range timers {
+		test.String(t, info.Stack).Contains("time.TestMock_CallerStack")
+		test.String(t, info.Stack).DoesNotContain("mockClock")
+	}
+}
+
+// Tests that the creation stack is not recorded by default.
+func TestMock_CallerStack_NotTracking(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	// act
+	_ = clock.NewTicker(time.Second)
+
+	// assert
+	test.That(t, clock.Timers()[0].Stack).Equals("")
+}
+
+func TestStallReport_String_WithStack(t *testing.T) {
+	// arrange
+	sut := StallReport{Target: time.Unix(10, 0).UTC(), Elapsed: time.Second, Now: time.Unix(5, 0).UTC(),
+		Timers: []TimerInfo{{ID: 1, Kind: "Timer", State: "active", Next: time.Unix(15, 0).UTC(),
+			Stack: "main.run\n\t/src/main.go:10\n",
+		}},
+	}
+
+	// act
+	result := sut.String()
+
+	// assert
+	test.That(t, result).Equals("mock clock: advance to 1970-01-01T00:00:10Z stalled for 1s\n" +
+		"  now: 1970-01-01T00:00:05Z\n" +
+		"  Timer #1: active, next: 1970-01-01T00:00:15Z\n" +
+		"    created by:\n" +
+		"      main.run\n" +
+		"      \t/src/main.go:10")
+}
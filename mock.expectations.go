@@ -0,0 +1,133 @@
+package time
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TimerExpectation is an expectation that a timer or ticker with a given
+// duration will be scheduled using a mock clock, established using the
+// ExpectTimer or ExpectTicker methods of the clock.
+//
+// By default a timer or ticker is expected to be scheduled at least once;
+// the expected number of times may be specified using Times, Once or Never.
+type TimerExpectation struct {
+	ticker bool
+	d      time.Duration
+	times  int // -1 for at least once
+}
+
+// Times sets the number of times that the timer or ticker is expected to be
+// scheduled.
+func (e *TimerExpectation) Times(n int) *TimerExpectation {
+	e.times = n
+	return e
+}
+
+// Once sets the timer or ticker to be expected to be scheduled exactly once.
+func (e *TimerExpectation) Once() *TimerExpectation {
+	return e.Times(1)
+}
+
+// Never sets the timer or ticker to be expected to never be scheduled.
+func (e *TimerExpectation) Never() *TimerExpectation {
+	return e.Times(0)
+}
+
+// String returns a description of the expectation.
+func (e *TimerExpectation) String() string {
+	kind := "Timer"
+	if e.ticker {
+		kind = "Ticker"
+	}
+	switch e.times {
+	case -1:
+		return fmt.Sprintf("%s(%s) scheduled at least once", kind, e.d)
+	case 0:
+		return fmt.Sprintf("%s(%s) never scheduled", kind, e.d)
+	case 1:
+		return fmt.Sprintf("%s(%s) scheduled once", kind, e.d)
+	default:
+		return fmt.Sprintf("%s(%s) scheduled %d times", kind, e.d, e.times)
+	}
+}
+
+// satisfiedBy returns true if the expectation is satisfied by a given count.
+func (e *TimerExpectation) satisfiedBy(n int) bool {
+	if e.times < 0 {
+		return n > 0
+	}
+	return n == e.times
+}
+
+// expectationKey identifies the timers or tickers with a given duration.
+type expectationKey struct {
+	ticker bool
+	d      time.Duration
+}
+
+// timerExpectations holds the expectations established on a mock clock and
+// the number of timers and tickers of each duration scheduled by the clock.
+type timerExpectations struct {
+	sync.Mutex
+	expected  []*TimerExpectation
+	scheduled map[expectationKey]int
+}
+
+// ExpectTicker establishes an expectation that a ticker with a given interval
+// will be created (or reset) using the clock.
+func (m *mockClock) ExpectTicker(d time.Duration) *TimerExpectation {
+	return m.expect(true, d)
+}
+
+// ExpectTimer establishes an expectation that a timer with a given duration
+// will be created (or reset) using the clock.
+func (m *mockClock) ExpectTimer(d time.Duration) *TimerExpectation {
+	return m.expect(false, d)
+}
+
+// expect adds an expectation to the clock.
+func (m *mockClock) expect(ticker bool, d time.Duration) *TimerExpectation {
+	m.expectations.Lock()
+	defer m.expectations.Unlock()
+
+	e := &TimerExpectation{ticker: ticker, d: d, times: -1}
+	m.expectations.expected = append(m.expectations.expected, e)
+	return e
+}
+
+// scheduled records that a timer or ticker has been scheduled with a given
+// duration.
+func (m *mockClock) scheduled(t tickable, d time.Duration) {
+	_, isTicker := t.(*ticker)
+
+	m.expectations.Lock()
+	defer m.expectations.Unlock()
+
+	if m.expectations.scheduled == nil {
+		m.expectations.scheduled = map[expectationKey]int{}
+	}
+	m.expectations.scheduled[expectationKey{ticker: isTicker, d: d}]++
+}
+
+// AssertExpectations verifies that the timers and tickers scheduled by the
+// clock satisfy the expectations established on the clock, failing the test
+// with a description of each expectation that is not satisfied.
+func (m *mockClock) AssertExpectations(t testing.TB) bool {
+	t.Helper()
+
+	m.expectations.Lock()
+	defer m.expectations.Unlock()
+
+	ok := true
+	for _, e := range m.expectations.expected {
+		n := m.expectations.scheduled[expectationKey{ticker: e.ticker, d: e.d}]
+		if !e.satisfiedBy(n) {
+			t.Errorf("expected %s: scheduled %d times", e, n)
+			ok = false
+		}
+	}
+	return ok
+}
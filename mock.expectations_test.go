@@ -0,0 +1,61 @@
+package time
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that expectations satisfied by the timers and tickers scheduled by
+// a clock do not fail the test.
+func TestMock_AssertExpectations_Satisfied(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	tb := &fakeTB{}
+	clock.ExpectTimer(time.Second).Times(2)
+	clock.ExpectTimer(5 * time.Second)
+	clock.ExpectTicker(time.Minute).Once()
+	clock.ExpectTicker(time.Second).Never()
+
+	timer := clock.NewTimer(time.Second)
+	timer.Reset(time.Second)
+	_, cancel := clock.ContextWithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ticker := clock.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	// act
+	result := clock.AssertExpectations(tb)
+
+	// assert
+	test.IsTrue(t, result)
+	test.That(t, len(tb.errors)).Equals(0)
+}
+
+// Tests that unsatisfied expectations fail the test.
+func TestMock_AssertExpectations_NotSatisfied(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	tb := &fakeTB{}
+	clock.ExpectTimer(time.Second).Once()
+	clock.ExpectTimer(time.Hour)
+	clock.ExpectTicker(time.Second).Never()
+
+	_ = clock.AfterFunc(time.Second, func() {})
+	_ = clock.AfterFunc(time.Second, func() {})
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	// act
+	result := clock.AssertExpectations(tb)
+
+	// assert
+	test.IsFalse(t, result)
+	test.That(t, tb.errors).Equals([]string{
+		"expected Timer(1s) scheduled once: scheduled 2 times",
+		"expected Timer(1h0m0s) scheduled at least once: scheduled 0 times",
+		"expected Ticker(1s) never scheduled: scheduled 1 times",
+	})
+}
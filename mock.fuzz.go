@@ -0,0 +1,168 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FuzzOp identifies the operation of a step of a schedule decoded from fuzz
+// data (see: DecodeFuzzSchedule).
+type FuzzOp int
+
+const (
+	// FuzzAdvance advances the clock by the duration of the step.
+	FuzzAdvance FuzzOp = iota
+
+	// FuzzStart is provided to the system under test, e.g. to start a
+	// component; it is not applied to the clock.
+	FuzzStart
+
+	// FuzzStop is provided to the system under test, e.g. to stop a
+	// component; it is not applied to the clock.
+	FuzzStop
+
+	// FuzzNewTimer creates a timer (using AfterFunc) expiring after the
+	// duration of the step.
+	FuzzNewTimer
+
+	// FuzzStopTimer stops the timer identified by the step.
+	FuzzStopTimer
+
+	// FuzzResetTimer resets the timer identified by the step to expire after
+	// the duration of the step.
+	FuzzResetTimer
+
+	fuzzOps // the number of operations
+)
+
+// String returns the name of the operation.
+func (op FuzzOp) String() string {
+	switch op {
+	case FuzzAdvance:
+		return "Advance"
+	case FuzzStart:
+		return "Start"
+	case FuzzStop:
+		return "Stop"
+	case FuzzNewTimer:
+		return "NewTimer"
+	case FuzzStopTimer:
+		return "StopTimer"
+	case FuzzResetTimer:
+		return "ResetTimer"
+	}
+	return "<invalid FuzzOp(" + strconv.Itoa(int(op)) + ")>"
+}
+
+// FuzzStep is a step of a schedule decoded from fuzz data.
+type FuzzStep struct {
+	// Op is the operation of the step.
+	Op FuzzOp
+
+	// Duration is the duration by which the clock is advanced (FuzzAdvance)
+	// or after which a timer expires (FuzzNewTimer, FuzzResetTimer).
+	Duration time.Duration
+
+	// Timer identifies the timer that is stopped or reset (FuzzStopTimer,
+	// FuzzResetTimer) or created (FuzzNewTimer); timers are numbered from 0
+	// in the order in which they are created.
+	Timer int
+}
+
+// String returns a description of the step.
+func (s FuzzStep) String() string {
+	switch s.Op {
+	case FuzzAdvance:
+		return fmt.Sprintf("Advance(%s)", s.Duration)
+	case FuzzNewTimer, FuzzResetTimer:
+		return fmt.Sprintf("%s(#%d, %s)", s.Op, s.Timer, s.Duration)
+	case FuzzStopTimer:
+		return fmt.Sprintf("%s(#%d)", s.Op, s.Timer)
+	}
+	return s.Op.String()
+}
+
+// DecodeFuzzSchedule decodes fuzz data (e.g. the []byte argument of a fuzz
+// target of a testing.F) into a schedule of steps.  Each step is decoded from
+// two bytes: the first identifies the operation and the second its argument,
+// a multiple of a unit duration for a duration, or the index of a timer
+// (modulo the number of timers created by preceding steps).  A final odd byte
+// is ignored.
+//
+// The schedule is deterministic for given data, so that a failure found by the
+// fuzzer may be reproduced.  A step that would stop or reset a timer when no
+// timers have been created is decoded as a step advancing the clock.
+func DecodeFuzzSchedule(data []byte, unit time.Duration) []FuzzStep {
+	steps := make([]FuzzStep, 0, len(data)/2)
+	timers := 0
+	for i := 0; i+1 < len(data); i += 2 {
+		op, arg := FuzzOp(int(data[i])%int(fuzzOps)), int(data[i+1])
+
+		if (op == FuzzStopTimer || op == FuzzResetTimer) && timers == 0 {
+			op = FuzzAdvance
+		}
+
+		step := FuzzStep{Op: op}
+		switch op {
+		case FuzzAdvance:
+			step.Duration = time.Duration(arg) * unit
+		case FuzzNewTimer:
+			step.Duration, step.Timer = time.Duration(arg+1)*unit, timers
+			timers++
+		case FuzzStopTimer:
+			step.Timer = arg % timers
+		case FuzzResetTimer:
+			step.Duration, step.Timer = time.Duration(arg+1)*unit, arg%timers
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// ApplyFuzzSchedule decodes a schedule from fuzz data (see: DecodeFuzzSchedule)
+// and applies each step to a mock clock: advancing the clock, or creating,
+// stopping or resetting timers (created using AfterFunc with a function that
+// does nothing) which compete for the attention of the clock with the timers
+// of the system under test.  After each step is applied, the step is passed to
+// a function (if not nil), which may apply the step to the system under test
+// (e.g. for FuzzStart or FuzzStop) and verify its invariants.
+//
+// The steps applied are returned, so that they may be reported if a test
+// fails.
+//
+// ApplyFuzzSchedule allows fuzzing of the order in which time passes and timers
+// fire, to reveal ordering bugs in code using many timers:
+//
+//	func FuzzScheduler(f *testing.F) {
+//		f.Add([]byte{0, 10, 3, 5})
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			clock := time.NewMockClock(time.Yielding(0))
+//			sut := NewScheduler(clock)
+//			time.ApplyFuzzSchedule(clock, data, time.Millisecond, func(step time.FuzzStep) {
+//				// apply FuzzStart/FuzzStop to sut and verify its invariants
+//			})
+//		})
+//	}
+func ApplyFuzzSchedule(clock MockClock, data []byte, unit time.Duration, fn func(FuzzStep)) []FuzzStep {
+	steps := DecodeFuzzSchedule(data, unit)
+
+	var timers []*Timer
+	for _, step := range steps {
+		switch step.Op {
+		case FuzzAdvance:
+			clock.AdvanceBy(step.Duration)
+		case FuzzNewTimer:
+			timers = append(timers, clock.AfterFunc(step.Duration, func() {}))
+		case FuzzStopTimer:
+			_ = timers[step.Timer].Stop()
+		case FuzzResetTimer:
+			_ = timers[step.Timer].Reset(step.Duration)
+		}
+
+		if fn != nil {
+			fn(step)
+		}
+	}
+	return steps
+}
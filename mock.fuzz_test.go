@@ -0,0 +1,123 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestFuzzOp_String(t *testing.T) {
+	testcases := []struct {
+		op     FuzzOp
+		result string
+	}{
+		{op: FuzzAdvance, result: "Advance"},
+		{op: FuzzStart, result: "Start"},
+		{op: FuzzStop, result: "Stop"},
+		{op: FuzzNewTimer, result: "NewTimer"},
+		{op: FuzzStopTimer, result: "StopTimer"},
+		{op: FuzzResetTimer, result: "ResetTimer"},
+		{op: fuzzOps, result: "<invalid FuzzOp(6)>"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.result, func(t *testing.T) {
+			test.That(t, tc.op.String()).Equals(tc.result)
+		})
+	}
+}
+
+func TestDecodeFuzzSchedule(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		data     []byte
+		result   []FuzzStep
+	}{
+		{scenario: "empty", data: nil, result: []FuzzStep{}},
+		{scenario: "odd byte ignored", data: []byte{0, 2, 1}, result: []FuzzStep{
+			{Op: FuzzAdvance, Duration: 2 * time.Millisecond},
+		}},
+		{scenario: "timer operations", data: []byte{3, 0, 9, 4, 4, 7, 5, 9, 1, 0, 2, 0}, result: []FuzzStep{
+			{Op: FuzzNewTimer, Duration: time.Millisecond, Timer: 0},
+			{Op: FuzzNewTimer, Duration: 5 * time.Millisecond, Timer: 1},
+			{Op: FuzzStopTimer, Timer: 1},
+			{Op: FuzzResetTimer, Duration: 10 * time.Millisecond, Timer: 1},
+			{Op: FuzzStart},
+			{Op: FuzzStop},
+		}},
+		{scenario: "no timers to stop or reset", data: []byte{4, 3, 5, 1}, result: []FuzzStep{
+			{Op: FuzzAdvance, Duration: 3 * time.Millisecond},
+			{Op: FuzzAdvance, Duration: time.Millisecond},
+		}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := DecodeFuzzSchedule(tc.data, time.Millisecond)
+
+			// assert
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestFuzzStep_String(t *testing.T) {
+	testcases := []struct {
+		step   FuzzStep
+		result string
+	}{
+		{step: FuzzStep{Op: FuzzAdvance, Duration: time.Second}, result: "Advance(1s)"},
+		{step: FuzzStep{Op: FuzzNewTimer, Duration: time.Second, Timer: 2}, result: "NewTimer(#2, 1s)"},
+		{step: FuzzStep{Op: FuzzResetTimer, Duration: time.Second, Timer: 2}, result: "ResetTimer(#2, 1s)"},
+		{step: FuzzStep{Op: FuzzStopTimer, Timer: 2}, result: "StopTimer(#2)"},
+		{step: FuzzStep{Op: FuzzStart}, result: "Start"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.result, func(t *testing.T) {
+			test.That(t, tc.step.String()).Equals(tc.result)
+		})
+	}
+}
+
+func TestApplyFuzzSchedule(t *testing.T) {
+	// arrange
+	clock := NewMockClock(Yielding(0))
+	applied := []FuzzOp{}
+
+	// act
+	steps := ApplyFuzzSchedule(clock, []byte{3, 9, 1, 0, 0, 4, 4, 0, 0, 9}, time.Millisecond, func(step FuzzStep) {
+		applied = append(applied, step.Op)
+	})
+
+	// assert
+	test.That(t, len(steps)).Equals(5)
+	test.That(t, applied).Equals([]FuzzOp{FuzzNewTimer, FuzzStart, FuzzAdvance, FuzzStopTimer, FuzzAdvance})
+	test.That(t, clock.SinceCreated()).Equals(13 * time.Millisecond)
+	test.That(t, clock.Timers()[0].State).Equals("stopped")
+}
+
+// Fuzzes the scheduling of timers by a mock clock: after each step the clock
+// has been advanced by the total of the advances and no active timer is due.
+func FuzzMock_Schedule(f *testing.F) {
+	f.Add([]byte{3, 9, 0, 4, 5, 0, 3, 1, 0, 20})
+	f.Add([]byte{3, 1, 3, 1, 3, 1, 0, 1, 4, 1, 0, 10})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		clock := NewMockClock(Yielding(0))
+		elapsed := time.Duration(0)
+
+		ApplyFuzzSchedule(clock, data, time.Millisecond, func(step FuzzStep) {
+			if step.Op == FuzzAdvance {
+				elapsed += step.Duration
+			}
+			if clock.SinceCreated() != elapsed {
+				t.Fatalf("after %s: elapsed %s, expected %s", step, clock.SinceCreated(), elapsed)
+			}
+			for _, timer := range clock.Timers() {
+				if timer.State == "active" && !timer.Next.After(clock.Now()) {
+					t.Fatalf("after %s: %s is due but active", step, timer)
+				}
+			}
+		})
+	})
+}
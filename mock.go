@@ -2,10 +2,12 @@ package time
 
 import (
 	"context"
-	"fmt"
+	"runtime"
+	"slices"
 	"sort"
 	"sync"
 	"sync/atomic"
+	"testing"
 	"time"
 )
 
@@ -38,9 +40,61 @@ type MockClock interface {
 	// Calling this method while the clock is running will result in a panic.
 	AdvanceTo(t time.Time)
 
+	// AdvanceUntilQuiescent repeatedly advances the clock to the time of the
+	// next timer or ticker, waiting (in real-time) after each advance for the
+	// times or functions of any timers or tickers that have fired to be
+	// received or to return, until no timers or tickers remain active or the
+	// clock has been advanced by a maximum duration.
+	//
+	// It returns true if the clock became quiescent (no active timers or
+	// tickers remained) within the maximum duration; in that case the clock
+	// is left at the time of the last timer or ticker to fire.  Otherwise it
+	// returns false, with the clock advanced by the maximum duration.
+	//
+	// Calling this method while the clock is running will result in a panic.
+	AdvanceUntilQuiescent(maxVirtual time.Duration) bool
+
+	// AssertExpectations verifies that the timers and tickers scheduled by
+	// the clock satisfy the expectations established using ExpectTimer and
+	// ExpectTicker, failing the test if not.  It returns true if all
+	// expectations were satisfied.
+	AssertExpectations(t testing.TB) bool
+
+	// Close stops all timers and tickers of the clock and closes the clock;
+	// any further attempt to create a timer or ticker, or to advance the
+	// clock, will panic with ErrClockClosed.
+	Close() error
+
 	// CreatedAt returns the mocked time at which the clock was started when created.
 	CreatedAt() time.Time
 
+	// Err returns an error joining each CallbackPanic recovered from a
+	// function called by a timer of the clock, or nil if none have been
+	// recovered (see: WithPanicRecovery).
+	Err() error
+
+	// ExpectTicker establishes an expectation that a ticker with a given
+	// interval will be created (or reset) using the clock.  The expectation is
+	// verified by AssertExpectations.
+	ExpectTicker(d time.Duration) *TimerExpectation
+
+	// ExpectTimer establishes an expectation that a timer with a given
+	// duration will be created (or reset) using the clock, including timers
+	// created by AfterFunc and for context deadlines and timeouts.  The
+	// expectation is verified by AssertExpectations.
+	ExpectTimer(d time.Duration) *TimerExpectation
+
+	// Freeze stops the clock, pinning the time of the clock at the time at
+	// which it was frozen.  Every call to Freeze() must be matched with a call
+	// to Unfreeze().
+	Freeze()
+
+	// FreezeAt freezes the clock and advances it to a given time, triggering
+	// any timers or tickers that would have been triggered during that passage
+	// of time.  The time must not be earlier than the current time of the
+	// clock.
+	FreezeAt(t time.Time)
+
 	// IsRunning returns true if the clock is in a running state.
 	// In this state the clock is advanced by elapsed time whenever Now()
 	// is obtained from the clock or when Update() is explicitly called.
@@ -52,10 +106,28 @@ type MockClock interface {
 	// they might.
 	IsRunning() bool
 
+	// Reset stops all timers and tickers of the clock and discards them,
+	// restoring the clock to the configuration and state in which it was
+	// created and applying any additional options.
+	Reset(options ...ClockOption)
+
 	// SinceCreated returns the elapsed mock time since the clock was created.
 	// This is the same as calling clock.Since(clock.CreatedAt()).
 	SinceCreated() time.Duration
 
+	// Timers returns information about all timers and tickers created by the
+	// clock (including any that have expired or been stopped, unless no longer
+	// reachable) in order of creation.
+	Timers() []TimerInfo
+
+	// State returns the current state of the clock.  The state may be used to
+	// create a new clock in the same state using the FromState option.
+	State() MockClockState
+
+	// MarshalJSON marshals the current state of the clock to JSON.  The
+	// result may be unmarshalled into a MockClockState.
+	MarshalJSON() ([]byte, error)
+
 	// Stop stops the clock from advancing automatically.  Every call to
 	// Stop() must be matched with a call to Start() to resume automatic
 	// advancement.
@@ -74,6 +146,11 @@ type MockClock interface {
 	// start the clock.
 	Start()
 
+	// Unfreeze starts a clock that has been frozen.  A running clock
+	// continues to advance from the time at which it was frozen, discarding
+	// the real time elapsed while it was frozen.
+	Unfreeze()
+
 	// Update moves the current time of the mock clock forward by a duration
 	// corresponding to the passage of real-time since it was last updated,
 	// triggering any timers or tickers that would have been triggered during
@@ -81,6 +158,10 @@ type MockClock interface {
 	//
 	// Calling this method while the clock is stopped will result in a panic.
 	Update()
+
+	// WhileFrozen calls a function with the clock frozen, unfreezing the
+	// clock when the function returns (or panics).
+	WhileFrozen(fn func())
 }
 
 // mockClock represents a mock clock that moves forward from an established time and can
@@ -98,6 +179,19 @@ type mockClock struct {
 	// the current time, only the last tick will be triggered.
 	dropsTicks bool
 
+	// coalescesTicks is a flag that when set will cause the mock clock to drop
+	// ticks in the same way as dropsTicks, with the number of dropped ticks
+	// recorded by each ticker (see: CoalescesTicks)
+	coalescesTicks bool
+
+	// nowLatency is the duration consumed by each call to Now()
+	// (see: WithNowLatency)
+	nowLatency time.Duration
+
+	// speed is the rate at which a running clock advances, as a multiple of
+	// real time (see: WithSpeed)
+	speed float64
+
 	// yield is the duration for which the calling goroutine is to be suspended
 	// after each time the clock is moved.
 	yield time.Duration
@@ -110,30 +204,89 @@ type mockClock struct {
 	// This is the time that is returned by Now() as used by Since() and Until()
 	now time.Time
 
+	// current holds a copy of now, so that the current time of a stopped clock
+	// may be read without locking the clock (see: setNow)
+	current atomic.Pointer[time.Time]
+
 	// when > 0 the clock will not advance automatically.  Every call to Stop()
 	// the clock should be matched by a call to Start().
 	nStopped atomic.Int32
 
+	// closed is set when the clock has been closed (see: Close)
+	closed atomic.Bool
+
+	// deliveries counts the goroutines delivering the ticks of timers and
+	// tickers that have yet to advance the clock to the time of the tick, so
+	// that a reset clock is not advanced by a tick that occurred before the
+	// reset (see: Reset)
+	deliveries sync.WaitGroup
+
 	// updated is the last time the clock was queried for the current time
 	// (this is the actual, local time according to the system clock)
 	//
 	// This is used to track elapsed time when advancing the mock clock automatically.
 	updated time.Time
 
-	// tickers provides lists of active and inactive tickers.  An inactive ticker
+	// tickers provides the active and inactive tickers.  An inactive ticker
 	// is one that has been stopped or has expired (for timers).
 	//
 	// The active tickers are maintained in order of the next tick time.
 	//
 	// Maintaining inactive tickers separately allows for tickers to be restarted
-	// and for timers to be reset, by returning them to the active list.
+	// and for timers to be reset, by returning them to the active list.  The
+	// inactive tickers are sharded by id, each shard having its own lock.
+	//
+	// A ticker that can no longer be restarted or reset, because the Timer or
+	// Ticker wrapping it is no longer reachable, is not retained in the
+	// inactive tickers (see: abandon).
 	tickers struct {
 		active   tickables
-		inactive tickables
+		inactive tickerShards
 	}
 
 	// nextTickerId is the next id to assign to a ticker.
 	nextTickerId int
+
+	// stalls configures the detection of stalled advances of the clock
+	// (see: WithStallDetection)
+	stalls stallDetection
+
+	// tracksCallers is a flag that when set will cause the mock clock to record
+	// the call stack at which each timer and ticker is created
+	// (see: WithCallerTracking)
+	tracksCallers bool
+
+	// expectations records the timers and tickers scheduled by the clock, to be
+	// verified against any expectations (see: ExpectTimer, ExpectTicker)
+	expectations timerExpectations
+
+	// recorder records the operations on timers and tickers of the clock
+	// (see: WithRecorder); nil if recording is not enabled
+	recorder *ScheduleRecorder
+
+	// chaos disrupts the deliveries of timers and tickers (see: WithChaos);
+	// nil if not enabled
+	chaos *chaos
+
+	// panics recovers panics in the functions called by timers (see:
+	// WithPanicRecovery); nil if not enabled
+	panics *panicRecovery
+
+	// tracer is the logger to which a trace of clock operations is written
+	// (see: WithTrace); nil if tracing is not enabled
+	tracer TraceLogger
+
+	// progress configures the reporting of the progress of advances of the
+	// clock (see: WithProgress)
+	progress progressReporting
+
+	// contextFormat is the format of the string representation of the
+	// contexts with deadlines and timeouts of the clock (see: WithContextFormat)
+	contextFormat ContextFormat
+
+	// options are the options with which the clock was created, re-applied
+	// when the clock is reset (see: Reset)
+	options []ClockOption
 }
 
 // eval is a helper function that executes a supplied function to return a
@@ -148,9 +301,25 @@ func eval[T any](m *mockClock, fn func() T) T {
 	return fn()
 }
 
+// lockedTimeout is the duration of real time for which panicIfLocked waits
+// for the lock of a clock to be released before panicking.
+const lockedTimeout = 100 * time.Millisecond
+
+// panicIfLocked panics with errClockLocked if the clock remains locked for
+// longer than lockedTimeout, which would otherwise indicate a deadlock (e.g.
+// a timer or ticker created in a function called while the clock is locked).
+//
+// The lock of a clock is held only briefly by other goroutines (including
+// those delivering ticks to many tickers, or creating timers and tickers
+// concurrently), so the lock is tried repeatedly rather than only once; a
+// single attempt would panic on contention that is not a deadlock.
 func (m *mockClock) panicIfLocked() {
-	if !m.TryLock() {
-		panic(errClockLocked)
+	deadline := time.Now().Add(lockedTimeout)
+	for !m.TryLock() {
+		if time.Now().After(deadline) {
+			panic(errClockLocked)
+		}
+		runtime.Gosched()
 	}
 	m.Unlock()
 }
@@ -183,6 +352,10 @@ type ClockOption func(*mockClock)
 //
 //   - AtTime(t time.Time) sets the initial time of the mock clock;
 //
+//   - CoalescesTicks() sets the clock to fire tickers only once where multiple
+//     ticks would have been triggered by a single advance of the clock, counting
+//     the skipped intervals (see: Ticker.Skipped());
+//
 //   - DropsTicks() sets the clock to fire tickers only once where multiple
 //     ticks would have been triggered by a single advance of the clock
 //
@@ -193,21 +366,166 @@ type ClockOption func(*mockClock)
 //     state the clock is advanced by elapsed time whenever Now() is obtained from
 //     the clock or when Update() is explicitly called.  AdvanceBy() and AdvanceTo()
 //     are not supported in the running state and will panic.
+//
+//   - WithChaos(seed, cfg) sets the clock to randomly deliver timers late, coalesce
+//     ticks and reorder timers due at the same instant, using a seeded random
+//     number generator.
+//
+//   - WithNowLatency(d) sets the clock to consume a duration of time on each call
+//     to Now().
+//
+//   - WithPanicRecovery(handlers...) recovers panics in functions called by
+//     timers of the clock, reporting them to handlers and from Err().
+//
+//   - WithSpeed(factor) sets the rate at which a running clock advances, as a
+//     multiple of real time.
+//
+//   - WithRecorder(r) records the operations on timers and tickers of the clock,
+//     for comparison with a golden schedule (see: AssertGoldenSchedule).
+//
+//   - WithStallDetection(threshold, handlers...) reports on any advance of the clock
+//     that does not complete within a threshold of real-time.
+//
+//   - WithCallerTracking() records the call stack at which each timer and ticker
+//     is created.
+//
+//   - WithContextFormat(f) sets the format of the string representation of the
+//     contexts with deadlines and timeouts of the clock.
+//
+//   - WithProgress(n, r) reports the progress of each advance of the clock to r,
+//     after every n timer and ticker events fired by the advance.
+//
+//   - WithTrace(log) writes a trace of clock operations to a TraceLogger (such as
+//     a testing.T).
 func NewMockClock(options ...ClockOption) MockClock {
-	ret := &mockClock{
-		createdAt: time.Unix(0, 0),
-		loc:       time.UTC,
-		now:       time.Unix(0, 0).UTC(),
-		updated:   time.Now(),
-		yield:     1 * time.Millisecond,
+	ret := &mockClock{options: options}
+	ret.initialise()
+	ret.configure()
+
+	return ret
+}
+
+// initialise sets the configuration and state of the clock to the defaults
+// of a new clock, with no timers or tickers.
+//
+// This method is not thread-safe and should only be called while the clock is
+// locked (or before it is used).
+func (m *mockClock) initialise() {
+	m.createdAt = time.Unix(0, 0)
+	m.loc = time.UTC
+	m.setNow(time.Unix(0, 0).UTC())
+	m.updated = time.Now()
+	m.speed = 1
+	m.yield = 1 * time.Millisecond
+	m.nStopped.Store(1) // start in stopped mode
+	m.closed.Store(false)
+
+	m.dropsTicks, m.coalescesTicks, m.tracksCallers = false, false, false
+	m.nowLatency = 0
+	m.stalls = stallDetection{}
+	m.progress = progressReporting{}
+	m.recorder, m.chaos, m.panics, m.tracer = nil, nil, nil, nil
+	m.contextFormat = DefaultContextFormat
+	m.tickers.active = nil
+	m.tickers.inactive.clear()
+
+	m.expectations.Lock()
+	m.expectations.expected, m.expectations.scheduled = nil, nil
+	m.expectations.Unlock()
+}
+
+// configure applies the options of the clock, together with any additional
+// options.
+//
+// The clock must not be locked, since an option may lock the clock.
+func (m *mockClock) configure(options ...ClockOption) {
+	for _, opt := range slices.Concat(m.options, options) {
+		opt(m)
 	}
-	ret.nStopped.Store(1) // start in stopped mode
 
-	for _, opt := range options {
-		opt(ret)
+	// the times of recorded operations are relative to the initial time
+	if m.recorder != nil {
+		m.recorder.origin = m.now
 	}
+}
 
-	return ret
+// Reset stops all timers and tickers of the clock and discards them, restoring
+// the clock to the configuration and state in which it was created (with the
+// options specified to NewMockClock) and applying any additional options.
+//
+// This allows a single clock to be reused by (for example) the subtests of a
+// table-driven test, without timers or tickers from one subtest affecting the
+// next.  Timers and tickers created before the clock was reset do not fire
+// (even if reset) and are no longer reported by Timers(); the ids of timers
+// and tickers continue from those created before the clock was reset.  Any
+// pending deliveries of the times of timers and tickers that fired before the
+// reset do not affect the time of the reset clock.
+//
+// Reset must not be called concurrently with any other use of the clock.
+func (m *mockClock) Reset(options ...ClockOption) {
+	m.tracefNow("reset clock")
+	m.stopAll()
+
+	// goroutines delivering ticks that occurred before the reset must advance
+	// the clock before it is re-initialised
+	m.deliveries.Wait()
+
+	m.withLock(func(m *mockClock) { m.initialise() })
+	m.configure(options...)
+}
+
+// Close stops all timers and tickers of the clock and closes the clock; any
+// further attempt to create a timer or ticker, or to advance the clock, will
+// panic with ErrClockClosed.  Now continues to return the time of the clock.
+//
+// A closed clock may be re-opened by resetting it (see: Reset).  Calling Close
+// more than once has no effect.  The error is always nil.
+func (m *mockClock) Close() error {
+	if m.closed.Swap(true) {
+		return nil
+	}
+	m.tracefNow("close clock")
+	m.stopAll()
+	return nil
+}
+
+// stopAll stops all timers and tickers of the clock.
+func (m *mockClock) stopAll() {
+	for _, t := range eval(m, func() tickables {
+		return slices.Concat(m.tickers.active, m.tickers.inactive.all())
+	}) {
+		t.enterState(tsStopped)
+	}
+}
+
+// setNow sets the current time of the clock.
+//
+// This method is not thread-safe and should only be called while the clock
+// is locked.
+func (m *mockClock) setNow(t time.Time) {
+	m.now = t
+	m.current.Store(&t)
+}
+
+// advanceNowTo sets the current time of the clock to a given time if it is
+// later than the current time.  This is used by the goroutines delivering
+// the ticks of timers and tickers, which may run after the clock has been
+// advanced beyond the time of the tick, so that the time of the clock does
+// not go backwards.
+//
+// This method is not thread-safe and should only be called while the clock
+// is locked.
+func (m *mockClock) advanceNowTo(t time.Time) {
+	if t.After(m.now) {
+		m.setNow(t)
+	}
+}
+
+// panicIfClosed panics with ErrClockClosed if the clock has been closed.
+func (m *mockClock) panicIfClosed() {
+	if m.closed.Load() {
+		panic(ErrClockClosed)
+	}
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -223,7 +541,7 @@ func (m *mockClock) After(d time.Duration) <-chan time.Time {
 // AfterFunc waits for the duration to elapse and then executes a function in its own goroutine.
 // A Timer is returned that can be stopped.
 func (m *mockClock) AfterFunc(d time.Duration, f func()) *Timer {
-	return m.newTimer(d, f)
+	return m.newTimer(d, "", f)
 }
 
 // Now returns the current wall time according to the mock clock.
@@ -233,10 +551,62 @@ func (m *mockClock) AfterFunc(d time.Duration, f func()) *Timer {
 // If the clock is not frozen, the clock will first advance by the time elapsed since the
 // clock was last updated.
 func (m *mockClock) Now() time.Time {
+	m.consumeNowLatency()
+
+	// the time of a stopped clock changes only when the clock is moved so,
+	// unless Now is traced, the time is read without locking the clock
+	if !m.IsRunning() && m.tracer == nil {
+		return *m.current.Load()
+	}
+
 	m.Lock()
 	defer m.Unlock()
 
-	return m.advance()
+	now := m.advance()
+	m.tracef(now, "Now")
+
+	return now
+}
+
+// consumeNowLatency consumes the latency of a call to Now, if any (see:
+// WithNowLatency).  A stopped clock is advanced by the latency, triggering any
+// timers or tickers due in that time, without yielding; for a running clock
+// the calling goroutine is suspended for the latency.
+func (m *mockClock) consumeNowLatency() {
+	if m.nowLatency <= 0 {
+		return
+	}
+	if m.IsRunning() {
+		time.Sleep(m.nowLatency)
+		return
+	}
+
+	t := eval(m, func() time.Time { return m.now.Add(m.nowLatency) })
+	for m.tick(t) {
+	}
+	m.withLock(func(m *mockClock) {
+		if m.now.Before(t) {
+			m.setNow(t.In(m.loc))
+		}
+	})
+}
+
+// Date returns the time corresponding to the given date and time of day in
+// the location of the clock (see: InLocation).
+func (m *mockClock) Date(year int, month time.Month, day, hour, min, sec, nsec int) time.Time {
+	return time.Date(year, month, day, hour, min, sec, nsec, m.loc)
+}
+
+// ParseInLocation parses a formatted string, interpreting a time without time
+// zone information in the location of the clock (see: InLocation).
+func (m *mockClock) ParseInLocation(layout, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, m.loc)
+}
+
+// In returns a view of the clock in which Now returns the current time of the
+// clock in a given location.
+func (m *mockClock) In(loc *time.Location) Clock {
+	return inLocation(m, loc)
 }
 
 // Since returns time since `t` using the mock clock's wall time.
@@ -253,8 +623,9 @@ func (m *mockClock) Until(t time.Time) time.Duration {
 //
 // If the duration is zero or negative the function returns immediately.
 //
-// If the clock is running, the duration is passed to time.Sleep() to suspend
-// the calling goroutine for the given duration.
+// If the clock is running, the calling goroutine is suspended (using
+// time.Sleep()) for the real time corresponding to the duration, according to
+// the speed of the clock (see: WithSpeed).
 //
 // If the clock is stopped, the duration is passed to After() and the calling
 // goroutine will block until the clock is advanced by at least the specified
@@ -266,7 +637,7 @@ func (m *mockClock) Sleep(d time.Duration) {
 		return
 	}
 	if m.IsRunning() {
-		time.Sleep(d)
+		time.Sleep(m.realDuration(d))
 		return
 	}
 	<-m.After(max(d, 0))
@@ -284,13 +655,24 @@ func (m *mockClock) Tick(d time.Duration) <-chan time.Time {
 
 // Ticker creates a new instance of Ticker.
 func (m *mockClock) NewTicker(d time.Duration) *Ticker {
-	return m.newTicker(d)
+	return m.newTicker(d, "")
+}
+
+// NewTickerNamed creates a new instance of Ticker with a given name.
+func (m *mockClock) NewTickerNamed(d time.Duration, name string) *Ticker {
+	return m.newTicker(d, name)
 }
 
 // Timer creates a new Timer.  Since this is a mock implementation, the Timer
 // will not fire until the clock is advanced.
 func (m *mockClock) NewTimer(d time.Duration) *Timer {
-	return m.newTimer(d, nil)
+	return m.newTimer(d, "", nil)
+}
+
+// NewTimerNamed creates a new Timer with a given name.  Since this is a mock
+// implementation, the Timer will not fire until the clock is advanced.
+func (m *mockClock) NewTimerNamed(d time.Duration, name string) *Timer {
+	return m.newTimer(d, name, nil)
 }
 
 // ContextWithDeadline returns a new context with the given deadline.
@@ -324,7 +706,9 @@ func (m *mockClock) ContextWithTimeoutCause(ctx context.Context, d time.Duration
 		return context.WithCancel(ctx)
 	}
 
-	return newMockContext(ctx, m, deadline, cause)
+	mctx, cancel := newMockContext(ctx, m, deadline, cause)
+	mctx.format = eval(m, func() ContextFormat { return m.contextFormat })
+	return mctx, cancel
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -346,12 +730,30 @@ func (m *mockClock) advance() time.Time {
 	}
 
 	var elapsed = time.Since(m.updated)
-	m.now = m.now.Add(elapsed)
+	m.setNow(m.now.Add(m.mockDuration(elapsed)))
 	m.updated = m.updated.Add(elapsed)
 
 	return m.now
 }
 
+// mockDuration returns the duration by which a running clock advances in a
+// given duration of real time, according to the speed of the clock.
+func (m *mockClock) mockDuration(d time.Duration) time.Duration {
+	if m.speed == 1 {
+		return d
+	}
+	return time.Duration(float64(d) * m.speed)
+}
+
+// realDuration returns the duration of real time in which a running clock
+// advances by a given duration, according to the speed of the clock.
+func (m *mockClock) realDuration(d time.Duration) time.Duration {
+	if m.speed == 1 {
+		return d
+	}
+	return time.Duration(float64(d) / m.speed)
+}
+
 // Update moves the current time of the mock clock forward by a duration
 // corresponding to the passage of real-time since it was last advanced.
 //
@@ -382,6 +784,14 @@ func (m *mockClock) AdvanceBy(d time.Duration) {
 // No attempt is made to simulate the expected elapsed time between the current time
 // and the new time or any relative time between timers.
 func (m *mockClock) AdvanceTo(t time.Time) {
+	m.panicIfClosed()
+
+	// if stall detection is enabled, a watchdog reports on the advance if it does
+	// not complete within the configured threshold
+	defer m.startStallDetection(t)()
+
+	m.tracefNow("advance to %s", t.Format(time.RFC3339Nano))
+
 	// a common pattern in tests involving a mock clock is to establish a
 	// goroutine to perform some setup or spy, before advancing the mock clock.
 	//
@@ -399,13 +809,21 @@ func (m *mockClock) AdvanceTo(t time.Time) {
 	// execute timers until there are no more before the new time. If a ticker is
 	// ticked, we sort the tickers in case the ticker just ticked now has a new next
 	// time later than the previous next ticker next time.
-	for m.tick(t) {
+	//
+	// progress is reported (if enabled) as the timers are executed
+	for fired := 1; ; fired++ {
+		due, _ := m.nextDeadline()
+		if !m.tick(t) {
+			break
+		}
+		m.reportProgress(due, t, fired)
 	}
 
 	// Ensure that we end with the new time.
 	m.withLock(func(m *mockClock) {
-		m.now = t.In(m.loc)
+		m.setNow(t.In(m.loc))
 		m.updated = time.Now()
+		m.tracef(m.now, "advanced")
 	})
 
 	// a second yield is provided to allow for any goroutines that are waiting
@@ -413,6 +831,19 @@ func (m *mockClock) AdvanceTo(t time.Time) {
 	time.Sleep(m.yield)
 }
 
+// nextDeadline returns the time at which the next active timer or ticker of
+// the clock is due to fire and true, or false if there are no active timers
+// or tickers.
+func (m *mockClock) nextDeadline() (time.Time, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if len(m.tickers.active) == 0 {
+		return time.Time{}, false
+	}
+	return m.tickers.active[0].nextTick(), true
+}
+
 // CreatedAt returns the time at which the clock was created.
 func (m *mockClock) CreatedAt() time.Time {
 	// this is not mutated after the clock is created so no lock is needed
@@ -462,62 +893,146 @@ func (m *mockClock) Stop() {
 	m.nStopped.Add(1)
 }
 
+// Freeze stops the clock (see: Stop), first advancing a running clock by the
+// real time elapsed since it was last updated, so that the time of the clock
+// is pinned at the time at which it was frozen.
+//
+// Every call to Freeze() must be matched with a call to Unfreeze().
+func (m *mockClock) Freeze() {
+	m.withLock(func(m *mockClock) {
+		m.advance()
+		m.nStopped.Add(1)
+	})
+}
+
+// FreezeAt freezes the clock (see: Freeze) and advances it to a given time,
+// triggering any timers or tickers that would have been triggered during that
+// passage of time.
+//
+// The clock remains frozen if the time is earlier than the current time of
+// the clock, in which case the function panics with ErrNotADelorean.
+func (m *mockClock) FreezeAt(t time.Time) {
+	m.Freeze()
+	m.AdvanceTo(t)
+}
+
+// Unfreeze starts a clock that has been frozen (see: Start).  A clock that
+// is running once unfrozen continues to advance from the time at which it
+// was frozen; the real time elapsed while the clock was frozen is discarded.
+func (m *mockClock) Unfreeze() {
+	m.withLock(func(m *mockClock) {
+		m.updated = time.Now()
+	})
+	m.Start()
+}
+
+// WhileFrozen calls a function with the clock frozen, unfreezing the clock
+// when the function returns (or panics).
+func (m *mockClock) WhileFrozen(fn func()) {
+	m.Freeze()
+	defer m.Unfreeze()
+
+	fn()
+}
+
 // ------------------------------------------------------------------------------------------------
 
 func (m *mockClock) resetTicker(t *ticker, d time.Duration) {
 	m.withLock(func(m *mockClock) {
 		t.d = d
-		t.next = m.now.Add(max(d, 0))
+		t.next, t.late, t.isPostponed = m.now.Add(max(d, 0)), 0, false
+		m.tracef(m.now, "reset: %s, interval: %s", t.describe(), d)
+		m.record(m.now, "reset", t.info(), d)
+
+		// an active ticker must be re-positioned in the active list
+		sort.Sort(m.tickers.active)
 	})
+	m.scheduled(t, d)
 
 	t.enterState(tsActive)
 }
 
 func (m *mockClock) resetTimer(t *timer, d time.Duration) {
 	m.withLock(func(m *mockClock) {
-		if t.next = t.clock.now.Add(d); d == 0 {
-			t.tick(t.clock.now)
-		}
+		m.tracef(m.now, "reset: %s, duration: %s", t.describe(), d)
+		m.record(m.now, "reset", t.info(), d)
+		t.isPostponed = false
+		t.next = t.clock.now.Add(d)
+
+		// an active timer must be re-positioned in the active list
+		sort.Sort(m.tickers.active)
 	})
+	m.scheduled(t, d)
 
 	if t.state != tsActive {
 		t.enterState(tsActive)
 	}
+	if d == 0 {
+		t.tick(t.next)
+	}
 }
 
 // activateTicker adds a ticker to the list of active tickers.
+//
+// This method is not thread-safe and should only be called while the clock
+// is locked.
 func (m *mockClock) activateTicker(t tickable) {
-	m.tickers.active = append(m.tickers.active, t)
-	sort.Sort(m.tickers.active)
+	m.tickers.active = m.tickers.active.insert(t)
 }
 
-// disableTicker moves a ticker from the active list to the inactive list.
+// disableTicker moves a ticker from the active list to the inactive tickers.
+// An abandoned ticker is removed from the active list but is not added to
+// the inactive tickers.
 func (m *mockClock) disableTicker(id int) {
-	var ticker tickable
+	m.withLock(func(m *mockClock) {
+		var ticker tickable
 
-	if m.tickers.active, ticker = m.tickers.active.take(id); ticker != nil {
-		m.tickers.inactive = append(m.tickers.inactive, ticker)
-	}
+		if m.tickers.active, ticker = m.tickers.active.take(id); ticker != nil {
+			m.tickers.inactive.add(ticker)
+		}
+	})
+}
+
+// abandon is called (as a finalizer) when the Timer or Ticker wrapping a
+// ticker is no longer reachable, marking the ticker as abandoned and
+// removing it from the inactive tickers.  Only the shard of the inactive
+// tickers holding the ticker is locked, not the clock.
+//
+// An active ticker remains in the active list until it expires or is
+// stopped, since a channel or function obtained from it may yet be waiting
+// for it to fire (e.g. a channel obtained from After or Tick).
+func (m *mockClock) abandon(id int, isAbandoned *bool) {
+	m.tickers.inactive.abandon(id, isAbandoned)
 }
 
 // enableTicker moves a ticker from the inactive list to the active list.
 func (m *mockClock) enableTicker(id int) {
-	var ticker tickable
-
-	if m.tickers.inactive, ticker = m.tickers.inactive.take(id); ticker != nil {
-		m.activateTicker(ticker)
-	}
+	m.withLock(func(m *mockClock) {
+		if ticker := m.tickers.inactive.take(id); ticker != nil {
+			m.activateTicker(ticker)
+		}
+	})
 }
 
 // newTicker creates a new Ticker backed by a mockTicker.
-func (m *mockClock) newTicker(d time.Duration) *Ticker {
+func (m *mockClock) newTicker(d time.Duration, name string) *Ticker {
 	m.panicIfLocked()
+	m.panicIfClosed()
+
+	stack := m.callerStack()
 
-	ticker := eval(m, func() *Ticker {
-		ticker := &Ticker{
+	var (
+		result *Ticker
+		now    time.Time
+	)
+	m.withLock(func(m *mockClock) {
+		now = m.now
+		result = &Ticker{
 			Ticker: &time.Ticker{},
 			ticker: &ticker{
 				tickerId: m.nextTickerId,
+				name:     name,
+				stack:    stack,
 				c:        make(chan time.Time, 1),
 				d:        d,
 				next:     m.now.Add(max(d, 0)),
@@ -525,19 +1040,26 @@ func (m *mockClock) newTicker(d time.Duration) *Ticker {
 			},
 			initialised: true,
 		}
-		ticker.C = ticker.c
+		result.C = result.ticker.c
 
-		m.activateTicker(ticker)
+		m.tracef(m.now, "new: %s, interval: %s", result.describe(), d)
+		m.record(m.now, "new", result.info(), d)
+		m.activateTicker(result.ticker)
 		m.nextTickerId++
+	})
+	m.scheduled(result.ticker, d)
 
-		return ticker
+	// once the Ticker is unreachable the ticker cannot be reset so need not
+	// be retained when stopped
+	runtime.SetFinalizer(result, func(t *Ticker) {
+		m.abandon(t.tickerId, &t.ticker.isAbandoned)
 	})
 
 	if d <= 0 {
-		ticker.tick(m.now)
+		result.tick(now)
 	}
 
-	return ticker
+	return result
 }
 
 // tick causes the first active ticker before time t (if any) to tick.
@@ -550,7 +1072,7 @@ func (m *mockClock) tick(t time.Time) bool {
 			return nil
 		}
 
-		ticker := m.tickers.active[0]
+		ticker := m.chaos.first(m.tickers.active)
 		if ticker.nextTick().After(t) {
 			return nil
 		}
@@ -562,7 +1084,11 @@ func (m *mockClock) tick(t time.Time) bool {
 		return false
 	}
 
-	ticker.tick(t)
+	// if the tick is postponed it will be ticked when the clock reaches the
+	// postponed time (which may be within the current advance)
+	if !m.postpone(ticker) {
+		ticker.tick(t)
+	}
 
 	m.withLock(func(m *mockClock) {
 		sort.Sort(m.tickers.active)
@@ -572,8 +1098,16 @@ func (m *mockClock) tick(t time.Time) bool {
 }
 
 // newTimer creates a new Timer backed by a mocked timer.
-func (m *mockClock) newTimer(d time.Duration, fn func()) (result *Timer) {
+func (m *mockClock) newTimer(d time.Duration, name string, fn func()) (result *Timer) {
+	m.panicIfLocked()
+	m.panicIfClosed()
+
+	stack := m.callerStack()
+
+	var now time.Time
 	m.withLock(func(m *mockClock) {
+		now = m.now
+
 		// a time.Timer is used to provide a read-only reference to the
 		// the channel on which the time is sent when the timer expires
 		// (when no function is provided).
@@ -584,6 +1118,8 @@ func (m *mockClock) newTimer(d time.Duration, fn func()) (result *Timer) {
 			Timer: &time.Timer{},
 			timer: &timer{
 				tickerId: m.nextTickerId,
+				name:     name,
+				stack:    stack,
 				next:     m.now.Add(max(d, 0)),
 				fn:       fn,
 				clock:    m,
@@ -598,30 +1134,98 @@ func (m *mockClock) newTimer(d time.Duration, fn func()) (result *Timer) {
 			result.Timer.C = result.timer.c
 		}
 
-		m.activateTicker(result)
+		m.tracef(m.now, "new: %s, duration: %s", result.describe(), d)
+		m.record(m.now, "new", result.info(), d)
+		m.activateTicker(result.timer)
 		m.nextTickerId++
 	})
+	m.scheduled(result.timer, d)
+
+	// once the Timer is unreachable the timer cannot be reset so need not
+	// be retained when expired or stopped
+	runtime.SetFinalizer(result, func(t *Timer) {
+		m.abandon(t.tickerId, &t.timer.isAbandoned)
+	})
 
 	if d <= 0 {
-		result.tick(m.now)
+		result.tick(now)
 	}
 
 	return result
 }
 
+// ContextFormat determines the string representation of the contexts with
+// deadlines and timeouts of a mock clock (see: WithContextFormat), of the form:
+//
+//	<prefix>: <time remaining>: <deadline>[: <cause>]
+//
+// e.g. "mock: context.WithDeadline: 1s: 1970-01-01 00:00:01 +0000 UTC".
+type ContextFormat struct {
+	// Prefix is the prefix of the string; if empty, the string has no prefix.
+	Prefix string
+
+	// Layout is the layout with which the deadline is formatted (see:
+	// time.Time.Format); if empty, the layout of time.Time.String is used.
+	Layout string
+
+	// IncludeCause determines whether the cause of the context (specified
+	// using ContextWithDeadlineCause or ContextWithTimeoutCause) is included,
+	// if the context has a cause.
+	IncludeCause bool
+}
+
+// DefaultContextFormat is the format of the string representation of the
+// contexts of a mock clock for which no other format is specified.
+var DefaultContextFormat = ContextFormat{
+	Prefix:       "mock: context.WithDeadline",
+	Layout:       "2006-01-02 15:04:05.999999999 -0700 MST",
+	IncludeCause: true,
+}
+
+// format returns the string representation of a context in the format, with a
+// given time remaining until its deadline and cause (if any).
+func (f ContextFormat) format(remaining time.Duration, deadline time.Time, cause error) string {
+	layout := f.Layout
+	if layout == "" {
+		layout = DefaultContextFormat.Layout
+	}
+
+	s := remaining.String() + ": " + deadline.Format(layout)
+	if f.Prefix != "" {
+		s = f.Prefix + ": " + s
+	}
+	if f.IncludeCause && cause != nil {
+		s += ": " + cause.Error()
+	}
+	return s
+}
+
 // ensure that mockContext implements the context.Context interface
 var _ context.Context = (*mockContext)(nil)
 
+// mockContext is a context with a deadline determined by a mock clock.
+//
+// Cancellation is delegated to a context obtained from context.WithCancelCause
+// so that cancellation of the parent is propagated and context.Cause reports
+// the cause of cancellation exactly as for a context with a deadline obtained
+// from the standard library.
+//
+// The context has its own done channel, closed once the error of the context
+// is determined, so that the children of the context obtain the error from
+// the mock context (rather than the delegated context).
 type mockContext struct {
+	context.Context
 	sync.Mutex
 
 	clock    Clock
-	parent   context.Context
 	deadline time.Time
+	cause    error
+	format   ContextFormat
+	cancel   context.CancelCauseFunc
 	done     chan struct{}
-
-	err   error
-	timer *Timer
+	isDone   bool
+	expired  bool
+	timer    *Timer
 }
 
 // newMockContext returns a new context with the given deadline and a
@@ -629,69 +1233,91 @@ type mockContext struct {
 //
 // If the specified deadline has already passed, the context is immediately
 // cancelled with context.DeadlineExceeded.
+//
+// When the deadline is reached the context error is context.DeadlineExceeded
+// and the cause (see: context.Cause) is the given cause or (if nil)
+// context.DeadlineExceeded.  When cancelled using the returned cancel function
+// the context error and cause are context.Canceled.
 func newMockContext(
 	parent context.Context,
 	clock Clock,
 	deadline time.Time,
 	cause error,
 ) (*mockContext, context.CancelFunc) {
+	inner, cancel := context.WithCancelCause(parent)
 	ctx := &mockContext{
+		Context:  inner,
 		clock:    clock,
-		parent:   parent,
 		deadline: deadline.UTC(),
+		cause:    cause,
+		format:   DefaultContextFormat,
+		cancel:   cancel,
 		done:     make(chan struct{}),
 	}
 
-	// if the parent has a cancellation channel arrange to cancel the new
-	// child context if the parent is cancelled
-	if parent.Done() != nil {
-		go func() {
-			select {
-			case <-parent.Done():
-				ctx.cancel(parent.Err())
-			case <-ctx.Done():
-				// if the child context is cancelled, stop listening for
-				// cancellation on the parent context
-			}
-		}()
+	// if the parent is cancelled the delegated context is cancelled with the
+	// error and cause of the parent
+	_ = context.AfterFunc(inner, ctx.stop)
+
+	if cause == nil {
+		cause = context.DeadlineExceeded
 	}
 
 	dur := clock.Until(deadline)
 	if dur <= 0 {
-		ctx.cancel(context.DeadlineExceeded) // deadline has already passed
+		ctx.expire(cause) // deadline has already passed
 		return ctx, func() { /* NO-OP */ }
 	}
 
 	ctx.Lock()
 	defer ctx.Unlock()
 
-	if ctx.err == nil {
+	if inner.Err() == nil {
 		// if the context is not already cancelled, start a timer to cancel
 		// the context when the deadline is reached
-		ctx.timer = clock.AfterFunc(dur, func() {
-			err := context.DeadlineExceeded
-			if cause != nil {
-				err = fmt.Errorf("%w: %w", err, cause)
-			}
-			ctx.cancel(err)
-		})
+		ctx.timer = clock.AfterFunc(dur, func() { ctx.expire(cause) })
 	}
 
 	// return the new context and a cancel function
 	// the cancel function will stop the timer if it is still running
 	// and cancel the context
-	return ctx, func() { ctx.cancel(context.Canceled) }
+	return ctx, ctx.stop
 }
 
-func (c *mockContext) cancel(err error) {
+// expire cancels the context (if not already cancelled) when the deadline is
+// reached, with a given cause.
+func (c *mockContext) expire(cause error) {
 	c.Lock()
 	defer c.Unlock()
 
-	if c.err != nil {
-		return // already canceled
+	if c.isDone {
+		return // already cancelled
 	}
+	c.cancel(cause)
+	c.expired = true
+	c.closeDone()
+}
+
+// stop cancels the context (if not already cancelled) and stops the timer on
+// the deadline, if running.
+func (c *mockContext) stop() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.cancel(nil)
+	c.closeDone()
+}
 
-	c.err = err
+// closeDone closes the done channel of the context and stops the timer on the
+// deadline, if not already done.
+//
+// This method is not thread-safe and should only be called while the context
+// is locked.
+func (c *mockContext) closeDone() {
+	if c.isDone {
+		return
+	}
+	c.isDone = true
 	close(c.done)
 
 	if c.timer != nil {
@@ -704,10 +1330,24 @@ func (c *mockContext) Deadline() (deadline time.Time, ok bool) { return c.deadli
 
 func (c *mockContext) Done() <-chan struct{} { return c.done }
 
-func (c *mockContext) Err() error { return c.err }
+// Err returns nil if the context is not done, context.DeadlineExceeded if the
+// deadline was reached or the error of the context cancelled by its cancel
+// function or the cancellation of its parent.
+func (c *mockContext) Err() error {
+	c.Lock()
+	defer c.Unlock()
 
-func (c *mockContext) Value(key any) any { return c.parent.Value(key) }
+	switch {
+	case !c.isDone:
+		return nil
+	case c.expired:
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
 
+// String returns a description of the context, with the time remaining until
+// its deadline, in the format of the clock (see: WithContextFormat).
 func (c *mockContext) String() string {
-	return fmt.Sprintf("mock: context.WithDeadline: %s: %s", c.deadline.Sub(c.clock.Now()), c.deadline)
+	return c.format.format(c.deadline.Sub(c.clock.Now()), c.deadline, c.cause)
 }
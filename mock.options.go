@@ -1,6 +1,7 @@
 package time
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -25,11 +26,34 @@ func AtNow() ClockOption {
 //	1970-01-01 00:00:00 +0000 UTC (in the location of the clock)
 func AtTime(t time.Time) ClockOption {
 	return func(m *mockClock) {
-		m.now = t.In(m.loc)
+		m.setNow(t.In(m.loc))
 		m.updated = time.Now()
 	}
 }
 
+// CoalescesTicks sets the mock clock to coalesce ticks when the clock is
+// advanced.  Ticks are dropped in the same way as for DropsTicks, but the
+// number of intervals that were dropped is recorded by each ticker and may be
+// obtained using the Skipped() method of the Ticker.
+//
+// # Example
+//
+// When a ticker is set to tick every 300 ms and the clock is advanced by 1s,
+// the clock will only send a tick event for the final tick at 900ms and
+// Skipped() will then return 2.
+//
+// This may be used to test a reader that must account for the work of any
+// missed intervals rather than silently ignoring them.
+//
+// # Default
+//
+//	not set/disabled
+func CoalescesTicks() ClockOption {
+	return func(m *mockClock) {
+		m.coalescesTicks = true
+	}
+}
+
 // DropsTicks sets the mock clock to drop ticks when the clock is advanced.
 // That is, if the clock is advanced by a duration that would ordinarily
 // result in a ticker being triggered more than once, the clock will only
@@ -71,7 +95,8 @@ func DropsTicks() ClockOption {
 //	UTC
 func InLocation(loc *time.Location) ClockOption {
 	return func(m *mockClock) {
-		m.now = m.now.In(loc)
+		m.loc = loc
+		m.setNow(m.now.In(loc))
 	}
 }
 
@@ -95,6 +120,233 @@ func StartRunning() ClockOption {
 	}
 }
 
+// WithCallerTracking sets the mock clock to record the call stack at which each
+// timer and ticker is created.  The call stack is provided in the TimerInfo for
+// each timer and ticker, obtained from Timers() or provided in a StallReport.
+//
+// This may be useful in identifying the code responsible for scheduling an
+// unexpected timer, at the cost of capturing a call stack for each timer and
+// ticker created.
+//
+// # Default
+//
+//	not set / disabled
+func WithCallerTracking() ClockOption {
+	return func(m *mockClock) {
+		m.tracksCallers = true
+	}
+}
+
+// WithChaos sets the mock clock to disrupt the delivery of timers and tickers
+// at random: delivering timers and ticks late, coalescing the ticks of tickers
+// and reordering timers and tickers due at the same instant, with the
+// probabilities given in a ChaosConfig.
+//
+// Decisions are made using a random number generator with the given seed, so
+// that a test advancing the clock in the same way will experience the same
+// disruption each time it is run; by running a test with a number of different
+// seeds, the robustness of timing-sensitive code may be fuzzed
+// deterministically:
+//
+//	for seed := range uint64(100) {
+//		clock := time.NewMockClock(time.WithChaos(seed, time.ChaosConfig{
+//			LateProbability: 0.2,
+//			MaxLateness:     100 * time.Millisecond,
+//		}))
+//		// ...
+//	}
+//
+// # Default
+//
+//	not set / disabled
+func WithChaos(seed uint64, cfg ChaosConfig) ClockOption {
+	return func(m *mockClock) {
+		m.chaos = newChaos(seed, cfg)
+	}
+}
+
+// WithContextFormat sets the format of the string representation of the
+// contexts with deadlines and timeouts of the mock clock, e.g. so that the
+// contexts are described consistently in golden logs:
+//
+//	format := time.DefaultContextFormat
+//	format.Prefix = "ctx"
+//	format.Layout = time.RFC3339
+//	clock := time.NewMockClock(time.WithContextFormat(format))
+//
+// # Default
+//
+//	DefaultContextFormat
+func WithContextFormat(f ContextFormat) ClockOption {
+	return func(m *mockClock) {
+		m.contextFormat = f
+	}
+}
+
+// WithNowLatency sets the mock clock to consume a given duration of time on
+// each call to Now (and to Since and Until).
+//
+// When the clock is stopped, the clock is advanced by the latency (in virtual
+// time), triggering any timers or tickers that would be triggered during
+// that passage of time.  When the clock is running, the calling goroutine is
+// suspended for the latency (in real time), by which the running clock is
+// then advanced.
+//
+// This may be used to surface code paths that call Now excessively (in a
+// hot loop, for example) or to simulate slow clock syscalls.  For a clock
+// other than a mock clock, see NewSlowClock.
+//
+// # Default
+//
+//	0 (not set / disabled)
+func WithNowLatency(d time.Duration) ClockOption {
+	return func(m *mockClock) {
+		m.nowLatency = max(d, 0)
+	}
+}
+
+// WithPanicRecovery sets the mock clock to recover a panic in a function called
+// by a timer (created by AfterFunc, or for a context deadline or timeout),
+// which would otherwise crash the test process from a goroutine deep inside
+// the clock.
+//
+// Each panic recovered is recorded as a CallbackPanic, which may be obtained
+// from the Err() method of the clock, and each of the given handlers is called
+// with the CallbackPanic.  To fail a test when a panic is recovered, specify
+// the FailOnPanic handler:
+//
+//	clock := time.NewMockClock(time.WithPanicRecovery(time.FailOnPanic(t)))
+//
+// # Default
+//
+//	not set / disabled (a panic is not recovered)
+func WithPanicRecovery(handlers ...PanicHandler) ClockOption {
+	return func(m *mockClock) {
+		m.panics = &panicRecovery{handlers: handlers}
+	}
+}
+
+// WithProgress sets the mock clock to report the progress of each advance
+// (AdvanceBy() or AdvanceTo()) to a given ProgressReporter, after every n
+// timer and ticker events fired by the advance (n < 1 is treated as 1).
+//
+// This allows a harness running a long simulation to render the progress of
+// virtual time and to abort a runaway advance (e.g. a ticker with a very short
+// interval, or timers that re-arm themselves indefinitely):
+//
+//	clock := time.NewMockClock(time.WithProgress(1000, time.ProgressFunc(
+//		func(current, target time.Time, fired int) error {
+//			if fired > 1_000_000 {
+//				return errors.New("runaway advance")
+//			}
+//			return nil
+//		})))
+//
+// To write the progress of advances to an io.Writer, use ProgressWriter.
+//
+// # Default
+//
+//	not set / disabled
+func WithProgress(n int, r ProgressReporter) ClockOption {
+	return func(m *mockClock) {
+		m.progress = progressReporting{every: max(n, 1), reporter: r}
+	}
+}
+
+// WithRecorder sets the mock clock to record the operations on its timers and
+// tickers using a given ScheduleRecorder: the creation, reset, firing, pausing
+// and stopping of each timer and ticker, with the time (relative to the initial
+// time of the clock) at which each occurred.
+//
+// The recorded ScheduleTrace may be compared with a golden trace, guarding
+// against accidental changes to the scheduling behaviour of the code under
+// test (such as retry or backoff intervals):
+//
+//	rec := &time.ScheduleRecorder{}
+//	clock := time.NewMockClock(time.WithRecorder(rec))
+//	// ... exercise the code under test
+//	time.AssertGoldenSchedule(t, rec.Trace(), "testdata/retry.golden.json", time.Millisecond)
+//
+// # Default
+//
+//	not set / disabled
+func WithRecorder(r *ScheduleRecorder) ClockOption {
+	return func(m *mockClock) {
+		m.recorder = r
+	}
+}
+
+// WithSpeed sets the rate at which a running mock clock advances, as a multiple
+// of real time.  e.g. a clock with a speed of 60 advances by one minute for
+// each second of real time.
+//
+// Sleep on a running clock suspends the calling goroutine for the real time
+// corresponding to the duration of the sleep (the duration divided by the
+// speed), so that code sleeping on a fast clock completes in a fraction of
+// the time it would take in real time.
+//
+// The speed has no effect when the clock is stopped.
+//
+// The function panics if the speed is not greater than zero.
+//
+// # Default
+//
+//	1 (real time)
+func WithSpeed(factor float64) ClockOption {
+	if !(factor > 0) {
+		panic(fmt.Errorf("%w: %v for WithSpeed", errInvalidSpeed, factor))
+	}
+	return func(m *mockClock) {
+		m.speed = factor
+	}
+}
+
+// WithStallDetection sets a real-time threshold for advances of the mock clock.
+// If an advance (AdvanceBy() or AdvanceTo()) does not complete within the
+// threshold, each of the given handlers is called with a StallReport
+// describing the advance and any active or pending timers and tickers.
+//
+// If no handlers are specified the report is written to os.Stderr.  To
+// terminate a stalled test, specify the PanicOnStall handler.
+//
+// Giving names to timers and tickers (see NewTimerNamed and NewTickerNamed)
+// makes it easier to identify them in a StallReport.
+//
+// # Default
+//
+//	not set / disabled
+func WithStallDetection(threshold time.Duration, handlers ...StallHandler) ClockOption {
+	return func(m *mockClock) {
+		m.stalls = stallDetection{
+			threshold: threshold,
+			handlers:  handlers,
+		}
+	}
+}
+
+// WithTrace sets the mock clock to write a trace of its operations to the given
+// TraceLogger; a testing.T (or any testing.TB) may be used, to write the trace
+// to the log of a test:
+//
+//	clock := time.NewMockClock(time.WithTrace(t))
+//
+// Each entry in the trace identifies the (mock) time of the clock at which the
+// operation occurred.  Operations traced include calls to Now(), advancing the
+// clock and the creation, reset, firing and stopping of timers and tickers.
+//
+// To write a trace to an io.Writer, use TraceWriter:
+//
+//	clock := time.NewMockClock(time.WithTrace(time.TraceWriter(os.Stdout)))
+//
+// # Default
+//
+//	not set / disabled
+func WithTrace(log TraceLogger) ClockOption {
+	return func(m *mockClock) {
+		m.tracer = log
+	}
+}
+
 // Yielding sets a duration for which the calling goroutine will be suspended
 // when performing operations such as advancing the clock or adding a timer or ticker.
 //
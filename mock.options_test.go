@@ -43,6 +43,20 @@ func TestClockOption_InLocation(t *testing.T) {
 	test.Value(t, mock.Now().Location()).Equals(loc)
 }
 
+// Tests that a mock clock in a location remains in that location when advanced
+// to a given time.
+func TestClockOption_InLocation_AdvanceTo(t *testing.T) {
+	// arrange
+	tokyo := time.FixedZone("JST", 9*60*60)
+	clock := NewMockClock(InLocation(tokyo))
+
+	// act
+	clock.AdvanceTo(time.Unix(3600, 0).UTC())
+
+	// assert
+	test.That(t, clock.Now().Location()).Equals(tokyo)
+}
+
 // Tests that YieldingFor sets the duration for which the calling goroutine is to be suspended
 // when performing operations such as advancing the clock or adding a timer or ticker.
 func TestClockOption_YieldingFor(t *testing.T) {
@@ -59,3 +73,76 @@ func TestClockOption_YieldingFor(t *testing.T) {
 	elapsed := time.Since(start)
 	test.IsTrue(t, elapsed >= d, "elapsed time")
 }
+
+// Tests that WithCallerTracking enables tracking of callers on the mock clock.
+func TestClockOption_WithCallerTracking(t *testing.T) {
+	// act
+	mock := NewMockClock(WithCallerTracking()).(*mockClock)
+
+	// assert
+	test.IsTrue(t, mock.tracksCallers)
+}
+
+// Tests that WithStallDetection configures stall detection on the mock clock.
+func TestClockOption_WithStallDetection(t *testing.T) {
+	// act
+	mock := NewMockClock(WithStallDetection(time.Second, PanicOnStall)).(*mockClock)
+
+	// assert
+	test.That(t, mock.stalls.threshold).Equals(time.Second)
+	test.That(t, len(mock.stalls.handlers)).Equals(1)
+}
+
+// Tests that WithNowLatency advances a stopped clock on each call to Now,
+// triggering any timers that become due.
+func TestClockOption_WithNowLatency(t *testing.T) {
+	// arrange
+	mock := NewMockClock(WithNowLatency(time.Second))
+	timer := mock.NewTimer(2 * time.Second)
+
+	// act
+	first := mock.Now()
+	second := mock.Now()
+
+	// assert
+	test.That(t, first).Equals(time.Unix(1, 0).UTC())
+	test.That(t, second).Equals(time.Unix(2, 0).UTC())
+	test.That(t, <-timer.C).Equals(time.Unix(2, 0).UTC())
+}
+
+// Tests that WithNowLatency suspends the caller of Now on a running clock.
+func TestClockOption_WithNowLatency_Running(t *testing.T) {
+	// arrange
+	mock := NewMockClock(WithNowLatency(10*time.Millisecond), StartRunning())
+	start := mock.Now()
+
+	// act
+	result := mock.Since(start)
+
+	// assert
+	test.IsTrue(t, result >= 10*time.Millisecond)
+}
+
+// Tests that a running clock with a speed advances by a multiple of real time
+// and that Sleep suspends the caller for the corresponding real time.
+func TestClockOption_WithSpeed(t *testing.T) {
+	// arrange
+	mock := NewMockClock(WithSpeed(1000), StartRunning())
+	start := mock.Now()
+	realStart := time.Now()
+
+	// act
+	mock.Sleep(10 * time.Second)
+
+	// assert
+	test.IsTrue(t, time.Since(realStart) < time.Second)
+	test.IsTrue(t, mock.Since(start) >= 10*time.Second)
+}
+
+func TestClockOption_WithSpeed_NotPositive(t *testing.T) {
+	// arrange
+	defer test.ExpectPanic(errInvalidSpeed).Assert(t)
+
+	// act
+	_ = WithSpeed(0)
+}
@@ -0,0 +1,146 @@
+package time
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// eventOps maps the words identifying the operation of a ScheduleEvent in an
+// event pattern (see: ScheduleEvent.Matches) to the operation; an operation
+// may be identified by its name ("fire") or in the past tense ("fired").
+var eventOps = map[string]string{
+	"new": "new", "created": "new",
+	"reset": "reset",
+	"stop":  "stop", "stopped": "stop",
+	"pause": "pause", "paused": "pause",
+	"fire": "fire", "fired": "fire",
+	"tick": "tick", "ticked": "tick",
+	"drop": "drop", "dropped": "drop",
+}
+
+// Matches returns true if the event matches a given pattern.  A pattern is a
+// sequence of words separated by spaces, each of which must match the event:
+//
+//   - an operation, e.g. "fire" (or "fired"), "tick" or "stop"; an operation
+//     may be identified by its name or in the past tense: "created" (new),
+//     "reset", "stopped", "paused", "fired", "ticked" and "dropped";
+//   - a kind of timer: "Timer", "AfterFunc" or "Ticker";
+//   - an id, e.g. "#3";
+//   - otherwise, the name of the timer or ticker, which may be a pattern
+//     using the syntax of path.Match, e.g. "retry*".
+//
+// For example, "retry fired" matches the firing of a timer named "retry" and
+// "Ticker #2" matches any operation on the ticker with id 2.
+func (e ScheduleEvent) Matches(pattern string) bool {
+	for _, word := range strings.Fields(pattern) {
+		if op, ok := eventOps[word]; ok {
+			if e.Op != op {
+				return false
+			}
+			continue
+		}
+
+		switch {
+		case word == "Timer" || word == "AfterFunc" || word == "Ticker":
+			if e.Kind != word {
+				return false
+			}
+		case strings.HasPrefix(word, "#"):
+			if id, err := strconv.Atoi(word[1:]); err != nil || e.ID != id {
+				return false
+			}
+		default:
+			if ok, _ := path.Match(word, e.Name); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Find returns the events in the trace that match a given pattern (see:
+// ScheduleEvent.Matches).
+func (tr ScheduleTrace) Find(pattern string) ScheduleTrace {
+	var result ScheduleTrace
+	for _, e := range tr {
+		if e.Matches(pattern) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// index returns the index of the first event in the trace at or after a given
+// index that matches a given pattern, or -1 if there is none.
+func (tr ScheduleTrace) index(from int, pattern string) int {
+	for i := from; i < len(tr); i++ {
+		if tr[i].Matches(pattern) {
+			return i
+		}
+	}
+	return -1
+}
+
+// AssertOrder verifies that events matching each of a given sequence of
+// patterns (see: ScheduleEvent.Matches) have been recorded in the order given,
+// failing the test if not.  The ordering is weak: other events may be recorded
+// before, between or after the matching events, and events recorded at the same
+// (mock) time are ordered as they were recorded.
+//
+// This allows a test to document the timing relationships between events
+// without asserting the instants at which they occurred:
+//
+//	time.AssertOrder(t, rec, "timerA fired", "timerB fired")
+func AssertOrder(t testing.TB, rec *ScheduleRecorder, patterns ...string) bool {
+	t.Helper()
+
+	tr := rec.Trace()
+	from := 0
+	for i, pattern := range patterns {
+		n := tr.index(from, pattern)
+		switch {
+		case n >= 0:
+			from = n + 1
+			continue
+		case i == 0:
+			t.Errorf("no event matching %q recorded", pattern)
+		default:
+			t.Errorf("no event matching %q recorded after %s", pattern, tr[from-1])
+		}
+		return false
+	}
+	return true
+}
+
+// AssertHappenedBefore verifies that the first event matching a given pattern
+// was recorded before the first event matching another (see:
+// ScheduleEvent.Matches), failing the test if not or if no event matching
+// either pattern has been recorded.
+func AssertHappenedBefore(t testing.TB, rec *ScheduleRecorder, pattern, other string) bool {
+	t.Helper()
+
+	tr := rec.Trace()
+	i, j := tr.index(0, pattern), tr.index(0, other)
+	switch {
+	case i < 0:
+		t.Errorf("no event matching %q recorded", pattern)
+	case j < 0:
+		t.Errorf("no event matching %q recorded", other)
+	case i >= j:
+		t.Errorf("%q did not happen before %q: %s was recorded first", pattern, other, tr[j])
+	default:
+		return true
+	}
+	return false
+}
+
+// AssertHappenedAfter verifies that the first event matching a given pattern
+// was recorded after the first event matching another (see:
+// AssertHappenedBefore).
+func AssertHappenedAfter(t testing.TB, rec *ScheduleRecorder, pattern, other string) bool {
+	t.Helper()
+
+	return AssertHappenedBefore(t, rec, other, pattern)
+}
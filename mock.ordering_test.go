@@ -0,0 +1,143 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// orderingRecorder returns a recorder of a mock clock on which timers named
+// "timerA" and "timerB" have fired, after 2s and 1s respectively, and a ticker
+// has ticked twice.
+func orderingRecorder() *ScheduleRecorder {
+	rec := &ScheduleRecorder{}
+	clock := NewMockClock(WithRecorder(rec))
+
+	_ = clock.NewTimerNamed(2*time.Second, "timerA")
+	_ = clock.NewTimerNamed(time.Second, "timerB")
+	ticker := clock.NewTicker(1500 * time.Millisecond)
+	clock.AdvanceBy(3 * time.Second)
+	ticker.Stop()
+
+	return rec
+}
+
+func TestScheduleEvent_Matches(t *testing.T) {
+	event := ScheduleEvent{At: time.Second, Op: "fire", Kind: "AfterFunc", ID: 3, Name: "retry-1"}
+
+	testcases := []struct {
+		scenario string
+		pattern  string
+		result   bool
+	}{
+		{scenario: "empty", pattern: "", result: true},
+		{scenario: "op", pattern: "fire", result: true},
+		{scenario: "op (past tense)", pattern: "fired", result: true},
+		{scenario: "other op", pattern: "stopped", result: false},
+		{scenario: "name and op", pattern: "retry-1 fired", result: true},
+		{scenario: "name pattern", pattern: "retry-*", result: true},
+		{scenario: "other name", pattern: "timeout", result: false},
+		{scenario: "kind", pattern: "AfterFunc", result: true},
+		{scenario: "other kind", pattern: "Ticker", result: false},
+		{scenario: "id", pattern: "#3 fire", result: true},
+		{scenario: "other id", pattern: "#4", result: false},
+		{scenario: "invalid id", pattern: "#x", result: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result := event.Matches(tc.pattern)
+
+			// assert
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestScheduleTrace_Find(t *testing.T) {
+	// arrange
+	rec := orderingRecorder()
+
+	// act
+	result := rec.Trace().Find("Ticker ticked")
+
+	// assert
+	test.That(t, result).Equals(ScheduleTrace{
+		{At: 1500 * time.Millisecond, Op: "tick", Kind: "Ticker", ID: 2},
+		{At: 3 * time.Second, Op: "tick", Kind: "Ticker", ID: 2},
+	})
+}
+
+func TestAssertOrder(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		patterns []string
+		errors   []string
+	}{
+		{scenario: "no patterns"},
+		{scenario: "in order", patterns: []string{"timerB fired", "ticked", "timerA fired", "ticked"}},
+		{scenario: "out of order",
+			patterns: []string{"timerA fired", "timerB fired"},
+			errors:   []string{`no event matching "timerB fired" recorded after +2s: fire: Timer "timerA" (#0)`},
+		},
+		{scenario: "not recorded",
+			patterns: []string{"timerC fired"},
+			errors:   []string{`no event matching "timerC fired" recorded`},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			tb := &fakeTB{}
+
+			// act
+			result := AssertOrder(tb, orderingRecorder(), tc.patterns...)
+
+			// assert
+			test.That(t, result).Equals(len(tc.errors) == 0)
+			test.That(t, tb.errors).Equals(tc.errors)
+		})
+	}
+}
+
+func TestAssertHappenedBefore(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      func(testing.TB, *ScheduleRecorder, string, string) bool
+		pattern  string
+		other    string
+		errors   []string
+	}{
+		{scenario: "before", sut: AssertHappenedBefore, pattern: "timerB fired", other: "timerA fired"},
+		{scenario: "not before", sut: AssertHappenedBefore, pattern: "timerA fired", other: "timerB fired",
+			errors: []string{`"timerA fired" did not happen before "timerB fired": +1s: fire: Timer "timerB" (#1) was recorded first`},
+		},
+		{scenario: "same event", sut: AssertHappenedBefore, pattern: "timerA fired", other: "timerA",
+			errors: []string{`"timerA fired" did not happen before "timerA": +0s: new: Timer "timerA" (#0), 2s was recorded first`},
+		},
+		{scenario: "not recorded", sut: AssertHappenedBefore, pattern: "timerC fired", other: "timerA fired",
+			errors: []string{`no event matching "timerC fired" recorded`},
+		},
+		{scenario: "other not recorded", sut: AssertHappenedBefore, pattern: "timerA fired", other: "timerC fired",
+			errors: []string{`no event matching "timerC fired" recorded`},
+		},
+		{scenario: "after", sut: AssertHappenedAfter, pattern: "timerA fired", other: "timerB fired"},
+		{scenario: "not after", sut: AssertHappenedAfter, pattern: "timerB fired", other: "timerA fired",
+			errors: []string{`"timerA fired" did not happen before "timerB fired": +1s: fire: Timer "timerB" (#1) was recorded first`},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			tb := &fakeTB{}
+
+			// act
+			result := tc.sut(tb, orderingRecorder(), tc.pattern, tc.other)
+
+			// assert
+			test.That(t, result).Equals(len(tc.errors) == 0)
+			test.That(t, tb.errors).Equals(tc.errors)
+		})
+	}
+}
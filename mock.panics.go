@@ -0,0 +1,113 @@
+package time
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+)
+
+// CallbackPanic describes a panic in a function called by a timer of a mock
+// clock (created by AfterFunc, or for a context deadline or timeout),
+// recovered by a clock created with the WithPanicRecovery option.
+type CallbackPanic struct {
+	// Timer identifies the timer that called the function.
+	Timer TimerInfo
+
+	// At is the time of the clock at which the timer fired.
+	At time.Time
+
+	// Value is the value with which the function panicked.
+	Value any
+
+	// Stack is the call stack of the goroutine at which the function
+	// panicked.
+	Stack string
+}
+
+// Error returns a description of the panic, identifying the timer that called
+// the function.
+func (p CallbackPanic) Error() string {
+	return fmt.Sprintf("mock clock: panic in %s at %s: %v", p.Timer.describe(), p.At.Format(time.RFC3339Nano), p.Value)
+}
+
+// Unwrap returns the value with which the function panicked, if it is an
+// error, so that the error may be identified using errors.Is or errors.As.
+func (p CallbackPanic) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// PanicHandler is a function that is called with a CallbackPanic when a mock
+// clock created with the WithPanicRecovery option recovers a panic.
+//
+// The handler is called on the goroutine of the function that panicked.
+type PanicHandler func(CallbackPanic)
+
+// FailOnPanic returns a PanicHandler that fails a test, reporting the panic
+// (and the call stack at which it occurred) in the log of the test.
+func FailOnPanic(t testing.TB) PanicHandler {
+	return func(p CallbackPanic) {
+		t.Errorf("%s\n%s", p, p.Stack)
+	}
+}
+
+// panicRecovery holds the configuration of panic recovery on a mock clock
+// and the panics that have been recovered.
+type panicRecovery struct {
+	mu        sync.Mutex
+	handlers  []PanicHandler
+	recovered []error
+}
+
+// Err returns an error joining each CallbackPanic recovered by the clock (see:
+// WithPanicRecovery), or nil if no panics have been recovered.
+func (m *mockClock) Err() error {
+	if m.panics == nil {
+		return nil
+	}
+
+	m.panics.mu.Lock()
+	defer m.panics.mu.Unlock()
+
+	return errors.Join(m.panics.recovered...)
+}
+
+// recoverPanic recovers a panic in the function of a timer that fired at a
+// given time, if panic recovery is enabled, recording the panic and calling
+// any handlers.  It must be deferred by the goroutine calling the function,
+// with the panic recovery of the clock (nil if not enabled) obtained while the
+// clock was locked.
+//
+// If panic recovery is not enabled a panic is not recovered.
+func (m *mockClock) recoverPanic(t *timer, at time.Time, recovery *panicRecovery) {
+	if recovery == nil {
+		return
+	}
+
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	p := CallbackPanic{
+		At:    at,
+		Value: r,
+		Stack: string(debug.Stack()),
+	}
+	m.withLock(func(m *mockClock) {
+		p.Timer = t.info()
+		m.tracef(p.At, "panic: %s: %v", p.Timer.describe(), r)
+	})
+
+	recovery.mu.Lock()
+	recovery.recovered = append(recovery.recovered, p)
+	handlers := recovery.handlers
+	recovery.mu.Unlock()
+
+	for _, fn := range handlers {
+		fn(p)
+	}
+}
@@ -0,0 +1,61 @@
+package time
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a panic in a function called by a timer is recovered, recorded
+// and reported to handlers, without preventing other timers from firing.
+func TestWithPanicRecovery(t *testing.T) {
+	// arrange
+	var (
+		errPanic  = errors.New("callback failed")
+		tb        = &fakeTB{}
+		recovered = make(chan CallbackPanic, 1)
+		clock     = NewMockClock(WithPanicRecovery(FailOnPanic(tb), func(p CallbackPanic) { recovered <- p }))
+		fired     = make(chan struct{})
+	)
+	_ = clock.NewTimerNamed(time.Hour, "unused")
+	clock.AfterFunc(time.Second, func() { panic(errPanic) })
+	clock.AfterFunc(2*time.Second, func() { close(fired) })
+
+	// act
+	clock.AdvanceBy(2 * time.Second)
+	p := <-recovered
+	<-fired
+
+	// assert
+	test.That(t, p.Timer.ID).Equals(1)
+	test.That(t, p.At).Equals(time.Unix(1, 0).UTC())
+	test.That(t, p.Error()).Equals("mock clock: panic in AfterFunc #1 at 1970-01-01T00:00:01Z: callback failed")
+	test.Error(t, clock.Err()).Is(errPanic)
+	test.That(t, len(tb.errors)).Equals(1)
+	test.IsTrue(t, strings.HasPrefix(tb.errors[0], p.Error()+"\n"), "reports stack")
+}
+
+func TestMockClock_Err(t *testing.T) {
+	// arrange
+	clock := NewMockClock(WithPanicRecovery())
+	done := make(chan struct{})
+	clock.AfterFunc(time.Second, func() { defer close(done); panic("not an error") })
+
+	// act
+	before := clock.Err()
+	clock.AdvanceBy(time.Second)
+	<-done
+	waitFor(func() bool { return clock.Err() != nil })
+
+	// assert
+	test.Error(t, before).IsNil()
+	test.Error(t, NewMockClock().Err()).IsNil()
+
+	p := CallbackPanic{}
+	test.IsTrue(t, errors.As(clock.Err(), &p), "is a CallbackPanic")
+	test.That(t, p.Value).Equals(any("not an error"))
+	test.Error(t, p.Unwrap()).IsNil()
+}
@@ -0,0 +1,84 @@
+package time
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// ProgressReporter is the interface through which a mock clock reports the
+// progress of an advance of the clock when created with the WithProgress
+// option.
+//
+// Progress is called with the time reached by the advance, the time to which
+// the clock is being advanced and the number of timer and ticker events fired
+// by the advance so far.  If Progress returns an error the advance is aborted:
+// the clock is left at the time reached and AdvanceTo (or AdvanceBy) panics
+// with ErrAdvanceAborted, wrapping the error.
+type ProgressReporter interface {
+	Progress(current, target time.Time, firedEvents int) error
+}
+
+// ProgressFunc is a function that implements ProgressReporter.
+type ProgressFunc func(current, target time.Time, firedEvents int) error
+
+// Progress calls the function.
+func (fn ProgressFunc) Progress(current, target time.Time, firedEvents int) error {
+	return fn(current, target, firedEvents)
+}
+
+// ProgressWriter returns a ProgressReporter that writes the progress of each
+// report to the given io.Writer, terminated by a newline.  The reporter never
+// aborts an advance.
+func ProgressWriter(w io.Writer) ProgressReporter {
+	return progressWriter{w}
+}
+
+type progressWriter struct {
+	io.Writer
+}
+
+func (w progressWriter) Progress(current, target time.Time, firedEvents int) error {
+	_, _ = fmt.Fprintf(w, "mock clock [%s]: advancing to %s: %d events fired (%s remaining)\n",
+		current.Format(time.RFC3339Nano),
+		target.Format(time.RFC3339Nano),
+		firedEvents,
+		target.Sub(current),
+	)
+	return nil
+}
+
+// progressReporting holds the configuration of progress reporting on a mock
+// clock.
+type progressReporting struct {
+	every    int
+	reporter ProgressReporter
+}
+
+// reportProgress reports the progress of an advance to a given target, having
+// fired a number of events, the most recent of which was due at a given time.
+// A report is made only after every n events (as configured by WithProgress).
+//
+// If the reporter aborts the advance the clock is moved to the time reached
+// and the method panics with ErrAdvanceAborted.
+func (m *mockClock) reportProgress(due, target time.Time, fired int) {
+	if m.progress.reporter == nil || fired%m.progress.every != 0 {
+		return
+	}
+
+	current := eval(m, func() time.Time {
+		if due.After(m.now) {
+			return due.In(m.loc)
+		}
+		return m.now
+	})
+
+	if err := m.progress.reporter.Progress(current, target, fired); err != nil {
+		m.withLock(func(m *mockClock) {
+			m.advanceNowTo(current)
+			m.updated = time.Now()
+			m.tracef(m.now, "advance aborted: %v", err)
+		})
+		panic(fmt.Errorf("%w: %w", ErrAdvanceAborted, err))
+	}
+}
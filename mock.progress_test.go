@@ -0,0 +1,53 @@
+package time
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestClockOption_WithProgress(t *testing.T) {
+	// arrange
+	buf := &bytes.Buffer{}
+	clock := NewMockClock(Yielding(0), WithProgress(2, ProgressWriter(buf)))
+	for i := 1; i <= 5; i++ {
+		_ = clock.AfterFunc(time.Duration(i)*time.Second, func() {})
+	}
+
+	// act
+	clock.AdvanceBy(10 * time.Second)
+
+	// assert
+	test.That(t, buf.String()).Equals(
+		"mock clock [1970-01-01T00:00:02Z]: advancing to 1970-01-01T00:00:10Z: 2 events fired (8s remaining)\n" +
+			"mock clock [1970-01-01T00:00:04Z]: advancing to 1970-01-01T00:00:10Z: 4 events fired (6s remaining)\n",
+	)
+}
+
+func TestClockOption_WithProgress_Abort(t *testing.T) {
+	// arrange
+	errRunaway := errors.New("runaway")
+	reports := 0
+	clock := NewMockClock(Yielding(0), WithProgress(0, ProgressFunc(func(current, target time.Time, fired int) error {
+		reports++
+		if fired == 3 {
+			return errRunaway
+		}
+		return nil
+	})))
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	// act
+	func() {
+		defer test.ExpectPanic(ErrAdvanceAborted).Assert(t)
+		clock.AdvanceBy(time.Hour)
+	}()
+
+	// assert
+	test.That(t, reports).Equals(3)
+	test.That(t, clock.Now()).Equals(time.Unix(3, 0).UTC())
+}
@@ -0,0 +1,83 @@
+package time
+
+import (
+	"time"
+)
+
+const (
+	// quiescencePoll is the interval (in real-time) at which the number of
+	// pending deliveries is polled while waiting for a clock to settle
+	quiescencePoll = time.Millisecond
+
+	// quiescenceWindow is the duration (in real-time) for which the number of
+	// pending deliveries must be unchanged for a clock to be considered settled
+	// when there are deliveries that are not being received
+	quiescenceWindow = 10 * time.Millisecond
+)
+
+// AdvanceUntilQuiescent advances the clock to the time of each timer or ticker
+// in turn, allowing the times or functions of those that fire to be received
+// or to return, until no timers or tickers remain active or the clock has been
+// advanced by a maximum duration.
+func (m *mockClock) AdvanceUntilQuiescent(maxVirtual time.Duration) bool {
+	limit := eval(m, func() time.Time { return m.now.Add(maxVirtual) })
+	m.tracefNow("advance until quiescent (limit %s)", limit.Format(time.RFC3339Nano))
+
+	// a deadline may be earlier than the current time of the clock (a timer
+	// may already be due, or the clock advanced by the delivery of a postponed
+	// tick) so the clock is advanced to no earlier than its current time
+	notBefore := func(t time.Time) time.Time {
+		return eval(m, func() time.Time { return MaxTime(t, m.now) })
+	}
+
+	for {
+		m.settle()
+
+		next, ok := m.nextDeadline()
+		switch {
+		case !ok:
+			m.tracefNow("quiescent")
+			return true
+		case next.After(limit):
+			m.AdvanceTo(notBefore(limit))
+			m.tracefNow("not quiescent: next due at %s", next.Format(time.RFC3339Nano))
+			return false
+		}
+		m.AdvanceTo(notBefore(next))
+	}
+}
+
+// settle waits (in real-time) until there are no pending deliveries for any
+// timer or ticker, or until the number of pending deliveries has been unchanged
+// for the quiescence window (a reader may have stopped reading from a ticker,
+// for example).
+func (m *mockClock) settle() {
+	last, unchanged := -1, time.Duration(0)
+	for {
+		n := eval(m, m.pendingDeliveries)
+		if n == 0 {
+			return
+		}
+		if n == last {
+			if unchanged += quiescencePoll; unchanged >= quiescenceWindow {
+				return
+			}
+		} else {
+			last, unchanged = n, 0
+		}
+		time.Sleep(quiescencePoll)
+	}
+}
+
+// pendingDeliveries returns the total number of times that timers and tickers
+// have fired without the time being received or the function returning.
+//
+// This method is not thread-safe and should only be called while the clock
+// is locked.
+func (m *mockClock) pendingDeliveries() int {
+	n := 0
+	for _, t := range m.timers() {
+		n += t.Pending
+	}
+	return n
+}
@@ -0,0 +1,85 @@
+package time
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a chain of timers, each created when the previous one fires,
+// is run to completion.
+func TestMock_AdvanceUntilQuiescent(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	var steps atomic.Int32
+	_ = clock.AfterFunc(time.Second, func() {
+		steps.Add(1)
+		_ = clock.AfterFunc(2*time.Second, func() {
+			steps.Add(1)
+			timer := clock.NewTimer(3 * time.Second)
+			go func() {
+				<-timer.C
+				steps.Add(1)
+			}()
+		})
+	})
+
+	// act
+	result := clock.AdvanceUntilQuiescent(time.Minute)
+
+	// assert
+	test.IsTrue(t, result)
+	test.That(t, clock.Now()).Equals(time.Unix(6, 0).UTC())
+	test.IsTrue(t, waitFor(func() bool { return steps.Load() == 3 }))
+}
+
+func TestMock_AdvanceUntilQuiescent_NoTimers(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	// act
+	result := clock.AdvanceUntilQuiescent(time.Minute)
+
+	// assert
+	test.IsTrue(t, result)
+	test.That(t, clock.Now()).Equals(time.Unix(0, 0).UTC())
+}
+
+// Tests that a clock with an active ticker is advanced by the maximum duration
+// and is not quiescent, even if the ticks are not being read.
+func TestMock_AdvanceUntilQuiescent_Ticker(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	ticker := clock.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	// act
+	result := clock.AdvanceUntilQuiescent(time.Minute)
+
+	// assert
+	test.IsFalse(t, result)
+	test.That(t, clock.Now()).Equals(time.Unix(60, 0).UTC())
+}
+
+// Tests that a timer that is already due when the clock is advanced is fired
+// without attempting to move the clock back to the time of the timer.
+func TestMock_AdvanceUntilQuiescent_AlreadyDue(t *testing.T) {
+	// arrange
+	clock := NewMockClock(WithSpeed(1000), StartRunning())
+	var fired atomic.Bool
+	_ = clock.AfterFunc(time.Second, func() { fired.Store(true) })
+
+	// a running clock advances without firing timers until it is moved
+	time.Sleep(5 * time.Millisecond)
+	due := clock.Now()
+
+	// act
+	result := clock.AdvanceUntilQuiescent(time.Minute)
+
+	// assert
+	test.IsTrue(t, result)
+	test.IsTrue(t, due.After(time.Unix(1, 0)), "timer already due")
+	test.IsTrue(t, waitFor(fired.Load), "timer fired")
+}
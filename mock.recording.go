@@ -0,0 +1,243 @@
+package time
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ScheduleEvent is an entry in a ScheduleTrace, recording an operation on a
+// timer or ticker of a mock clock.
+type ScheduleEvent struct {
+	// At is the (mock) time at which the operation occurred, relative to the
+	// initial time of the clock.
+	At time.Duration
+
+	// Op identifies the operation: "new", "reset", "stop", "pause", "fire"
+	// (for a timer), "tick" or "drop" (for a ticker; see: TickerPolicy).
+	Op string
+
+	// Kind identifies the type of timer: "Timer", "AfterFunc" or "Ticker".
+	Kind string
+
+	// ID is the id of the timer or ticker.
+	ID int
+
+	// Name is the name of the timer or ticker, if any.
+	Name string
+
+	// Duration is the duration (or interval) with which a timer or ticker
+	// was created or reset, or the duration remaining when a timer was
+	// paused; zero for other operations.
+	Duration time.Duration
+}
+
+// scheduleEventJSON is the JSON representation of a ScheduleEvent, with
+// durations expressed as strings so that golden files are easily read.
+type scheduleEventJSON struct {
+	At       string `json:"at"`
+	Op       string `json:"op"`
+	Kind     string `json:"kind"`
+	ID       int    `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ScheduleEvent) MarshalJSON() ([]byte, error) {
+	v := scheduleEventJSON{
+		At:   e.At.String(),
+		Op:   e.Op,
+		Kind: e.Kind,
+		ID:   e.ID,
+		Name: e.Name,
+	}
+	if e.Duration != 0 {
+		v.Duration = e.Duration.String()
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ScheduleEvent) UnmarshalJSON(b []byte) error {
+	v := scheduleEventJSON{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	at, err := time.ParseDuration(v.At)
+	if err != nil {
+		return err
+	}
+	var d time.Duration
+	if v.Duration != "" {
+		if d, err = time.ParseDuration(v.Duration); err != nil {
+			return err
+		}
+	}
+
+	*e = ScheduleEvent{At: at, Op: v.Op, Kind: v.Kind, ID: v.ID, Name: v.Name, Duration: d}
+	return nil
+}
+
+// String returns a single line description of the event.
+func (e ScheduleEvent) String() string {
+	info := TimerInfo{ID: e.ID, Name: e.Name, Kind: e.Kind}
+	s := fmt.Sprintf("+%s: %s: %s", e.At, e.Op, info.describe())
+	if e.Duration != 0 {
+		s += ", " + e.Duration.String()
+	}
+	return s
+}
+
+// ScheduleTrace is the sequence of operations on the timers and tickers of a
+// mock clock, recorded by a ScheduleRecorder.
+//
+// A trace may be marshalled to (and unmarshalled from) JSON, and compared
+// with a previously recorded "golden" trace using AssertGoldenSchedule.
+type ScheduleTrace []ScheduleEvent
+
+// Diff compares the trace with a given (expected) trace, returning a
+// description of each difference or nil if the traces match.
+//
+// Events are compared in order; the times and durations of corresponding
+// events match if they differ by no more than the given tolerance.  All
+// other fields must match exactly.
+func (tr ScheduleTrace) Diff(want ScheduleTrace, tolerance time.Duration) []string {
+	within := func(a, b time.Duration) bool {
+		d := a - b
+		return d <= tolerance && -d <= tolerance
+	}
+
+	var diffs []string
+	for i := range max(len(tr), len(want)) {
+		switch {
+		case i >= len(tr):
+			diffs = append(diffs, fmt.Sprintf("event %d: missing: %s", i, want[i]))
+		case i >= len(want):
+			diffs = append(diffs, fmt.Sprintf("event %d: unexpected: %s", i, tr[i]))
+		default:
+			got, wanted := tr[i], want[i]
+			if got.Op != wanted.Op || got.Kind != wanted.Kind || got.ID != wanted.ID || got.Name != wanted.Name ||
+				!within(got.At, wanted.At) || !within(got.Duration, wanted.Duration) {
+				diffs = append(diffs, fmt.Sprintf("event %d: got %s, wanted %s", i, got, wanted))
+			}
+		}
+	}
+	return diffs
+}
+
+// LoadScheduleTrace reads a ScheduleTrace from a JSON file.
+func LoadScheduleTrace(path string) (ScheduleTrace, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := ScheduleTrace{}
+	if err := json.Unmarshal(b, &tr); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tr, nil
+}
+
+// Save writes the trace to a JSON file, creating any directories in the
+// path that do not already exist.
+func (tr ScheduleTrace) Save(path string) error {
+	b, err := json.MarshalIndent(tr, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// AssertGoldenSchedule verifies that a trace matches the golden trace in a
+// given file (within a tolerance; see ScheduleTrace.Diff), failing the test
+// with a description of each difference if not.
+//
+// If the golden file does not exist it is created from the trace and the
+// assertion passes; to update a golden file after an intentional change
+// in behaviour, delete the file and re-run the test.
+func AssertGoldenSchedule(t testing.TB, tr ScheduleTrace, path string, tolerance time.Duration) bool {
+	t.Helper()
+
+	want, err := LoadScheduleTrace(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := tr.Save(path); err != nil {
+			t.Errorf("writing golden schedule: %s", err)
+			return false
+		}
+		t.Logf("golden schedule written: %s", path)
+		return true
+	}
+	if err != nil {
+		t.Errorf("reading golden schedule: %s", err)
+		return false
+	}
+
+	diffs := tr.Diff(want, tolerance)
+	for _, diff := range diffs {
+		t.Errorf("schedule does not match %s: %s", path, diff)
+	}
+	return len(diffs) == 0
+}
+
+// ScheduleRecorder records the operations on the timers and tickers of a mock
+// clock created with the WithRecorder option.
+type ScheduleRecorder struct {
+	mu     sync.Mutex
+	origin time.Time
+	events ScheduleTrace
+}
+
+// Trace returns the operations that have been recorded.
+func (r *ScheduleRecorder) Trace() ScheduleTrace {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append(ScheduleTrace{}, r.events...)
+}
+
+// record adds an event to the recording of the clock, if recording is enabled.
+//
+// This method does not lock the clock; the time of the operation and the
+// information describing the timer are provided by the caller.
+func (m *mockClock) record(at time.Time, op string, info TimerInfo, d time.Duration) {
+	r := m.recorder
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, ScheduleEvent{
+		At:       at.Sub(r.origin),
+		Op:       op,
+		Kind:     info.Kind,
+		ID:       info.ID,
+		Name:     info.Name,
+		Duration: d,
+	})
+}
+
+// recordNow adds an event to the recording of the clock, if recording is
+// enabled, at the current time of the clock.
+//
+// This method obtains a read lock on the clock and must not be called while
+// the clock is locked.
+func (m *mockClock) recordNow(op string, info TimerInfo, d time.Duration) {
+	if m.recorder == nil {
+		return
+	}
+	m.record(eval(m, func() time.Time { return m.now }), op, info, d)
+}
@@ -0,0 +1,136 @@
+package time
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that operations on timers and tickers are recorded relative to the
+// initial time of the clock.
+func TestMock_WithRecorder(t *testing.T) {
+	// arrange
+	var (
+		rec   = &ScheduleRecorder{}
+		clock = NewMockClock(WithRecorder(rec), AtTime(time.Unix(100, 0)))
+	)
+
+	// act
+	timer := clock.NewTimerNamed(time.Second, "retry")
+	ticker := clock.NewTicker(2 * time.Second)
+	clock.AdvanceBy(2 * time.Second)
+	<-timer.C
+	<-ticker.C
+	timer.Reset(3 * time.Second)
+	ticker.Stop()
+
+	// assert
+	test.That(t, rec.Trace()).Equals(ScheduleTrace{
+		{At: 0, Op: "new", Kind: "Timer", ID: 0, Name: "retry", Duration: time.Second},
+		{At: 0, Op: "new", Kind: "Ticker", ID: 1, Duration: 2 * time.Second},
+		{At: time.Second, Op: "fire", Kind: "Timer", ID: 0, Name: "retry"},
+		{At: 2 * time.Second, Op: "tick", Kind: "Ticker", ID: 1},
+		{At: 2 * time.Second, Op: "reset", Kind: "Timer", ID: 0, Name: "retry", Duration: 3 * time.Second},
+		{At: 2 * time.Second, Op: "stop", Kind: "Ticker", ID: 1},
+	})
+}
+
+func TestScheduleTrace_Diff(t *testing.T) {
+	want := ScheduleTrace{
+		{At: 0, Op: "new", Kind: "Timer", ID: 0, Duration: time.Second},
+		{At: time.Second, Op: "fire", Kind: "Timer", ID: 0},
+	}
+
+	testcases := []struct {
+		scenario string
+		sut      ScheduleTrace
+		result   []string
+	}{
+		{scenario: "identical",
+			sut: want,
+		},
+		{scenario: "within tolerance",
+			sut: ScheduleTrace{
+				{At: 0, Op: "new", Kind: "Timer", ID: 0, Duration: time.Second + time.Millisecond},
+				{At: time.Second - time.Millisecond, Op: "fire", Kind: "Timer", ID: 0},
+			},
+		},
+		{scenario: "outside tolerance",
+			sut: ScheduleTrace{
+				{At: 0, Op: "new", Kind: "Timer", ID: 0, Duration: 2 * time.Second},
+				{At: time.Second, Op: "fire", Kind: "Timer", ID: 0},
+			},
+			result: []string{"event 0: got +0s: new: Timer #0, 2s, wanted +0s: new: Timer #0, 1s"},
+		},
+		{scenario: "missing event",
+			sut:    want[:1],
+			result: []string{"event 1: missing: +1s: fire: Timer #0"},
+		},
+		{scenario: "unexpected event",
+			sut:    append(want, ScheduleEvent{At: time.Second, Op: "new", Kind: "Ticker", ID: 1, Name: "poll", Duration: time.Second}),
+			result: []string{`event 2: unexpected: +1s: new: Ticker "poll" (#1), 1s`},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.That(t, tc.sut.Diff(want, time.Millisecond)).Equals(tc.result)
+		})
+	}
+}
+
+// Tests that a trace survives a round trip through JSON.
+func TestScheduleTrace_JSON(t *testing.T) {
+	// arrange
+	sut := ScheduleTrace{
+		{At: 1500 * time.Millisecond, Op: "new", Kind: "AfterFunc", ID: 3, Name: "retry", Duration: time.Minute},
+		{At: time.Minute, Op: "fire", Kind: "AfterFunc", ID: 3, Name: "retry"},
+	}
+
+	// act
+	b, err := json.Marshal(sut)
+	result := ScheduleTrace{}
+	test.Error(t, err).IsNil()
+	test.Error(t, json.Unmarshal(b, &result)).IsNil()
+
+	// assert
+	test.That(t, string(b)).Equals(`[{"at":"1.5s","op":"new","kind":"AfterFunc","id":3,"name":"retry","duration":"1m0s"},` +
+		`{"at":"1m0s","op":"fire","kind":"AfterFunc","id":3,"name":"retry"}]`)
+	test.That(t, result).Equals(sut)
+}
+
+// Tests that a golden schedule is written if it does not exist and that a
+// later trace is compared with it.
+func TestAssertGoldenSchedule(t *testing.T) {
+	// arrange
+	var (
+		path   = filepath.Join(t.TempDir(), "testdata", "schedule.golden.json")
+		golden = ScheduleTrace{{At: 0, Op: "new", Kind: "Timer", ID: 0, Duration: time.Second}}
+	)
+
+	// act
+	written := AssertGoldenSchedule(t, golden, path, 0)
+	loaded, err := LoadScheduleTrace(path)
+
+	// assert
+	test.IsTrue(t, written)
+	test.Error(t, err).IsNil()
+	test.That(t, loaded).Equals(golden)
+
+	t.Run("matching", func(t *testing.T) {
+		tb := &fakeTB{}
+		test.IsTrue(t, AssertGoldenSchedule(tb, golden, path, 0))
+		test.That(t, len(tb.errors)).Equals(0)
+	})
+
+	t.Run("not matching", func(t *testing.T) {
+		tb := &fakeTB{}
+		changed := ScheduleTrace{{At: 0, Op: "new", Kind: "Timer", ID: 0, Duration: 2 * time.Second}}
+		test.IsFalse(t, AssertGoldenSchedule(tb, changed, path, 0))
+		test.That(t, tb.errors).Equals([]string{
+			"schedule does not match " + path + ": event 0: got +0s: new: Timer #0, 2s, wanted +0s: new: Timer #0, 1s",
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package time
+
+import (
+	"sync"
+)
+
+// tickerShardCount is the number of shards in which the inactive timers and
+// tickers of a mock clock are held.
+const tickerShardCount = 16
+
+// tickerShards holds the inactive timers and tickers of a mock clock, sharded
+// by id.  Each shard has its own lock, so that the bookkeeping of inactive
+// timers and tickers (in particular, the removal of those that are abandoned,
+// by finalizers) does not contend for the lock of the clock.
+type tickerShards [tickerShardCount]tickerShard
+
+// tickerShard is a shard of the inactive timers and tickers of a mock clock.
+type tickerShard struct {
+	sync.Mutex
+	tickers map[int]tickable
+}
+
+// shard returns the shard holding the ticker with a given id.
+func (s *tickerShards) shard(id int) *tickerShard {
+	return &s[id%tickerShardCount]
+}
+
+// add adds a ticker to its shard unless the ticker has been abandoned.
+func (s *tickerShards) add(t tickable) {
+	shard := s.shard(t.id())
+	shard.Lock()
+	defer shard.Unlock()
+
+	if t.abandoned() {
+		return
+	}
+	if shard.tickers == nil {
+		shard.tickers = map[int]tickable{}
+	}
+	shard.tickers[t.id()] = t
+}
+
+// abandon marks a ticker as abandoned (setting the flag referenced by a given
+// pointer) and removes it from its shard.
+func (s *tickerShards) abandon(id int, isAbandoned *bool) {
+	shard := s.shard(id)
+	shard.Lock()
+	defer shard.Unlock()
+
+	*isAbandoned = true
+	delete(shard.tickers, id)
+}
+
+// all returns the tickers in all shards, in no particular order.
+func (s *tickerShards) all() tickables {
+	var result tickables
+	for i := range s {
+		shard := &s[i]
+		shard.Lock()
+		for _, t := range shard.tickers {
+			result = append(result, t)
+		}
+		shard.Unlock()
+	}
+	return result
+}
+
+// clear removes all tickers from all shards.
+func (s *tickerShards) clear() {
+	for i := range s {
+		shard := &s[i]
+		shard.Lock()
+		shard.tickers = nil
+		shard.Unlock()
+	}
+}
+
+// take removes the ticker with a given id from its shard, returning the
+// ticker or nil if there is no ticker with that id.
+func (s *tickerShards) take(id int) tickable {
+	shard := s.shard(id)
+	shard.Lock()
+	defer shard.Unlock()
+
+	t := shard.tickers[id]
+	delete(shard.tickers, id)
+	return t
+}
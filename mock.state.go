@@ -0,0 +1,95 @@
+package time
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MockClockState describes the state of a mock clock.
+//
+// The state of a mock clock may be obtained using the State() method of the
+// clock and may be marshalled to (and unmarshalled from) JSON, allowing the
+// state of a clock to be persisted or attached to a test report.  A clock
+// may be created from a previously obtained state using the FromState option.
+type MockClockState struct {
+	// CreatedAt is the mocked time at which the clock was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Now is the current time of the clock.
+	Now time.Time `json:"now"`
+
+	// Location is the name of the location of the clock.
+	Location string `json:"location"`
+
+	// Running is true if the clock is in a running state.
+	Running bool `json:"running"`
+
+	// DropsTicks is true if the clock drops ticks (see: DropsTicks).
+	DropsTicks bool `json:"dropsTicks,omitempty"`
+
+	// CoalescesTicks is true if the clock coalesces ticks (see: CoalescesTicks).
+	CoalescesTicks bool `json:"coalescesTicks,omitempty"`
+
+	// Timers describes the timers and tickers created by the clock.
+	//
+	// This is provided for information only; timers and tickers are not
+	// re-created when a clock is created from a state.
+	Timers []TimerInfo `json:"timers,omitempty"`
+}
+
+// State returns the current state of the clock.
+func (m *mockClock) State() MockClockState {
+	running := m.IsRunning()
+	return eval(m, func() MockClockState {
+		return MockClockState{
+			CreatedAt:      m.createdAt,
+			Now:            m.now,
+			Location:       m.now.Location().String(),
+			Running:        running,
+			DropsTicks:     m.dropsTicks,
+			CoalescesTicks: m.coalescesTicks,
+			Timers:         m.timers(),
+		}
+	})
+}
+
+// MarshalJSON implements json.Marshaler, marshalling the current state of the clock.
+func (m *mockClock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.State())
+}
+
+// FromState sets the initial state of the mock clock from a given state, as
+// previously obtained from the State() method of a clock or unmarshalled
+// from JSON.
+//
+// The created and current times, location and running state of the clock and
+// whether it drops or coalesces ticks are restored from the state.  Timers and tickers in
+// the state are not re-created.
+//
+// If the named location of the state cannot be loaded, a fixed zone of that
+// name with the offset of the current time of the state is used.
+//
+// Any options specified after FromState will override the restored state.
+func FromState(state MockClockState) ClockOption {
+	return func(m *mockClock) {
+		loc, err := time.LoadLocation(state.Location)
+		if err != nil {
+			_, offset := state.Now.Zone()
+			loc = time.FixedZone(state.Location, offset)
+		}
+
+		m.createdAt = state.CreatedAt
+		m.loc = loc
+		m.setNow(state.Now.In(loc))
+		m.dropsTicks = state.DropsTicks
+		m.coalescesTicks = state.CoalescesTicks
+		m.updated = time.Now()
+
+		switch {
+		case state.Running && !m.IsRunning():
+			m.Start()
+		case !state.Running && m.IsRunning():
+			m.Stop()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package time
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that State returns the current state of a clock.
+func TestMock_State(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Unix(100, 0)), DropsTicks())
+	_ = clock.NewTimerNamed(time.Second, "rebalance")
+	clock.AdvanceBy(10 * time.Second)
+
+	// act
+	state := clock.State()
+
+	// assert
+	test.That(t, state.CreatedAt).Equals(time.Unix(0, 0))
+	test.That(t, state.Now).Equals(time.Unix(110, 0).UTC())
+	test.That(t, state.Location).Equals("UTC")
+	test.IsFalse(t, state.Running)
+	test.IsTrue(t, state.DropsTicks)
+	test.That(t, len(state.Timers)).Equals(1)
+	test.That(t, state.Timers[0].Name).Equals("rebalance")
+}
+
+// Tests that the state of a clock marshalled to JSON may be used to
+// create a new clock in the same state.
+func TestMock_MarshalJSON(t *testing.T) {
+	// arrange
+	loc, err := time.LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+	clock := NewMockClock(AtTime(time.Date(2024, 6, 1, 12, 0, 0, 0, loc)), InLocation(loc), StartRunning())
+
+	// act
+	data, err := json.Marshal(clock)
+	test.Error(t, err).IsNil()
+
+	var state MockClockState
+	err = json.Unmarshal(data, &state)
+	test.Error(t, err).IsNil()
+
+	restored := NewMockClock(FromState(state))
+
+	// assert
+	test.IsTrue(t, restored.IsRunning())
+	test.That(t, restored.Now().Location().String()).Equals("Europe/London")
+	test.IsTrue(t, restored.Since(time.Date(2024, 6, 1, 12, 0, 0, 0, loc)) >= 0)
+}
+
+// Tests that FromState restores a stopped clock and uses a fixed zone when
+// the location of the state cannot be loaded.
+func TestClockOption_FromState(t *testing.T) {
+	// arrange
+	state := MockClockState{
+		CreatedAt: time.Unix(0, 0),
+		Now:       time.Unix(3600, 0).In(time.FixedZone("Custom", 3600)),
+		Location:  "Custom",
+	}
+
+	// act
+	clock := NewMockClock(StartRunning(), FromState(state))
+
+	// assert
+	test.IsFalse(t, clock.IsRunning())
+	test.That(t, clock.Now().Unix()).Equals(int64(3600))
+	name, offset := clock.Now().Zone()
+	test.That(t, name).Equals("Custom")
+	test.That(t, offset).Equals(3600)
+	test.That(t, clock.SinceCreated()).Equals(time.Hour)
+}
@@ -0,0 +1,52 @@
+package time
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// TraceLogger is the interface through which a mock clock writes a trace of
+// its operations when created with the WithTrace option.
+//
+// The interface is satisfied by testing.T, testing.B and testing.F (or any
+// testing.TB), so that a trace is written to the log of a test.  To write
+// a trace to an io.Writer, use TraceWriter.
+type TraceLogger interface {
+	Logf(format string, args ...any)
+}
+
+// TraceWriter returns a TraceLogger that writes each entry in a trace to the
+// given io.Writer, terminated by a newline.
+func TraceWriter(w io.Writer) TraceLogger {
+	return traceWriter{w}
+}
+
+type traceWriter struct {
+	io.Writer
+}
+
+func (w traceWriter) Logf(format string, args ...any) {
+	_, _ = fmt.Fprintf(w, format+"\n", args...)
+}
+
+// tracef writes an entry to the trace of the clock, if tracing is enabled,
+// identifying the time of the clock at which the operation occurred.
+func (m *mockClock) tracef(at time.Time, format string, args ...any) {
+	if m.tracer == nil {
+		return
+	}
+	m.tracer.Logf("mock clock [%s]: "+format, append([]any{at.Format(time.RFC3339Nano)}, args...)...)
+}
+
+// tracefNow writes an entry to the trace of the clock, if tracing is enabled,
+// identifying the current time of the clock.
+//
+// This method obtains a read lock on the clock and must not be called while
+// the clock is locked.
+func (m *mockClock) tracefNow(format string, args ...any) {
+	if m.tracer == nil {
+		return
+	}
+	m.tracef(eval(m, func() time.Time { return m.now }), format, args...)
+}
@@ -0,0 +1,54 @@
+package time
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that operations on a mock clock are written to a trace.
+func TestMock_Trace(t *testing.T) {
+	// arrange
+	var (
+		buf   = &bytes.Buffer{}
+		clock = NewMockClock(WithTrace(TraceWriter(buf)))
+	)
+
+	// act
+	timer := clock.NewTimerNamed(time.Second, "rebalance")
+	ticker := clock.NewTicker(2 * time.Second)
+	clock.AdvanceBy(2 * time.Second)
+	<-timer.C
+	<-ticker.C
+	ticker.Stop()
+	_ = clock.Now()
+
+	// assert
+	test.That(t, strings.Split(strings.TrimSpace(buf.String()), "\n")).Equals([]string{
+		`mock clock [1970-01-01T00:00:00Z]: new: Timer "rebalance", duration: 1s`,
+		`mock clock [1970-01-01T00:00:00Z]: new: Ticker #1, interval: 2s`,
+		`mock clock [1970-01-01T00:00:00Z]: advance to 1970-01-01T00:00:02Z`,
+		`mock clock [1970-01-01T00:00:01Z]: fire: Timer "rebalance"`,
+		`mock clock [1970-01-01T00:00:02Z]: tick: Ticker #1`,
+		`mock clock [1970-01-01T00:00:02Z]: advanced`,
+		`mock clock [1970-01-01T00:00:02Z]: stop: Ticker #1`,
+		`mock clock [1970-01-01T00:00:02Z]: Now`,
+	})
+}
+
+// Tests that a trace may be written to the log of a test.
+func TestMock_Trace_TestingT(t *testing.T) {
+	// arrange
+	clock := NewMockClock(WithTrace(t))
+
+	// act
+	timer := clock.AfterFunc(time.Second, func() {})
+	timer.Reset(2 * time.Second)
+	timer.Stop()
+
+	// assert
+	test.IsFalse(t, t.Failed())
+}
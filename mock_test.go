@@ -120,6 +120,168 @@ func TestMock_IsRunning_StoppedClock(t *testing.T) {
 	test.IsFalse(t, clock.IsRunning())
 }
 
+// Tests that a frozen running clock does not advance and that, once unfrozen,
+// it continues from the time at which it was frozen.
+func TestMock_Freeze(t *testing.T) {
+	// arrange
+	clock := NewMockClock(StartRunning())
+
+	// act
+	clock.Freeze()
+	frozen := clock.Now()
+	time.Sleep(20 * time.Millisecond)
+	stillFrozen := clock.Now()
+	clock.Unfreeze()
+
+	// assert
+	test.IsFalse(t, frozen.Before(time.Unix(0, 0)))
+	test.That(t, stillFrozen).Equals(frozen)
+	test.IsTrue(t, clock.IsRunning())
+	test.IsTrue(t, clock.Since(frozen) < 20*time.Millisecond)
+}
+
+// Tests that FreezeAt advances a frozen clock to a given time and that an
+// earlier time panics, leaving the clock frozen.
+func TestMock_FreezeAt(t *testing.T) {
+	t.Run("later time", func(t *testing.T) {
+		// arrange
+		clock := NewMockClock(StartRunning())
+		timer := clock.NewTimer(time.Hour)
+
+		// act
+		clock.FreezeAt(time.Unix(7200, 0))
+
+		// assert
+		test.That(t, clock.Now()).Equals(time.Unix(7200, 0).UTC())
+		test.IsTrue(t, (<-timer.C).Before(time.Unix(3601, 0)))
+		test.IsFalse(t, clock.IsRunning())
+	})
+
+	t.Run("earlier time", func(t *testing.T) {
+		// arrange
+		clock := NewMockClock(AtTime(time.Unix(100, 0)))
+		defer func() {
+			test.IsFalse(t, clock.IsRunning())
+			clock.Unfreeze()
+		}()
+		defer test.ExpectPanic(ErrNotADelorean).Assert(t)
+
+		// act
+		clock.FreezeAt(time.Unix(0, 0))
+	})
+}
+
+// Tests that WhileFrozen unfreezes the clock when the function panics.
+func TestMock_WhileFrozen(t *testing.T) {
+	// arrange
+	clock := NewMockClock(StartRunning())
+	running := true
+	defer func() {
+		test.IsFalse(t, running)
+		test.IsTrue(t, clock.IsRunning())
+	}()
+	defer test.ExpectPanic(ErrTimeout).Assert(t)
+
+	// act
+	clock.WhileFrozen(func() {
+		running = clock.IsRunning()
+		panic(ErrTimeout)
+	})
+}
+
+// Tests that resetting a clock discards its timers and tickers and restores
+// the configuration with which it was created, with any additional options.
+func TestMock_Close(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	timer := clock.NewTimer(time.Second)
+
+	// act
+	err := clock.Close()
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.Error(t, clock.Close()).IsNil()
+	test.That(t, clock.Timers()[0].State).Equals("stopped")
+	test.That(t, clock.Now()).Equals(time.Unix(0, 0).UTC())
+
+	t.Run("advance", func(t *testing.T) {
+		defer test.ExpectPanic(ErrClockClosed).Assert(t)
+		clock.AdvanceBy(time.Second)
+	})
+
+	t.Run("new timer", func(t *testing.T) {
+		defer test.ExpectPanic(ErrClockClosed).Assert(t)
+		_ = clock.NewTimer(time.Second)
+	})
+
+	t.Run("new ticker", func(t *testing.T) {
+		defer test.ExpectPanic(ErrClockClosed).Assert(t)
+		_ = clock.NewTicker(time.Second)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		// act
+		clock.Reset()
+		timer = clock.NewTimer(time.Second)
+		clock.AdvanceBy(time.Second)
+
+		// assert
+		test.That(t, (<-timer.C).Unix()).Equals(int64(1))
+	})
+}
+
+func TestMock_Reset(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Unix(100, 0)))
+	timer := clock.NewTimer(time.Second)
+	ticker := clock.NewTicker(time.Hour)
+	fired := make(chan struct{})
+	_ = clock.AfterFunc(2*time.Second, func() { close(fired) })
+	clock.ExpectTimer(time.Minute)
+	clock.AdvanceBy(2 * time.Second)
+	<-timer.C
+	<-fired
+
+	// act
+	clock.Reset(InLocation(time.FixedZone("UTC+1", 3600)))
+	ids := created(clock)
+	_ = timer.Reset(time.Second)
+	clock.AdvanceBy(2 * time.Hour)
+
+	// assert
+	test.That(t, clock.Now().Unix()).Equals(int64(7300))
+	test.That(t, clock.Now().Location().String()).Equals("UTC+1")
+	test.That(t, len(clock.Timers())).Equals(0)
+	test.IsTrue(t, clock.AssertExpectations(&fakeTB{}), "expectations discarded")
+	test.That(t, ids).Equals(3)
+	select {
+	case <-timer.C:
+		t.Error("timer fired after reset")
+	case <-ticker.C:
+		t.Error("ticker ticked after reset")
+	default:
+	}
+}
+
+// Tests that a reset clock is not advanced by the delivery of a tick that
+// occurred before the reset.
+func TestMock_Reset_PendingDelivery(t *testing.T) {
+	// arrange
+	clock := NewMockClock(Yielding(0))
+	for range 10 {
+		_ = clock.NewTimer(time.Hour)
+	}
+	clock.AdvanceBy(time.Hour)
+
+	// act
+	clock.Reset()
+	time.Sleep(time.Millisecond)
+
+	// assert
+	test.That(t, clock.Now()).Equals(time.Unix(0, 0).UTC())
+}
+
 //
 
 func TestMock_Since(t *testing.T) {
@@ -681,6 +843,130 @@ func TestMock_DropsTicks(t *testing.T) {
 	test.Value(t, cnt.Load(), "ticks").Equals(1)
 }
 
+func TestMock_CoalescesTicks(t *testing.T) {
+	// arrange: establish a mock clock with CoalescesTicks set and
+	// create a ticker to tick every 1s
+	clock := NewMockClock(CoalescesTicks())
+	ticker := clock.NewTicker(1 * time.Second)
+
+	// act: advance the clock by 10s and receive the tick
+	clock.AdvanceBy(10 * time.Second)
+	tick := <-ticker.C
+
+	// assert: the ticker should tick once, at 10s, having skipped the
+	// 9 preceding intervals; the count is reset once obtained
+	test.That(t, tick).Equals(time.Unix(10, 0).UTC())
+	test.Value(t, ticker.Skipped(), "skipped").Equals(9)
+	test.Value(t, ticker.Skipped(), "skipped (reset)").Equals(0)
+}
+
+func TestMock_CoalescesTicks_NoneSkipped(t *testing.T) {
+	// arrange
+	clock := NewMockClock(CoalescesTicks())
+	ticker := clock.NewTicker(1 * time.Second)
+
+	// act
+	clock.AdvanceBy(1 * time.Second)
+	<-ticker.C
+
+	// assert
+	test.Value(t, ticker.Skipped(), "skipped").Equals(0)
+}
+
+// Tests that intervals dropped by a clock with DropsTicks are not counted.
+func TestMock_DropsTicks_NotCounted(t *testing.T) {
+	// arrange
+	clock := NewMockClock(DropsTicks())
+	ticker := clock.NewTicker(1 * time.Second)
+
+	// act
+	clock.AdvanceBy(10 * time.Second)
+	<-ticker.C
+
+	// assert
+	test.Value(t, ticker.Skipped(), "skipped").Equals(0)
+}
+
+// Tests that ticks that cannot be received are delivered or dropped according
+// to the policy of the ticker.
+func TestMock_Ticker_WhenFull(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		policy   TickerPolicy
+		ticks    int
+		dropped  int
+	}{
+		{scenario: "default", ticks: 5},
+		{scenario: "drop", policy: DropWhenFull, ticks: 1, dropped: 4},
+		{scenario: "queue", policy: QueueWhenFull(2), ticks: 3, dropped: 2},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock()
+			ticker := clock.NewTicker(time.Second).WhenFull(tc.policy)
+			defer ticker.Stop()
+
+			// act
+			clock.AdvanceBy(5 * time.Second)
+			result := []time.Time{}
+		receive:
+			for {
+				select {
+				case tick := <-ticker.C:
+					result = append(result, tick)
+				case <-time.After(10 * time.Millisecond):
+					break receive
+				}
+			}
+
+			// assert
+			test.That(t, len(result)).Equals(tc.ticks)
+			for i, tick := range result {
+				test.That(t, tick).Equals(time.Unix(int64(i+1), 0).UTC())
+			}
+			test.That(t, ticker.Dropped()).Equals(tc.dropped)
+			test.That(t, ticker.Dropped()).Equals(0)
+		})
+	}
+}
+
+// Tests that the advance of a clock is blocked until the ticks of a ticker with
+// the BlockWhenFull policy are received.
+func TestMock_Ticker_WhenFull_Block(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	ticker := clock.NewTicker(time.Second).WhenFull(BlockWhenFull)
+	defer ticker.Stop()
+
+	// act
+	advanced := make(chan struct{})
+	go func() {
+		clock.AdvanceBy(3 * time.Second)
+		close(advanced)
+	}()
+
+	// assert
+	test.That(t, <-ticker.C).Equals(time.Unix(1, 0).UTC())
+	select {
+	case <-advanced:
+		t.Fatal("advance completed before ticks were received")
+	case <-time.After(10 * time.Millisecond):
+	}
+	test.That(t, <-ticker.C).Equals(time.Unix(2, 0).UTC())
+	test.That(t, <-ticker.C).Equals(time.Unix(3, 0).UTC())
+	<-advanced
+	test.That(t, ticker.Dropped()).Equals(0)
+}
+
+func TestQueueWhenFull_Negative(t *testing.T) {
+	// arrange
+	defer test.ExpectPanic(errNegativeQueueLength).Assert(t)
+
+	// act
+	_ = QueueWhenFull(-1)
+}
+
 func TestMock_panicIfLocked_WhenLocked(t *testing.T) {
 	// arrange: create a mock clock and lock it
 	clock := NewMockClock().(*mockClock)
@@ -690,3 +976,13 @@ func TestMock_panicIfLocked_WhenLocked(t *testing.T) {
 	// act/assert: attempt to lock the clock again (should panic)
 	clock.panicIfLocked()
 }
+
+func TestMock_NewTimer_WhenLocked(t *testing.T) {
+	// arrange: create a mock clock and lock it
+	clock := NewMockClock().(*mockClock)
+	clock.Lock()
+	defer test.ExpectPanic(errClockLocked).Assert(t)
+
+	// act/assert: create a timer (should panic rather than deadlock)
+	_ = clock.NewTimer(time.Second)
+}
@@ -0,0 +1,157 @@
+package time
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Pacer paces the issue of work (such as requests made by a load generator)
+// at a target rate, optionally ramping linearly from an initial rate, blocking
+// callers until it is time for each unit of work to be issued according to a
+// clock.
+//
+// Work is scheduled from the time of the first call to Wait; the n-th unit of
+// work is issued at the time by which n units are due at the (ramping) rate.
+// A caller that is late does not change the schedule, so that subsequent
+// callers are not delayed until the pacer has caught up.
+//
+// The zero value is an unpaced Pacer using the system clock, for which Wait
+// returns immediately.  A Pacer must not be copied after first use.
+type Pacer struct {
+	// Clock is the clock used to pace work.  If nil, the system clock is used.
+	Clock Clock
+
+	// Rate is the target rate, in units of work per second.
+	Rate float64
+
+	// StartRate is the rate at which work is issued initially, ramping
+	// linearly to the target rate over the Ramp duration.
+	StartRate float64
+
+	// Ramp is the duration over which the rate ramps from the start rate to
+	// the target rate.  If zero, work is issued at the target rate.
+	Ramp time.Duration
+
+	mu    sync.Mutex
+	start time.Time
+	n     int
+}
+
+// Wait blocks until the next unit of work is due, returning nil, or until the
+// context is done, returning the error of the context.
+//
+// If the rate is zero (or less) once any ramp is complete, no further work is
+// due and Wait blocks until the context is done.
+func (p *Pacer) Wait(ctx context.Context) error {
+	clock := p.clock()
+
+	due, ok := p.reserve(clock)
+	if !ok {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	d := clock.Until(due)
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := clock.NewTimerNamed(d, "Pacer")
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Ticks returns a channel on which the time is sent each time a unit of work
+// is due, until the context is done, when the channel is closed.
+//
+// As with a Ticker, a tick that is not received before the next is due is
+// not dropped; the pacer is blocked until it is received.
+func (p *Pacer) Ticks(ctx context.Context) <-chan time.Time {
+	ch := make(chan time.Time)
+	go func() {
+		defer close(ch)
+		for p.Wait(ctx) == nil {
+			select {
+			case ch <- p.clock().Now():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// clock returns the clock of the pacer or the system clock if none is specified.
+func (p *Pacer) clock() Clock {
+	if p.Clock == nil {
+		return SystemClock()
+	}
+	return p.Clock
+}
+
+// reserve reserves the next unit of work, returning the time at which it is
+// due and true, or false if no further work is due.
+func (p *Pacer) reserve(clock Clock) (time.Time, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.n == 0 {
+		p.start = clock.Now()
+	}
+	if p.Rate == 0 && p.StartRate == 0 {
+		p.n++
+		return p.start, true
+	}
+
+	offset, ok := p.offset(float64(p.n))
+	if !ok {
+		return time.Time{}, false
+	}
+	p.n++
+	return p.start.Add(offset), true
+}
+
+// offset returns the time after the start of the schedule at which n units
+// of work are due and true, or false if that many units are never due.
+//
+// The number of units due after t seconds is the integral of the rate:
+//
+//	s.t + (r - s).t²/2R    during a ramp of R seconds from s to r
+//	N(R) + r.(t - R)       after the ramp
+func (p *Pacer) offset(n float64) (time.Duration, bool) {
+	if n == 0 {
+		return 0, true
+	}
+
+	seconds := func(f float64) time.Duration { return time.Duration(f * float64(time.Second)) }
+
+	s, r := p.StartRate, p.Rate
+	ramp := max(p.Ramp.Seconds(), 0)
+	if ramp > 0 {
+		due := (s + r) * ramp / 2
+		if n <= due {
+			// solve a.t² + s.t - n = 0 for the smallest non-negative t, in a
+			// form that is stable when a is zero or negative
+			a := (r - s) / (2 * ramp)
+			disc := s*s + 4*a*n
+			if disc < 0 || s+math.Sqrt(disc) <= 0 {
+				return 0, false
+			}
+			return seconds(2 * n / (s + math.Sqrt(disc))), true
+		}
+		n -= due
+	}
+
+	if r <= 0 {
+		return 0, false
+	}
+	return seconds(ramp + n/r), true
+}
@@ -0,0 +1,128 @@
+package time
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestPacer_offset(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      *Pacer
+		n        float64
+		result   time.Duration
+		ok       bool
+	}{
+		{scenario: "first unit", sut: &Pacer{Rate: 10}, n: 0, result: 0, ok: true},
+		{scenario: "constant rate", sut: &Pacer{Rate: 10}, n: 5, result: 500 * time.Millisecond, ok: true},
+		{scenario: "zero rate", sut: &Pacer{StartRate: 10, Ramp: time.Second}, n: 10, ok: false},
+		{scenario: "ramp up from zero", sut: &Pacer{Rate: 20, Ramp: 10 * time.Second}, n: 25, result: 5 * time.Second, ok: true},
+		{scenario: "ramp down", sut: &Pacer{StartRate: 20, Rate: 10, Ramp: 10 * time.Second}, n: 150, result: 10 * time.Second, ok: true},
+		{scenario: "after ramp", sut: &Pacer{Rate: 20, Ramp: 10 * time.Second}, n: 120, result: 11 * time.Second, ok: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, ok := tc.sut.offset(tc.n)
+
+			// assert
+			test.That(t, ok).Equals(tc.ok)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+// Tests that callers are blocked until work is due at the target rate.
+func TestPacer_Wait(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock()
+		sut    = &Pacer{Clock: clock, Rate: 4}
+		ctx    = context.Background()
+		issued = make(chan time.Time, 3)
+	)
+	test.Error(t, sut.Wait(ctx)).IsNil()
+
+	// act
+	go func() {
+		for range 3 {
+			_ = sut.Wait(ctx)
+			issued <- clock.Now()
+		}
+	}()
+	result := []time.Time{}
+	for i := range 3 {
+		waitFor(func() bool { return created(clock) > i })
+		clock.AdvanceBy(250 * time.Millisecond)
+		result = append(result, <-issued)
+	}
+
+	// assert
+	test.That(t, result).Equals([]time.Time{
+		time.Unix(0, int64(250*time.Millisecond)).UTC(),
+		time.Unix(0, int64(500*time.Millisecond)).UTC(),
+		time.Unix(0, int64(750*time.Millisecond)).UTC(),
+	})
+}
+
+// Tests that a late caller does not delay the schedule.
+func TestPacer_Late(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = &Pacer{Clock: clock, Rate: 10}
+		ctx   = context.Background()
+	)
+	_ = sut.Wait(ctx)
+
+	// act
+	clock.AdvanceBy(time.Second)
+	for range 10 {
+		test.Error(t, sut.Wait(ctx)).IsNil()
+	}
+
+	// assert
+	test.That(t, len(clock.Timers())).Equals(0)
+}
+
+// Tests that a wait is ended when the context is done.
+func TestPacer_Wait_Cancelled(t *testing.T) {
+	// arrange
+	var (
+		sut         = &Pacer{Clock: NewMockClock(), Rate: 1}
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	_ = sut.Wait(ctx)
+	cancel()
+
+	// act
+	err := sut.Wait(ctx)
+
+	// assert
+	test.Error(t, err).Is(context.Canceled)
+}
+
+// Tests that ticks are sent when work is due.
+func TestPacer_Ticks(t *testing.T) {
+	// arrange
+	var (
+		clock       = NewMockClock()
+		sut         = &Pacer{Clock: clock, Rate: 1}
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+
+	// act
+	ticks := sut.Ticks(ctx)
+	first := <-ticks
+	waitFor(func() bool { return created(clock) > 0 })
+	clock.AdvanceBy(time.Second)
+	second := <-ticks
+
+	// assert
+	test.That(t, first).Equals(time.Unix(0, 0).UTC())
+	test.That(t, second).Equals(time.Unix(1, 0).UTC())
+}
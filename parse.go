@@ -0,0 +1,136 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CommonLogLayout is the layout of timestamps in the Common Log Format
+	// used by web servers, e.g. "10/Oct/2000:13:55:36 -0700".
+	CommonLogLayout = "02/Jan/2006:15:04:05 -0700"
+
+	// LayoutUnixSeconds is a pseudo-layout which may be specified to
+	// ParseWithLayouts to parse a number of seconds since the Unix epoch,
+	// with an optional fractional part (e.g. "1700000000" or "1700000000.25").
+	//
+	// To avoid ambiguity with LayoutUnixMillis, at most 11 digits are accepted
+	// before any decimal point.
+	LayoutUnixSeconds = "unix"
+
+	// LayoutUnixMillis is a pseudo-layout which may be specified to
+	// ParseWithLayouts to parse an integer number of milliseconds since the
+	// Unix epoch (e.g. "1700000000000").
+	//
+	// To avoid ambiguity with LayoutUnixSeconds, only 12 to 14 digits are
+	// accepted.
+	LayoutUnixMillis = "unixmilli"
+)
+
+// DefaultLayouts is the ordered list of layouts tried by ParseAny.  The list may
+// be modified to change the layouts tried by ParseAny; this should be done only
+// during initialisation since the list is not guarded against concurrent
+// modification.
+var DefaultLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05.999999999",
+	time.DateTime + ".999999999",
+	time.DateTime + "Z07:00",
+	time.DateTime,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.UnixDate,
+	time.RubyDate,
+	time.ANSIC,
+	CommonLogLayout,
+	time.DateOnly,
+	LayoutUnixMillis,
+	LayoutUnixSeconds,
+}
+
+// ParseAny parses a string using each of the DefaultLayouts in turn, returning
+// the time parsed using the first layout that is successful and that layout.
+//
+// Times with no time zone information are parsed as UTC.
+//
+// If the string cannot be parsed using any layout an error wrapping
+// ErrNoMatchingLayout is returned.
+func ParseAny(s string) (time.Time, string, error) {
+	return ParseWithLayouts(s, DefaultLayouts...)
+}
+
+// ParseWithLayouts parses a string using each of the given layouts in turn,
+// returning the time parsed using the first layout that is successful and
+// that layout.  In addition to time.Parse layouts, the LayoutUnixSeconds and
+// LayoutUnixMillis pseudo-layouts may be specified.
+//
+// Times with no time zone information are parsed as UTC.
+//
+// If the string cannot be parsed using any layout an error wrapping
+// ErrNoMatchingLayout is returned.
+func ParseWithLayouts(s string, layouts ...string) (time.Time, string, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		var (
+			t   time.Time
+			err error
+		)
+		switch layout {
+		case LayoutUnixSeconds:
+			t, err = parseUnixSeconds(s)
+		case LayoutUnixMillis:
+			t, err = parseUnixMillis(s)
+		default:
+			t, err = time.Parse(layout, s)
+		}
+		if err == nil {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("%w: %q", ErrNoMatchingLayout, s)
+}
+
+// parseUnixSeconds parses a number of seconds since the Unix epoch with an
+// optional fractional part of up to 9 digits.
+func parseUnixSeconds(s string) (time.Time, error) {
+	secs, frac, _ := strings.Cut(s, ".")
+	if !isDigits(secs, 1, 11) || (frac != "" && !isDigits(frac, 1, 9)) || strings.HasSuffix(s, ".") {
+		return time.Time{}, strconv.ErrSyntax
+	}
+
+	sec, _ := strconv.ParseInt(secs, 10, 64)
+	var nsec int64
+	if frac != "" {
+		nsec, _ = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// parseUnixMillis parses an integer number of milliseconds since the Unix epoch.
+func parseUnixMillis(s string) (time.Time, error) {
+	if !isDigits(s, 12, 14) {
+		return time.Time{}, strconv.ErrSyntax
+	}
+	ms, _ := strconv.ParseInt(s, 10, 64)
+	return time.UnixMilli(ms).UTC(), nil
+}
+
+// isDigits returns true if s consists only of between minLen and maxLen
+// decimal digits.
+func isDigits(s string, minLen, maxLen int) bool {
+	if len(s) < minLen || len(s) > maxLen {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
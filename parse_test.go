@@ -0,0 +1,57 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestParseAny(t *testing.T) {
+	testcases := []struct {
+		s      string
+		want   time.Time
+		layout string
+	}{
+		{s: "2024-04-10T12:30:45.5+01:00", want: time.Date(2024, 4, 10, 11, 30, 45, 5e8, time.UTC), layout: time.RFC3339Nano},
+		{s: "2024-04-10T12:30:45", want: time.Date(2024, 4, 10, 12, 30, 45, 0, time.UTC), layout: "2006-01-02T15:04:05.999999999"},
+		{s: "2024-04-10 12:30:45", want: time.Date(2024, 4, 10, 12, 30, 45, 0, time.UTC), layout: time.DateTime + ".999999999"},
+		{s: "Wed, 10 Apr 2024 12:30:45 GMT", want: time.Date(2024, 4, 10, 12, 30, 45, 0, time.UTC), layout: time.RFC1123},
+		{s: "10/Apr/2024:12:30:45 +0000", want: time.Date(2024, 4, 10, 12, 30, 45, 0, time.UTC), layout: CommonLogLayout},
+		{s: "2024-04-10", want: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), layout: time.DateOnly},
+		{s: "1712752245", want: time.Date(2024, 4, 10, 12, 30, 45, 0, time.UTC), layout: LayoutUnixSeconds},
+		{s: "1712752245.25", want: time.Date(2024, 4, 10, 12, 30, 45, 25e7, time.UTC), layout: LayoutUnixSeconds},
+		{s: "1712752245250", want: time.Date(2024, 4, 10, 12, 30, 45, 25e7, time.UTC), layout: LayoutUnixMillis},
+		{s: " 2024-04-10 ", want: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), layout: time.DateOnly},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.s, func(t *testing.T) {
+			result, layout, err := ParseAny(tc.s)
+
+			test.Error(t, err).IsNil()
+			test.IsTrue(t, result.Equal(tc.want), "time")
+			test.That(t, layout).Equals(tc.layout)
+		})
+	}
+}
+
+func TestParseAny_NoMatch(t *testing.T) {
+	for _, s := range []string{"", "yesterday", "1712752245.", "17127522451234567", "12.3.4"} {
+		t.Run(s, func(t *testing.T) {
+			_, layout, err := ParseAny(s)
+
+			test.Error(t, err).Is(ErrNoMatchingLayout)
+			test.That(t, layout).Equals("")
+		})
+	}
+}
+
+func TestParseWithLayouts(t *testing.T) {
+	// act
+	result, layout, err := ParseWithLayouts("10:30AM", time.DateOnly, time.Kitchen)
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, layout).Equals(time.Kitchen)
+	test.That(t, result.Hour()).Equals(10)
+}
@@ -0,0 +1,56 @@
+package time
+
+import (
+	"context"
+	"fmt"
+)
+
+// Recv receives a value from a channel, waiting until a value is received, the
+// channel is closed or the context is done.
+//
+// If the context is done before a value is received, the zero value is returned
+// with the error of the context.  If the channel is closed, the zero value is
+// returned with ErrChannelClosed.
+//
+// A deadline or timeout of the context is that of the clock with which the
+// context was created (see: ContextWithTimeout), so that a Recv may be timed
+// out by advancing a mock clock.
+func Recv[T any](ctx context.Context, ch <-chan T) (T, error) {
+	var zero T
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			return zero, ErrChannelClosed
+		}
+		return v, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// RecvTimeout receives a value from a channel, waiting until a value is
+// received, the channel is closed or a timeout has elapsed according to a
+// given clock.  This replaces the common pattern of a select between a
+// channel and time.After with a single call that may be timed out by
+// advancing a mock clock.
+//
+// If the timeout elapses before a value is received, the zero value is
+// returned with ErrTimeout.  If the channel is closed, the zero value is
+// returned with ErrChannelClosed.
+func RecvTimeout[T any](clock Clock, ch <-chan T, d Duration) (T, error) {
+	var zero T
+
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			return zero, ErrChannelClosed
+		}
+		return v, nil
+	case <-timer.C:
+		return zero, fmt.Errorf("%w: no value received after %s", ErrTimeout, d)
+	}
+}
@@ -0,0 +1,124 @@
+package time
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestRecv(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		exec     func(t *testing.T)
+	}{
+		{scenario: "value received",
+			exec: func(t *testing.T) {
+				// arrange
+				ch := make(chan int, 1)
+				ch <- 42
+
+				// act
+				result, err := Recv(context.Background(), ch)
+
+				// assert
+				test.Error(t, err).IsNil()
+				test.That(t, result).Equals(42)
+			},
+		},
+		{scenario: "channel closed",
+			exec: func(t *testing.T) {
+				// arrange
+				ch := make(chan int)
+				close(ch)
+
+				// act
+				_, err := Recv(context.Background(), ch)
+
+				// assert
+				test.Error(t, err).Is(ErrChannelClosed)
+			},
+		},
+		{scenario: "context timed out",
+			exec: func(t *testing.T) {
+				// arrange
+				clock := NewMockClock()
+				ctx, cancel := clock.ContextWithTimeout(context.Background(), time.Second)
+				defer cancel()
+				go clock.AdvanceBy(time.Second)
+
+				// act
+				_, err := Recv(ctx, make(chan int))
+
+				// assert
+				test.Error(t, err).Is(context.DeadlineExceeded)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			tc.exec(t)
+		})
+	}
+}
+
+func TestRecvTimeout(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		exec     func(t *testing.T)
+	}{
+		{scenario: "value received",
+			exec: func(t *testing.T) {
+				// arrange
+				clock := NewMockClock()
+				ch := make(chan string, 1)
+				ch <- "value"
+
+				// act
+				result, err := RecvTimeout(clock, ch, time.Second)
+
+				// assert
+				test.Error(t, err).IsNil()
+				test.That(t, result).Equals("value")
+				test.That(t, len(clock.Timers())).Equals(1)
+				test.That(t, clock.Timers()[0].State).Equals("stopped")
+			},
+		},
+		{scenario: "channel closed",
+			exec: func(t *testing.T) {
+				// arrange
+				ch := make(chan string)
+				close(ch)
+
+				// act
+				_, err := RecvTimeout(NewMockClock(), ch, time.Second)
+
+				// assert
+				test.Error(t, err).Is(ErrChannelClosed)
+			},
+		},
+		{scenario: "timed out",
+			exec: func(t *testing.T) {
+				// arrange
+				clock := NewMockClock()
+				go func() {
+					waitFor(func() bool { return created(clock) > 0 })
+					clock.AdvanceBy(time.Second)
+				}()
+
+				// act
+				_, err := RecvTimeout(clock, make(chan string), time.Second)
+
+				// assert
+				test.Error(t, err).Is(ErrTimeout)
+				test.That(t, err.Error()).Equals("timeout: no value received after 1s")
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			tc.exec(t)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package time
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Registry tracks the functions scheduled through it, each identified by a
+// key (such as a string or an application-defined type), so that scheduled
+// work may be cancelled individually or all at once (for example, when a
+// server shuts down) and so that tests may verify what remains scheduled.
+//
+// Scheduling a function with the key of a function that is already scheduled
+// cancels and replaces that function.  A function is removed from the registry
+// when it is called.
+//
+// The zero value is a Registry using the system clock.  A Registry must not be
+// copied after first use.
+type Registry[K comparable] struct {
+	// Schedule is the schedule used to schedule functions, determining the
+	// clock and the maximum duration of timers.
+	Schedule Schedule
+
+	mu   sync.Mutex
+	jobs map[K]*registeredFunc[K]
+	seq  uint64
+}
+
+// RegisteredFunc describes a function scheduled through a Registry.
+type RegisteredFunc[K comparable] struct {
+	// ID is the key with which the function was scheduled.
+	ID K
+
+	// At is the time at which the function is scheduled to be called.
+	At time.Time
+}
+
+// registeredFunc is a function scheduled through a registry, with a sequence
+// number recording the order in which functions were scheduled.
+type registeredFunc[K comparable] struct {
+	*ScheduledFunc
+	id  K
+	seq uint64
+}
+
+// AfterFunc schedules a function with a given key to be called after a given
+// duration.
+func (r *Registry[K]) AfterFunc(id K, d time.Duration, fn func()) {
+	r.At(id, r.clock().Now().Add(d), fn)
+}
+
+// At schedules a function with a given key to be called at a given time.
+func (r *Registry[K]) At(id K, t time.Time, fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.jobs == nil {
+		r.jobs = map[K]*registeredFunc[K]{}
+	}
+	if job, ok := r.jobs[id]; ok {
+		job.Cancel()
+	}
+
+	r.seq++
+	job := &registeredFunc[K]{id: id, seq: r.seq}
+	r.jobs[id] = job
+
+	// the registry remains locked until the function is scheduled, so that a
+	// function that is called immediately is not removed before it is added
+	job.ScheduledFunc = r.Schedule.At(t, func() {
+		r.remove(job)
+		fn()
+	})
+}
+
+// Cancel cancels the function with a given key, returning true if it was
+// cancelled or false if no function with that key is scheduled.
+func (r *Registry[K]) Cancel(id K) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return false
+	}
+	delete(r.jobs, id)
+	return job.Cancel()
+}
+
+// CancelAll cancels all scheduled functions, returning the number cancelled.
+func (r *Registry[K]) CancelAll() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for id, job := range r.jobs {
+		if job.Cancel() {
+			n++
+		}
+		delete(r.jobs, id)
+	}
+	return n
+}
+
+// Len returns the number of scheduled functions.
+func (r *Registry[K]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.jobs)
+}
+
+// Scheduled returns the functions that are scheduled, in the order in which
+// they were scheduled.
+func (r *Registry[K]) Scheduled() []RegisteredFunc[K] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobs := make([]*registeredFunc[K], 0, len(r.jobs))
+	for _, job := range r.jobs {
+		jobs = append(jobs, job)
+	}
+	slices.SortFunc(jobs, func(a, b *registeredFunc[K]) int { return cmp.Compare(a.seq, b.seq) })
+
+	result := make([]RegisteredFunc[K], len(jobs))
+	for i, job := range jobs {
+		result[i] = RegisteredFunc[K]{ID: job.id, At: job.At()}
+	}
+	return result
+}
+
+// clock returns the clock of the schedule of the registry or the system clock
+// if none is specified.
+func (r *Registry[K]) clock() Clock {
+	if r.Schedule.Clock == nil {
+		return SystemClock()
+	}
+	return r.Schedule.Clock
+}
+
+// remove removes a function from the registry when it is called, unless it
+// has been replaced.
+func (r *Registry[K]) remove(job *registeredFunc[K]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.jobs[job.id] == job {
+		delete(r.jobs, job.id)
+	}
+}
@@ -0,0 +1,79 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that scheduled functions are listed until called.
+func TestRegistry(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock()
+		called = make(chan string, 2)
+		sut    = &Registry[string]{Schedule: Schedule{Clock: clock}}
+	)
+
+	// act
+	sut.AfterFunc("poll", time.Second, func() { called <- "poll" })
+	sut.At("report", time.Unix(10, 0), func() { called <- "report" })
+	scheduled := sut.Scheduled()
+	clock.AdvanceBy(time.Second)
+	result := <-called
+
+	// assert
+	test.That(t, scheduled).Equals([]RegisteredFunc[string]{
+		{ID: "poll", At: time.Unix(1, 0).UTC()},
+		{ID: "report", At: time.Unix(10, 0)},
+	})
+	test.That(t, result).Equals("poll")
+	waitFor(func() bool { return sut.Len() == 1 })
+}
+
+// Tests that scheduling a function with the key of a scheduled function
+// replaces it.
+func TestRegistry_Replace(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock()
+		called = make(chan int, 2)
+		sut    = &Registry[int]{Schedule: Schedule{Clock: clock}}
+	)
+	sut.AfterFunc(1, time.Second, func() { called <- 1 })
+
+	// act
+	sut.AfterFunc(1, 2*time.Second, func() { called <- 2 })
+	clock.AdvanceBy(2 * time.Second)
+
+	// assert
+	test.That(t, <-called).Equals(2)
+	test.That(t, len(called)).Equals(0)
+}
+
+// Tests that cancelled functions are not called.
+func TestRegistry_Cancel(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock()
+		called = make(chan string, 3)
+		sut    = &Registry[string]{Schedule: Schedule{Clock: clock}}
+	)
+	for _, id := range []string{"a", "b", "c"} {
+		sut.AfterFunc(id, time.Second, func() { called <- id })
+	}
+
+	// act
+	cancelled := sut.Cancel("a")
+	notScheduled := sut.Cancel("x")
+	n := sut.CancelAll()
+	clock.AdvanceBy(time.Second)
+
+	// assert
+	test.IsTrue(t, cancelled)
+	test.IsFalse(t, notScheduled)
+	test.That(t, n).Equals(2)
+	test.That(t, sut.Len()).Equals(0)
+	test.That(t, len(called)).Equals(0)
+}
@@ -0,0 +1,350 @@
+package time
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeUnits maps the units accepted by ParseRelative to a function adding
+// a number of those units to a time, or returning false if the number is out
+// of range.  Days, weeks, months and years are calendar units, respecting the
+// location of the time.
+var relativeUnits = map[string]func(t time.Time, n int) (time.Time, bool){
+	"s":         addDurations(time.Second),
+	"sec":       addDurations(time.Second),
+	"second":    addDurations(time.Second),
+	"m":         addDurations(time.Minute),
+	"min":       addDurations(time.Minute),
+	"minute":    addDurations(time.Minute),
+	"h":         addDurations(time.Hour),
+	"hr":        addDurations(time.Hour),
+	"hour":      addDurations(time.Hour),
+	"d":         addDays(1),
+	"day":       addDays(1),
+	"w":         addDays(7),
+	"wk":        addDays(7),
+	"week":      addDays(7),
+	"month":     addMonths(1),
+	"y":         addMonths(12),
+	"yr":        addMonths(12),
+	"year":      addMonths(12),
+	"fortnight": addDays(14),
+}
+
+// maxRelativeYears is the maximum span, in years, of a quantity of a calendar
+// unit in a relative duration (the range of a four-digit year).
+const maxRelativeYears = 10000
+
+// addDurations returns a function adding a number of a unit of duration to a
+// time, if the number of units does not overflow a Duration.
+func addDurations(unit time.Duration) func(time.Time, int) (time.Time, bool) {
+	return func(t time.Time, n int) (time.Time, bool) {
+		if n > int(math.MaxInt64/unit) || n < int(math.MinInt64/unit) {
+			return time.Time{}, false
+		}
+		return t.Add(time.Duration(n) * unit), true
+	}
+}
+
+// addDays returns a function adding a number of units of a given number of
+// calendar days to a time.
+func addDays(days int) func(time.Time, int) (time.Time, bool) {
+	return func(t time.Time, n int) (time.Time, bool) {
+		if n > maxRelativeYears*366/days || n < -maxRelativeYears*366/days {
+			return time.Time{}, false
+		}
+		return t.AddDate(0, 0, days*n), true
+	}
+}
+
+// addMonths returns a function adding a number of units of a given number of
+// calendar months to a time, clamping the day to the end of a shorter month.
+func addMonths(months int) func(time.Time, int) (time.Time, bool) {
+	return func(t time.Time, n int) (time.Time, bool) {
+		if n > maxRelativeYears*12/months || n < -maxRelativeYears*12/months {
+			return time.Time{}, false
+		}
+		return AddMonths(t, months*n, ClampToMonthEnd), true
+	}
+}
+
+// relativeWeekdays maps the names (and abbreviations) of weekdays accepted by
+// ParseRelative and ParseWeeklySchedule.
+var relativeWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ParseRelative parses a natural-language description of a time relative to
+// the current time of a clock, e.g. "in 2 hours", "5m ago", "tomorrow at 9am"
+// or "next monday".  Days are resolved in the location of the time returned by
+// the clock.
+//
+// The following forms are supported (case-insensitive):
+//
+//   - "now"
+//   - "today", "tomorrow" and "yesterday" (at midnight, unless a time is given);
+//   - a weekday, e.g. "friday" or "next friday" (the next such day after today)
+//     or "last friday" (the last such day before today), at midnight unless a
+//     time is given;
+//   - "next" or "last" followed by a unit, e.g. "next week";
+//   - "in" followed by a duration or a duration followed by "ago", e.g.
+//     "in 3 days", "in an hour", "2 hours 30 minutes ago", "5m ago" or
+//     "in 1h30m";
+//   - any of the above followed by "at" and a time of day, e.g. "tomorrow at
+//     9:30am" or "next monday at 17:00";
+//   - a time of day alone, e.g. "9am", "noon" or "23:15" (today).
+//
+// The units of a duration may be seconds (s, sec), minutes (m, min), hours
+// (h, hr), days (d), weeks (w, wk), fortnights, months or years (y, yr), in
+// singular or plural form.  Months and years are added using AddMonths and
+// AddYears with the ClampToMonthEnd policy.  A quantity of seconds, minutes or
+// hours that would overflow a Duration, or of a calendar unit spanning more
+// than 10,000 years, is out of range.
+//
+// If the string is not recognised an error wrapping ErrInvalidRelativeTime is
+// returned.
+func ParseRelative(clock Clock, s string) (time.Time, error) {
+	t, err := parseRelative(clock.Now(), strings.ToLower(strings.TrimSpace(s)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q: %w", ErrInvalidRelativeTime, s, err)
+	}
+	return t, nil
+}
+
+// parseRelative parses a lower-case relative time, resolved against a given time.
+func parseRelative(now time.Time, s string) (time.Time, error) {
+	day, tod, hasTime := strings.Cut(s, " at ")
+	if !hasTime {
+		if h, m, sec, err := parseTimeOfDay(s); err == nil {
+			return atTimeOfDay(now, h, m, sec), nil
+		}
+	}
+
+	t, err := parseRelativeDay(now, strings.Fields(day))
+	if err != nil || !hasTime {
+		return t, err
+	}
+
+	h, m, sec, err := parseTimeOfDay(strings.TrimSpace(tod))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return atTimeOfDay(t, h, m, sec), nil
+}
+
+// parseRelativeDay parses the fields of a relative time (excluding any time
+// of day), resolved against a given time.
+func parseRelativeDay(now time.Time, fields []string) (time.Time, error) {
+	midnight := atTimeOfDay(now, 0, 0, 0)
+
+	switch {
+	case len(fields) == 0:
+		return time.Time{}, errors.New("no date or time")
+
+	case len(fields) == 1 && fields[0] == "now":
+		return now, nil
+
+	case len(fields) == 1 && fields[0] == "today":
+		return midnight, nil
+
+	case len(fields) == 1 && fields[0] == "tomorrow":
+		return midnight.AddDate(0, 0, 1), nil
+
+	case len(fields) == 1 && fields[0] == "yesterday":
+		return midnight.AddDate(0, 0, -1), nil
+
+	case fields[0] == "in":
+		return addRelative(now, fields[1:], 1)
+
+	case fields[len(fields)-1] == "ago":
+		return addRelative(now, fields[:len(fields)-1], -1)
+
+	case len(fields) == 2 && (fields[0] == "next" || fields[0] == "last"):
+		sign := 1
+		if fields[0] == "last" {
+			sign = -1
+		}
+		if wd, ok := relativeWeekdays[fields[1]]; ok {
+			return nextWeekday(midnight, wd, sign), nil
+		}
+		if add, ok := relativeUnit(fields[1]); ok {
+			t, _ := add(now, sign) // a single unit is always in range
+			return t, nil
+		}
+
+	case len(fields) == 1:
+		if wd, ok := relativeWeekdays[fields[0]]; ok {
+			return nextWeekday(midnight, wd, 1), nil
+		}
+	}
+	return time.Time{}, errors.New("not recognised")
+}
+
+// addRelative adds a duration, described by the given fields, to a time; the
+// sign determines whether the duration is added (1) or subtracted (-1).
+//
+// The fields are either a compact duration (e.g. "1h30m", "2d") or pairs of
+// quantity and unit (e.g. "2 hours 30 minutes"), where a quantity of "a" or
+// "an" is equivalent to 1.
+func addRelative(t time.Time, fields []string, sign int) (time.Time, error) {
+	if len(fields) == 0 {
+		return time.Time{}, errors.New("no duration")
+	}
+
+	if len(fields) == 1 {
+		return addCompact(t, fields[0], sign)
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		if i+1 == len(fields) {
+			return time.Time{}, fmt.Errorf("%q: no unit", fields[i])
+		}
+		n, err := relativeQuantity(fields[i])
+		if err != nil {
+			return time.Time{}, err
+		}
+		add, ok := relativeUnit(strings.TrimSuffix(fields[i+1], ","))
+		if !ok {
+			return time.Time{}, fmt.Errorf("%q: unknown unit", fields[i+1])
+		}
+		if t, ok = add(t, sign*n); !ok {
+			return time.Time{}, fmt.Errorf("%q: quantity out of range", fields[i]+" "+fields[i+1])
+		}
+		if i+2 < len(fields) && fields[i+2] == "and" {
+			if i+3 == len(fields) {
+				return time.Time{}, errors.New("no duration after \"and\"")
+			}
+			i++
+		}
+	}
+	return t, nil
+}
+
+// addCompact adds a compact duration (e.g. "5m", "1h30m" or "2d12h") to a time.
+func addCompact(t time.Time, s string, sign int) (time.Time, error) {
+	rest := s
+	for len(rest) > 0 {
+		i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return time.Time{}, fmt.Errorf("%q: not a duration", s)
+		}
+		j := strings.IndexFunc(rest[i:], func(r rune) bool { return r >= '0' && r <= '9' })
+		if j < 0 {
+			j = len(rest) - i
+		}
+
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%q: quantity out of range", s)
+		}
+		add, ok := relativeUnit(rest[i : i+j])
+		if !ok {
+			return time.Time{}, fmt.Errorf("%q: not a duration", s)
+		}
+		if t, ok = add(t, sign*n); !ok {
+			return time.Time{}, fmt.Errorf("%q: quantity out of range", s)
+		}
+		rest = rest[i+j:]
+	}
+	return t, nil
+}
+
+// relativeQuantity parses the quantity of a unit in a relative duration.
+func relativeQuantity(s string) (int, error) {
+	if s == "a" || s == "an" {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q: not a quantity", s)
+	}
+	return n, nil
+}
+
+// relativeUnit returns the function adding a given unit, which may be plural.
+func relativeUnit(s string) (func(time.Time, int) (time.Time, bool), bool) {
+	if add, ok := relativeUnits[s]; ok {
+		return add, true
+	}
+	if len(s) > 2 {
+		add, ok := relativeUnits[strings.TrimSuffix(s, "s")]
+		return add, ok
+	}
+	return nil, false
+}
+
+// nextWeekday returns the next (sign 1) or previous (sign -1) occurrence of a
+// given weekday, strictly after or before a given day.
+func nextWeekday(day time.Time, wd time.Weekday, sign int) time.Time {
+	n := (int(wd) - int(day.Weekday()) + 7) % 7
+	if sign < 0 {
+		n = (n - 7) % 7
+	}
+	if n == 0 {
+		n = 7 * sign
+	}
+	return day.AddDate(0, 0, n)
+}
+
+// parseTimeOfDay parses a time of day, e.g. "9am", "9:30 pm", "17:00",
+// "17:00:30", "noon" or "midnight".
+func parseTimeOfDay(s string) (h, m, sec int, err error) {
+	switch s {
+	case "noon":
+		return 12, 0, 0, nil
+	case "midnight":
+		return 0, 0, 0, nil
+	}
+
+	s = strings.ReplaceAll(s, " ", "")
+	meridiem := ""
+	if strings.HasSuffix(s, "am") || strings.HasSuffix(s, "pm") {
+		meridiem, s = s[len(s)-2:], s[:len(s)-2]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 || (meridiem == "" && len(parts) < 2) {
+		return 0, 0, 0, fmt.Errorf("%q: not a time of day", s)
+	}
+
+	values := [3]int{}
+	for i, p := range parts {
+		if !isDigits(p, 1, 2) || (i > 0 && len(p) != 2) {
+			return 0, 0, 0, fmt.Errorf("%q: not a time of day", s)
+		}
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("%q: not a time of day", s)
+		}
+		values[i] = v
+	}
+	h, m, sec = values[0], values[1], values[2]
+
+	switch {
+	case meridiem != "" && (h < 1 || h > 12):
+		return 0, 0, 0, fmt.Errorf("%q: hour out of range", s)
+	case meridiem == "am" && h == 12:
+		h = 0
+	case meridiem == "pm" && h < 12:
+		h += 12
+	}
+	if h > 23 || m > 59 || sec > 59 {
+		return 0, 0, 0, fmt.Errorf("%q: not a time of day", s)
+	}
+	return h, m, sec, nil
+}
+
+// atTimeOfDay returns the given time of day on the day of a given time.
+func atTimeOfDay(t time.Time, h, m, sec int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), h, m, sec, 0, t.Location())
+}
@@ -0,0 +1,106 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestParseRelative(t *testing.T) {
+	// arrange: Wednesday 10th January 2024, 14:30:15 UTC
+	now := time.Date(2024, time.January, 10, 14, 30, 15, 0, time.UTC)
+	clock := NewMockClock(AtTime(now))
+
+	testcases := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "now", want: now},
+		{input: " Now ", want: now},
+		{input: "today", want: time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)},
+		{input: "tomorrow", want: time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)},
+		{input: "yesterday", want: time.Date(2024, time.January, 9, 0, 0, 0, 0, time.UTC)},
+		{input: "tomorrow at 9am", want: time.Date(2024, time.January, 11, 9, 0, 0, 0, time.UTC)},
+		{input: "today at 9:30 pm", want: time.Date(2024, time.January, 10, 21, 30, 0, 0, time.UTC)},
+		{input: "yesterday at 12am", want: time.Date(2024, time.January, 9, 0, 0, 0, 0, time.UTC)},
+		{input: "tomorrow at noon", want: time.Date(2024, time.January, 11, 12, 0, 0, 0, time.UTC)},
+		{input: "9am", want: time.Date(2024, time.January, 10, 9, 0, 0, 0, time.UTC)},
+		{input: "23:15:30", want: time.Date(2024, time.January, 10, 23, 15, 30, 0, time.UTC)},
+		{input: "friday", want: time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC)},
+		{input: "next monday", want: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "next wednesday", want: time.Date(2024, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		{input: "last wednesday", want: time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{input: "last tue at 17:00", want: time.Date(2024, time.January, 9, 17, 0, 0, 0, time.UTC)},
+		{input: "next week", want: now.AddDate(0, 0, 7)},
+		{input: "last month", want: time.Date(2023, time.December, 10, 14, 30, 15, 0, time.UTC)},
+		{input: "in 2 hours", want: now.Add(2 * time.Hour)},
+		{input: "in an hour", want: now.Add(time.Hour)},
+		{input: "in 1 day", want: now.AddDate(0, 0, 1)},
+		{input: "in 2 hours 30 minutes", want: now.Add(150 * time.Minute)},
+		{input: "in 1 hour and 5 mins", want: now.Add(65 * time.Minute)},
+		{input: "in 1h30m", want: now.Add(90 * time.Minute)},
+		{input: "in 2d", want: now.AddDate(0, 0, 2)},
+		{input: "5m ago", want: now.Add(-5 * time.Minute)},
+		{input: "3 weeks ago", want: now.AddDate(0, 0, -21)},
+		{input: "a year ago", want: now.AddDate(-1, 0, 0)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			// act
+			result, err := ParseRelative(clock, tc.input)
+
+			// assert
+			test.Error(t, err).IsNil()
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestParseRelative_Invalid(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+
+	testcases := []string{
+		"",
+		"soon",
+		"in",
+		"ago",
+		"in 2",
+		"in 2 fortnights and",
+		"in two hours",
+		"in 5x",
+		"next blue moon",
+		"tomorrow at teatime",
+		"13pm",
+		"25:00",
+		"9:5",
+		"in 99999999999999999999d",
+		"in 9999999999h",
+		"in 20000 years",
+		"3000000000000 weeks ago",
+	}
+	for _, input := range testcases {
+		t.Run(input, func(t *testing.T) {
+			// act
+			_, err := ParseRelative(clock, input)
+
+			// assert
+			test.Error(t, err).Is(ErrInvalidRelativeTime)
+		})
+	}
+}
+
+// Tests that days are resolved in the location of the clock.
+func TestParseRelative_InLocation(t *testing.T) {
+	// arrange: 23:30 on the 10th in UTC is 01:30 on the 11th in EET (UTC+2)
+	loc := time.FixedZone("EET", 2*60*60)
+	clock := NewMockClock(AtTime(time.Date(2024, time.January, 10, 23, 30, 0, 0, time.UTC)), InLocation(loc))
+
+	// act
+	result, err := ParseRelative(clock, "tomorrow")
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, result).Equals(time.Date(2024, time.January, 12, 0, 0, 0, 0, loc))
+}
@@ -0,0 +1,141 @@
+package time
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RetryBudget limits the retries made by a client to a proportion of the
+// requests it makes, so that retries cannot multiply the load on a failing
+// service.
+//
+// Each request deposits a fraction of a token (the ratio of the budget) and
+// each retry withdraws a whole token; a retry is permitted only if a whole
+// token is available.  Tokens decay over time (as determined by a clock),
+// halving with each half-life, so that the budget reflects recent requests
+// rather than the requests made over the lifetime of the client.
+//
+// A budget with no recent requests permits no retries.
+type RetryBudget struct {
+	mu       sync.Mutex
+	clock    Clock
+	ratio    float64
+	halfLife time.Duration
+	tokens   float64
+	updated  time.Time
+}
+
+// NewRetryBudget returns a RetryBudget permitting retries of a given ratio of
+// requests (e.g. 0.1 permits one retry for every ten requests), with tokens
+// decaying with a given half-life, using a given clock (or the system clock,
+// if nil).  The half-life must be greater than zero; if halfLife <= 0,
+// NewRetryBudget will panic.
+func NewRetryBudget(clock Clock, ratio float64, halfLife time.Duration) *RetryBudget {
+	if halfLife <= 0 {
+		panic(fmt.Errorf("%w for NewRetryBudget", errNonPositiveInterval))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &RetryBudget{clock: clock, ratio: max(ratio, 0), halfLife: halfLife, updated: clock.Now()}
+}
+
+// Deposit records a request, depositing the ratio of the budget in tokens.
+func (b *RetryBudget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.decay()
+	b.tokens += b.ratio
+}
+
+// Withdraw withdraws a token for a retry if one is available, returning true
+// if the retry is permitted or false if the budget is exhausted.
+func (b *RetryBudget) Withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.decay()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Balance returns the number of tokens available at the current time of the
+// clock; a retry is permitted if the balance is at least 1.
+func (b *RetryBudget) Balance() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.decay()
+	return b.tokens
+}
+
+// decay decays the tokens of the budget to the current time of the clock.
+//
+// This method is not thread-safe and should only be called while the budget
+// is locked.
+func (b *RetryBudget) decay() {
+	now := b.clock.Now()
+	b.tokens *= decay(now.Sub(b.updated), b.halfLife)
+	b.updated = now
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// Hedge calls a function and, if it has not returned successfully after a
+// given delay (according to the Clock in the context), calls it a second time
+// concurrently, returning the result of whichever call succeeds first.  The
+// context passed to the calls is cancelled when Hedge returns, cancelling
+// the call that did not succeed first.
+//
+// Hedging mitigates tail latency, not failure: if the first call fails before
+// the second is made, the error is returned without making the second call.
+// Once both calls have been made, the error of the last to fail is returned if
+// neither succeeds.
+//
+// If the context is done before a call succeeds, the zero value is returned
+// with the error of the context.
+func Hedge[T any](ctx context.Context, delay time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+
+	hctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan result, 2)
+	call := func() {
+		v, err := fn(hctx)
+		results <- result{v, err}
+	}
+	go call()
+
+	timer := ClockFromContext(ctx).NewTimerNamed(delay, "Hedge")
+	defer timer.Stop()
+
+	hedge, pending := timer.C, 1
+	for {
+		select {
+		case <-hedge:
+			hedge = nil
+			pending++
+			go call()
+
+		case r := <-results:
+			pending--
+			if r.err == nil || hedge != nil || pending == 0 {
+				return r.value, r.err
+			}
+
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package time
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that retries are permitted in proportion to requests and that tokens
+// decay over time.
+func TestRetryBudget(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewRetryBudget(clock, 0.5, time.Second)
+
+	// act/assert
+	test.IsFalse(t, sut.Withdraw(), "no requests")
+
+	sut.Deposit()
+	sut.Deposit()
+	test.IsTrue(t, sut.Withdraw(), "after two requests")
+	test.IsFalse(t, sut.Withdraw(), "budget exhausted")
+
+	for range 4 {
+		sut.Deposit()
+	}
+	clock.AdvanceBy(time.Second)
+	test.That(t, sut.Balance()).Equals(1.0)
+	test.IsTrue(t, sut.Withdraw(), "after decay")
+	test.IsFalse(t, sut.Withdraw(), "decayed budget exhausted")
+}
+
+func TestNewRetryBudget(t *testing.T) {
+	t.Run("nil clock", func(t *testing.T) {
+		// act
+		result := NewRetryBudget(nil, 0.1, time.Second)
+
+		// assert
+		test.That(t, result.clock).Equals(SystemClock())
+	})
+
+	t.Run("non-positive half-life", func(t *testing.T) {
+		// arrange
+		defer test.ExpectPanic(errNonPositiveInterval).Assert(t)
+
+		// act
+		_ = NewRetryBudget(nil, 0.1, 0)
+	})
+}
+
+// Tests that a call returning before the delay is not hedged.
+func TestHedge_NotHedged(t *testing.T) {
+	// arrange
+	ctx, _ := ContextWithMockClock(context.Background())
+	calls := atomic.Int32{}
+
+	// act
+	result, err := Hedge(ctx, time.Second, func(context.Context) (string, error) {
+		calls.Add(1)
+		return "first", nil
+	})
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, result).Equals("first")
+	test.That(t, calls.Load()).Equals(int32(1))
+}
+
+// Tests that a slow call is hedged after the delay and that the call that does
+// not succeed first is cancelled.
+func TestHedge_Hedged(t *testing.T) {
+	// arrange
+	ctx, clock := ContextWithMockClock(context.Background())
+	calls := atomic.Int32{}
+	cancelled := make(chan error, 1)
+
+	// act
+	go func() {
+		waitFor(func() bool { return created(clock) > 0 })
+		clock.AdvanceBy(time.Second)
+	}()
+	result, err := Hedge(ctx, time.Second, func(ctx context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return "", ctx.Err()
+		}
+		return "second", nil
+	})
+
+	// assert
+	test.Error(t, err).IsNil()
+	test.That(t, result).Equals("second")
+	test.Error(t, <-cancelled).Is(context.Canceled)
+}
+
+func TestHedge_Errors(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	t.Run("first call fails before hedging", func(t *testing.T) {
+		// arrange
+		ctx, _ := ContextWithMockClock(context.Background())
+		calls := atomic.Int32{}
+
+		// act
+		_, err := Hedge(ctx, time.Second, func(context.Context) (int, error) {
+			calls.Add(1)
+			return 0, errFirst
+		})
+
+		// assert
+		test.Error(t, err).Is(errFirst)
+		test.That(t, calls.Load()).Equals(int32(1))
+	})
+
+	t.Run("both calls fail", func(t *testing.T) {
+		// arrange
+		ctx, clock := ContextWithMockClock(context.Background())
+		release := make(chan struct{})
+		calls := atomic.Int32{}
+
+		// act
+		go func() {
+			waitFor(func() bool { return created(clock) > 0 })
+			clock.AdvanceBy(time.Second)
+		}()
+		_, err := Hedge(ctx, time.Second, func(context.Context) (int, error) {
+			if calls.Add(1) == 1 {
+				<-release
+				return 0, errFirst
+			}
+			close(release)
+			return 0, errSecond
+		})
+
+		// assert
+		test.Error(t, err).Is(errFirst)
+		test.That(t, calls.Load()).Equals(int32(2))
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		// arrange
+		ctx, _ := ContextWithMockClock(context.Background())
+		ctx, cancel := context.WithCancel(ctx)
+		release := make(chan struct{})
+		defer close(release)
+
+		// act
+		go cancel()
+		_, err := Hedge(ctx, time.Second, func(context.Context) (int, error) {
+			<-release
+			return 0, nil
+		})
+
+		// assert
+		test.Error(t, err).Is(context.Canceled)
+	})
+}
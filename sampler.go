@@ -0,0 +1,132 @@
+package time
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Sampler permits an action, such as logging a message or warning, at most
+// once per period according to a clock: the first call of ShouldRun returns
+// true, as does the next call made when at least the period has elapsed since
+// the last call returning true; all other calls return false.
+//
+// A KeyedSampler permits an action at most once per period for each of any
+// number of keys (e.g. the template of a log message).
+type Sampler struct {
+	mu     sync.Mutex
+	clock  Clock
+	period time.Duration
+	last   time.Time
+	ran    bool
+}
+
+// NewSampler returns a Sampler permitting an action at most once per period,
+// using a given clock (or the system clock, if nil).  The period must be
+// greater than zero; if period <= 0, NewSampler will panic.
+func NewSampler(clock Clock, period time.Duration) *Sampler {
+	if period <= 0 {
+		panic(fmt.Errorf("%w for NewSampler", errNonPositiveInterval))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &Sampler{clock: clock, period: period}
+}
+
+// ShouldRun returns true if the action is permitted, i.e. if the action has
+// not been permitted in the preceding period.
+func (s *Sampler) ShouldRun() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if s.ran && now.Sub(s.last) < s.period {
+		return false
+	}
+	s.last, s.ran = now, true
+	return true
+}
+
+// Do calls a function if the action is permitted (see: ShouldRun), returning
+// true if the function was called.
+func (s *Sampler) Do(fn func()) bool {
+	if !s.ShouldRun() {
+		return false
+	}
+	fn()
+	return true
+}
+
+// KeyedSampler permits an action at most once per period for each key,
+// according to a clock (see: Sampler).
+//
+// A key is retained until at least the period has elapsed since the action
+// was last permitted for it; keys are then discarded as the sampler is used.
+type KeyedSampler[K comparable] struct {
+	mu      sync.Mutex
+	clock   Clock
+	period  time.Duration
+	last    map[K]time.Time
+	pruneAt int
+}
+
+// minSamplerPrune is the minimum number of keys retained by a KeyedSampler
+// before keys for which the period has elapsed are discarded.
+const minSamplerPrune = 64
+
+// NewKeyedSampler returns a KeyedSampler permitting an action at most once per
+// period for each key, using a given clock (or the system clock, if nil).  The
+// period must be greater than zero; if period <= 0, NewKeyedSampler will
+// panic.
+func NewKeyedSampler[K comparable](clock Clock, period time.Duration) *KeyedSampler[K] {
+	if period <= 0 {
+		panic(fmt.Errorf("%w for NewKeyedSampler", errNonPositiveInterval))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &KeyedSampler[K]{clock: clock, period: period, last: map[K]time.Time{}, pruneAt: minSamplerPrune}
+}
+
+// ShouldRun returns true if the action is permitted for a given key, i.e. if
+// the action has not been permitted for the key in the preceding period.
+func (s *KeyedSampler[K]) ShouldRun(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if last, ok := s.last[key]; ok && now.Sub(last) < s.period {
+		return false
+	}
+	s.last[key] = now
+	s.prune(now)
+	return true
+}
+
+// Do calls a function if the action is permitted for a given key (see:
+// ShouldRun), returning true if the function was called.
+func (s *KeyedSampler[K]) Do(key K, fn func()) bool {
+	if !s.ShouldRun(key) {
+		return false
+	}
+	fn()
+	return true
+}
+
+// prune discards the keys for which the period has elapsed, once the number
+// of keys has doubled since the keys were last pruned.
+//
+// This method is not thread-safe and should only be called while the sampler
+// is locked.
+func (s *KeyedSampler[K]) prune(now time.Time) {
+	if len(s.last) < s.pruneAt {
+		return
+	}
+	for key, last := range s.last {
+		if now.Sub(last) >= s.period {
+			delete(s.last, key)
+		}
+	}
+	s.pruneAt = max(2*len(s.last), minSamplerPrune)
+}
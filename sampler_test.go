@@ -0,0 +1,87 @@
+package time
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a sampler permits an action at most once per period, across
+// period boundaries.
+func TestSampler(t *testing.T) {
+	// arrange
+	clock := NewMockClock(Yielding(0))
+	sut := NewSampler(clock, time.Second)
+
+	// act
+	result := []bool{}
+	for _, d := range []time.Duration{0, 0, 999 * time.Millisecond, time.Millisecond, 0, 1500 * time.Millisecond, 500 * time.Millisecond} {
+		clock.AdvanceBy(d)
+		result = append(result, sut.ShouldRun())
+	}
+
+	// assert
+	test.That(t, result).Equals([]bool{true, false, false, true, false, true, false})
+}
+
+func TestSampler_Do(t *testing.T) {
+	// arrange
+	clock := NewMockClock(Yielding(0))
+	sut := NewSampler(clock, time.Minute)
+	calls := 0
+
+	// act
+	ran := sut.Do(func() { calls++ })
+	skipped := sut.Do(func() { calls++ })
+
+	// assert
+	test.IsTrue(t, ran)
+	test.IsFalse(t, skipped)
+	test.That(t, calls).Equals(1)
+}
+
+func TestNewSampler_NonPositivePeriod(t *testing.T) {
+	// assert
+	defer test.ExpectPanic(errNonPositiveInterval).Assert(t)
+
+	// act
+	_ = NewSampler(nil, 0)
+}
+
+// Tests that a keyed sampler permits an action at most once per period for
+// each key.
+func TestKeyedSampler(t *testing.T) {
+	// arrange
+	clock := NewMockClock(Yielding(0))
+	sut := NewKeyedSampler[string](clock, time.Second)
+
+	// act
+	a1, b1 := sut.ShouldRun("a"), sut.ShouldRun("b")
+	clock.AdvanceBy(500 * time.Millisecond)
+	a2 := sut.Do("a", func() { t.Error("called when not permitted") })
+	clock.AdvanceBy(500 * time.Millisecond)
+	a3, b3 := sut.ShouldRun("a"), sut.ShouldRun("b")
+
+	// assert
+	test.That(t, []bool{a1, b1, a2, a3, b3}).Equals([]bool{true, true, false, true, true})
+}
+
+// Tests that a keyed sampler discards keys for which the period has elapsed.
+func TestKeyedSampler_Prune(t *testing.T) {
+	// arrange
+	clock := NewMockClock(Yielding(0))
+	sut := NewKeyedSampler[string](clock, time.Second)
+	for i := range minSamplerPrune - 1 {
+		_ = sut.ShouldRun(strconv.Itoa(i))
+	}
+
+	// act
+	clock.AdvanceBy(time.Second)
+	_ = sut.ShouldRun("recent")
+
+	// assert
+	test.That(t, len(sut.last)).Equals(1)
+	test.That(t, sut.pruneAt).Equals(minSamplerPrune)
+}
@@ -0,0 +1,122 @@
+package time
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultMaxTimerDuration is the maximum duration of the timers used by a
+// Schedule for which no maximum is specified.
+const DefaultMaxTimerDuration = time.Hour
+
+// Schedule schedules functions to be called at absolute times, using the
+// timers of a clock.
+//
+// A timer measures elapsed time, so a very long timer may fire at the wrong
+// wall-clock time if the wall clock is adjusted (or the system is suspended)
+// while it is running.  A Schedule therefore arms timers of no more than a
+// maximum duration, re-arming them until the scheduled time is reached.
+//
+// The zero value is a Schedule using the system clock with a maximum timer
+// duration of DefaultMaxTimerDuration.
+type Schedule struct {
+	// Clock is the clock used to schedule functions.  If nil, the system
+	// clock is used.
+	Clock Clock
+
+	// MaxTimerDuration is the maximum duration of any timer armed by the
+	// schedule.  If zero (or less), DefaultMaxTimerDuration is used.
+	MaxTimerDuration time.Duration
+}
+
+// ScheduledFunc is a function scheduled to be called at a given time by a
+// Schedule.  It may be cancelled before it is called.
+type ScheduledFunc struct {
+	mu        sync.Mutex
+	clock     Clock
+	at        time.Time
+	max       time.Duration
+	fn        func()
+	timer     *Timer
+	fired     bool
+	cancelled bool
+}
+
+// At schedules a function to be called, on its own goroutine, at a given time.
+// If the time has already passed the function is called immediately.
+func (s Schedule) At(t time.Time, fn func()) *ScheduledFunc {
+	f := &ScheduledFunc{
+		clock: s.Clock,
+		at:    t,
+		max:   s.MaxTimerDuration,
+		fn:    fn,
+	}
+	if f.clock == nil {
+		f.clock = SystemClock()
+	}
+	if f.max <= 0 {
+		f.max = DefaultMaxTimerDuration
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.arm()
+
+	return f
+}
+
+// At returns the time at which the function is scheduled to be called.
+func (f *ScheduledFunc) At() time.Time {
+	return f.at
+}
+
+// Cancel cancels the scheduled function, returning true if it was cancelled
+// or false if it has already been called (or cancelled).
+func (f *ScheduledFunc) Cancel() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.fired || f.cancelled {
+		return false
+	}
+	f.cancelled = true
+	f.timer.Stop()
+	return true
+}
+
+// Fired returns true if the function has been called.
+func (f *ScheduledFunc) Fired() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.fired
+}
+
+// arm arms a timer for the time remaining until the function is due, limited
+// to the maximum timer duration.
+//
+// This method is not thread-safe and should only be called while the function
+// is locked.
+func (f *ScheduledFunc) arm() {
+	d := min(f.clock.Until(f.at), f.max)
+	f.timer = f.clock.AfterFunc(d, f.wake)
+}
+
+// wake is called when the timer of the function fires, calling the function if
+// it is due or re-arming the timer otherwise.
+func (f *ScheduledFunc) wake() {
+	f.mu.Lock()
+	if f.cancelled {
+		f.mu.Unlock()
+		return
+	}
+	if f.clock.Now().Before(f.at) {
+		f.arm()
+		f.mu.Unlock()
+		return
+	}
+	f.fired = true
+	f.mu.Unlock()
+
+	f.fn()
+}
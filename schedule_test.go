@@ -0,0 +1,74 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a function is called at the scheduled time, re-arming timers of
+// no more than the maximum duration.
+func TestSchedule_At(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock()
+		called = make(chan time.Time, 1)
+		sut    = Schedule{Clock: clock, MaxTimerDuration: time.Hour}
+	)
+
+	// act
+	f := sut.At(time.Unix(9000, 0), func() { called <- clock.Now() })
+	timers := []time.Time{}
+	for i := range 3 {
+		waitFor(func() bool { return created(clock) > i })
+		next := clock.Timers()[i].Next
+		timers = append(timers, next)
+		clock.AdvanceTo(next)
+	}
+
+	// assert
+	test.That(t, timers).Equals([]time.Time{
+		time.Unix(3600, 0).UTC(),
+		time.Unix(7200, 0).UTC(),
+		time.Unix(9000, 0).UTC(),
+	})
+	test.That(t, <-called).Equals(time.Unix(9000, 0).UTC())
+	test.IsTrue(t, f.Fired())
+	test.IsFalse(t, f.Cancel())
+}
+
+// Tests that a function scheduled at a time that has passed is called immediately.
+func TestSchedule_At_Passed(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock(AtTime(time.Unix(100, 0)))
+		called = make(chan struct{})
+	)
+
+	// act
+	_ = Schedule{Clock: clock}.At(time.Unix(50, 0), func() { close(called) })
+
+	// assert
+	<-called
+}
+
+// Tests that a cancelled function is not called.
+func TestScheduledFunc_Cancel(t *testing.T) {
+	// arrange
+	var (
+		clock  = NewMockClock()
+		called = false
+		sut    = Schedule{Clock: clock}.At(time.Unix(10, 0), func() { called = true })
+	)
+
+	// act
+	result := sut.Cancel()
+	clock.AdvanceBy(time.Minute)
+
+	// assert
+	test.IsTrue(t, result)
+	test.IsFalse(t, called)
+	test.IsFalse(t, sut.Fired())
+	test.That(t, sut.At()).Equals(time.Unix(10, 0))
+}
@@ -0,0 +1,170 @@
+package time
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SessionExpiry determines the expiry of a session (or of a cookie or token
+// identifying one) according to a clock, with an absolute lifetime from the
+// time at which the session was created and a sliding (idle) lifetime from
+// the time at which it was last used.
+//
+// A session expires at the earlier of the two expirations; a lifetime of zero
+// does not limit the session, so that a session with neither lifetime does
+// not expire.
+type SessionExpiry struct {
+	mu       sync.Mutex
+	clock    Clock
+	absolute time.Duration
+	idle     time.Duration
+	created  time.Time
+	touched  time.Time
+}
+
+// NewSessionExpiry returns a SessionExpiry for a session created at the
+// current time of a given clock (or the system clock, if nil), with a given
+// absolute lifetime and idle lifetime.
+func NewSessionExpiry(clock Clock, absolute, idle time.Duration) *SessionExpiry {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	now := clock.Now()
+	return RestoreSessionExpiry(clock, absolute, idle, now, now)
+}
+
+// RestoreSessionExpiry returns a SessionExpiry for a session created and last
+// used at given times, such as a session restored from a session store, using
+// a given clock (or the system clock, if nil).
+func RestoreSessionExpiry(clock Clock, absolute, idle time.Duration, created, touched time.Time) *SessionExpiry {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &SessionExpiry{
+		clock:    clock,
+		absolute: max(absolute, 0),
+		idle:     max(idle, 0),
+		created:  created,
+		touched:  touched,
+	}
+}
+
+// Created returns the time at which the session was created.
+func (s *SessionExpiry) Created() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.created
+}
+
+// Touched returns the time at which the session was last used.
+func (s *SessionExpiry) Touched() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.touched
+}
+
+// Touch records the use of the session at the current time of the clock,
+// extending the idle expiry of the session.  It returns false (without
+// extending the expiry) if the session has already expired.
+func (s *SessionExpiry) Touch() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if s.expiredAt(now) {
+		return false
+	}
+	s.touched = now
+	return true
+}
+
+// ExpiresAt returns the time at which the session expires and true or, if the
+// session does not expire, the zero time and false.
+func (s *SessionExpiry) ExpiresAt() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.expiresAt()
+}
+
+// IsExpired returns true if the session has expired at the current time of
+// the clock.
+func (s *SessionExpiry) IsExpired() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.expiredAt(s.clock.Now())
+}
+
+// Remaining returns the duration until the session expires according to the
+// current time of the clock, zero if it has expired or -1 if the session does
+// not expire.
+func (s *SessionExpiry) Remaining() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at, ok := s.expiresAt()
+	if !ok {
+		return -1
+	}
+	return max(s.clock.Until(at), 0)
+}
+
+// SetCookie sets the Expires and MaxAge of a cookie identifying the session,
+// consistently with the expiry of the session:
+//
+//   - for a session that does not expire, the cookie is a session cookie,
+//     with no Expires or Max-Age attribute;
+//
+//   - for a session that has not expired, Expires is the time at which the
+//     session expires and MaxAge the remaining lifetime, in seconds (rounded
+//     up, so that a cookie does not expire before the session);
+//
+//   - for a session that has expired, Expires is the time at which the
+//     session expired and MaxAge is negative, deleting the cookie.
+func (s *SessionExpiry) SetCookie(c *http.Cookie) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at, ok := s.expiresAt()
+	if !ok {
+		c.Expires, c.MaxAge = time.Time{}, 0
+		return
+	}
+
+	c.Expires = at.UTC()
+	if remaining := s.clock.Until(at); remaining > 0 {
+		c.MaxAge = int((remaining + time.Second - 1) / time.Second)
+		return
+	}
+	c.MaxAge = -1
+}
+
+// expiresAt returns the time at which the session expires and true, or false
+// if the session does not expire.
+//
+// This method is not thread-safe and should only be called while the expiry
+// is locked.
+func (s *SessionExpiry) expiresAt() (time.Time, bool) {
+	switch {
+	case s.absolute == 0 && s.idle == 0:
+		return time.Time{}, false
+	case s.absolute == 0:
+		return s.touched.Add(s.idle), true
+	case s.idle == 0:
+		return s.created.Add(s.absolute), true
+	}
+	return MinTime(s.created.Add(s.absolute), s.touched.Add(s.idle)), true
+}
+
+// expiredAt returns true if the session has expired at a given time.
+//
+// This method is not thread-safe and should only be called while the expiry
+// is locked.
+func (s *SessionExpiry) expiredAt(t time.Time) bool {
+	at, ok := s.expiresAt()
+	return ok && !t.Before(at)
+}
@@ -0,0 +1,113 @@
+package time
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that a session expires at the earlier of its absolute and idle
+// expirations, and that touching the session extends the idle expiry.
+func TestSessionExpiry(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewSessionExpiry(clock, time.Hour, 20*time.Minute)
+
+	// act/assert
+	for range 3 {
+		clock.AdvanceBy(15 * time.Minute)
+		test.IsTrue(t, sut.Touch(), "touched")
+	}
+	at, ok := sut.ExpiresAt()
+	test.IsTrue(t, ok)
+	test.That(t, at).Equals(time.Unix(3600, 0).UTC())
+	test.That(t, sut.Remaining()).Equals(15 * time.Minute)
+
+	clock.AdvanceBy(15 * time.Minute)
+	test.IsTrue(t, sut.IsExpired(), "absolute expiry")
+	test.IsFalse(t, sut.Touch(), "touched when expired")
+	test.That(t, sut.Touched()).Equals(time.Unix(2700, 0).UTC())
+	test.That(t, sut.Remaining()).Equals(time.Duration(0))
+}
+
+func TestSessionExpiry_Lifetimes(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		absolute time.Duration
+		idle     time.Duration
+		elapsed  time.Duration
+		expired  bool
+	}{
+		{scenario: "no expiry", elapsed: 1000 * time.Hour},
+		{scenario: "idle only", idle: time.Minute, elapsed: time.Minute, expired: true},
+		{scenario: "idle only, not expired", idle: time.Minute, elapsed: 59 * time.Second},
+		{scenario: "absolute only", absolute: time.Hour, elapsed: time.Hour, expired: true},
+		{scenario: "idle before absolute", absolute: time.Hour, idle: time.Minute, elapsed: time.Minute, expired: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock()
+			sut := NewSessionExpiry(clock, tc.absolute, tc.idle)
+
+			// act
+			clock.AdvanceBy(tc.elapsed)
+
+			// assert
+			test.That(t, sut.IsExpired()).Equals(tc.expired)
+		})
+	}
+}
+
+func TestRestoreSessionExpiry(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Unix(1000, 0)))
+
+	// act
+	sut := RestoreSessionExpiry(clock, time.Hour, 10*time.Minute, time.Unix(0, 0), time.Unix(500, 0))
+
+	// assert
+	test.That(t, sut.Created()).Equals(time.Unix(0, 0))
+	test.That(t, sut.Remaining()).Equals(100 * time.Second)
+}
+
+func TestSessionExpiry_SetCookie(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		idle     time.Duration
+		elapsed  time.Duration
+		expires  time.Time
+		maxAge   int
+	}{
+		{scenario: "session cookie"},
+		{scenario: "not expired", idle: time.Minute, elapsed: 1500 * time.Millisecond, expires: time.Unix(60, 0).UTC(), maxAge: 59},
+		{scenario: "expired", idle: time.Minute, elapsed: time.Hour, expires: time.Unix(60, 0).UTC(), maxAge: -1},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock()
+			sut := NewSessionExpiry(clock, 0, tc.idle)
+			cookie := &http.Cookie{Name: "session", MaxAge: 10}
+			clock.AdvanceBy(tc.elapsed)
+
+			// act
+			sut.SetCookie(cookie)
+
+			// assert
+			test.That(t, cookie.Expires).Equals(tc.expires)
+			test.That(t, cookie.MaxAge).Equals(tc.maxAge)
+		})
+	}
+}
+
+func TestNewSessionExpiry_NilClock(t *testing.T) {
+	// act
+	result := NewSessionExpiry(nil, time.Hour, 0)
+
+	// assert
+	test.That(t, result.clock).Equals(SystemClock())
+	test.IsFalse(t, result.IsExpired())
+}
@@ -0,0 +1,199 @@
+package time
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SimChannel is a channel for which each value sent is delivered after a
+// latency (with optional jitter) determined by a Clock.  With a mock clock,
+// this allows network delay to be modelled in virtual time, alongside timers,
+// in tests of protocols.
+//
+// Values are received from the channel C.  Values are delivered in order of
+// the time at which they are due; values due at the same time are delivered
+// in the order in which they were sent.  Jitter may therefore cause values to
+// be delivered in a different order to that in which they were sent.
+//
+// Sending a value does not block; values that are due are queued for delivery
+// until they are received.
+type SimChannel[T any] struct {
+	// C is the channel on which values are delivered.  The channel is closed
+	// when the SimChannel is closed.
+	C <-chan T
+
+	c       chan T
+	clock   Clock
+	latency time.Duration
+	jitter  time.Duration
+
+	mu       sync.Mutex
+	seq      uint64
+	inflight []simMessage[T]
+	timers   map[uint64]*Timer
+	ready    []T
+	closed   bool
+	signal   chan struct{}
+	done     chan struct{}
+}
+
+// simMessage is a value sent on a SimChannel that has not yet been delivered.
+type simMessage[T any] struct {
+	seq   uint64
+	due   time.Time
+	value T
+}
+
+// NewSimChannel returns a SimChannel delivering values after a given latency,
+// according to a given clock, with an additional random delay of up to a given
+// jitter (which may be zero).
+//
+// A SimChannel must be closed when no longer required, to stop the goroutine
+// delivering values.
+func NewSimChannel[T any](clock Clock, latency, jitter time.Duration) *SimChannel[T] {
+	c := make(chan T)
+	s := &SimChannel[T]{
+		C:       c,
+		c:       c,
+		clock:   clock,
+		latency: max(latency, 0),
+		jitter:  max(jitter, 0),
+		timers:  map[uint64]*Timer{},
+		signal:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go s.deliver()
+	return s
+}
+
+// Send sends a value on the channel, to be delivered after the latency of the
+// channel (plus any jitter).
+//
+// Send panics if the channel has been closed.
+func (s *SimChannel[T]) Send(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		panic(fmt.Errorf("%w: send on closed SimChannel", ErrChannelClosed))
+	}
+
+	delay := s.latency
+	if s.jitter > 0 {
+		delay += rand.N(s.jitter + 1)
+	}
+
+	s.seq++
+	seq := s.seq
+	msg := simMessage[T]{seq: seq, due: s.clock.Now().Add(delay), value: v}
+
+	i, _ := slices.BinarySearchFunc(s.inflight, msg, compareSimMessages[T])
+	s.inflight = slices.Insert(s.inflight, i, msg)
+	s.timers[seq] = s.clock.AfterFunc(delay, func() { s.due(seq) })
+}
+
+// InFlight returns the number of values that have been sent but not yet
+// received from the channel.
+//
+// A value is counted until its delivery on C has completed, which may be
+// momentarily after the value has been received; InFlight therefore never
+// reports fewer values than remain to be received.
+func (s *SimChannel[T]) InFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.inflight) + len(s.ready)
+}
+
+// Close closes the channel; any values that have not been received are
+// discarded and C is closed.  Calling Close more than once has no effect.
+func (s *SimChannel[T]) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	for _, t := range s.timers {
+		t.Stop()
+	}
+	s.timers, s.inflight, s.ready = nil, nil, nil
+	close(s.done)
+}
+
+// due is called when the delay for the value with a given sequence number has
+// elapsed; all values that are due (including that value) are moved from the
+// in-flight list to the queue of values ready for delivery.
+func (s *SimChannel[T]) due(seq uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	delete(s.timers, seq)
+
+	now := s.clock.Now()
+	n := 0
+	for n < len(s.inflight) && (s.inflight[n].seq == seq || !s.inflight[n].due.After(now)) {
+		s.ready = append(s.ready, s.inflight[n].value)
+		delete(s.timers, s.inflight[n].seq)
+		n++
+	}
+	s.inflight = s.inflight[n:]
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends values that are ready for delivery on the channel, in order,
+// until the SimChannel is closed.
+func (s *SimChannel[T]) deliver() {
+	defer close(s.c)
+
+	for {
+		s.mu.Lock()
+		if len(s.ready) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-s.signal:
+				continue
+			case <-s.done:
+				return
+			}
+		}
+		// the value remains in the queue (and is counted by InFlight) until it
+		// has been sent; values made ready meanwhile are appended to the queue
+		v := s.ready[0]
+		s.mu.Unlock()
+
+		select {
+		case s.c <- v:
+		case <-s.done:
+			return
+		}
+
+		s.mu.Lock()
+		if !s.closed {
+			s.ready = s.ready[1:]
+		}
+		s.mu.Unlock()
+	}
+}
+
+// compareSimMessages orders messages by the time at which they are due and
+// then by the order in which they were sent.
+func compareSimMessages[T any](a, b simMessage[T]) int {
+	if c := a.due.Compare(b.due); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.seq, b.seq)
+}
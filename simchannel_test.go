@@ -0,0 +1,122 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestSimChannel(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewSimChannel[string](clock, 100*time.Millisecond, 0)
+	defer sut.Close()
+
+	// act
+	sut.Send("hello")
+	clock.AdvanceBy(99 * time.Millisecond)
+
+	// assert: not delivered before the latency has elapsed
+	select {
+	case v := <-sut.C:
+		t.Fatalf("delivered early: %q", v)
+	default:
+	}
+	test.That(t, sut.InFlight()).Equals(1)
+
+	// act
+	clock.AdvanceBy(time.Millisecond)
+
+	// assert
+	test.That(t, <-sut.C).Equals("hello")
+	test.IsTrue(t, waitFor(func() bool { return sut.InFlight() == 0 }))
+}
+
+// Tests that a value that is due is counted as in flight until it has been
+// received.
+func TestSimChannel_InFlight_UntilReceived(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewSimChannel[string](clock, time.Millisecond, 0)
+	defer sut.Close()
+
+	sut.Send("hello")
+
+	// act
+	clock.AdvanceBy(time.Millisecond)
+
+	// assert: the value remains in flight while it is not received
+	test.IsFalse(t, waitFor(func() bool { return sut.InFlight() != 1 }))
+	test.That(t, <-sut.C).Equals("hello")
+	test.IsTrue(t, waitFor(func() bool { return sut.InFlight() == 0 }))
+}
+
+// Tests that values are delivered in order of the time at which they are due
+// and then in the order in which they were sent.
+func TestSimChannel_Order(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewSimChannel[int](clock, 100*time.Millisecond, 0)
+	defer sut.Close()
+
+	sut.Send(1)
+	sut.Send(2)
+	clock.AdvanceBy(50 * time.Millisecond)
+	sut.Send(3)
+
+	// act
+	clock.AdvanceBy(time.Second)
+
+	// assert
+	test.That(t, []int{<-sut.C, <-sut.C, <-sut.C}).Equals([]int{1, 2, 3})
+}
+
+func TestSimChannel_Jitter(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewSimChannel[int](clock, 100*time.Millisecond, 50*time.Millisecond)
+	defer sut.Close()
+
+	for i := range 10 {
+		sut.Send(i)
+	}
+
+	// act/assert: nothing is delivered before the latency, everything is
+	// delivered by the latency plus jitter
+	clock.AdvanceBy(99 * time.Millisecond)
+	test.That(t, sut.InFlight()).Equals(10)
+
+	clock.AdvanceBy(51 * time.Millisecond)
+	for range 10 {
+		<-sut.C
+	}
+	test.IsTrue(t, waitFor(func() bool { return sut.InFlight() == 0 }))
+}
+
+func TestSimChannel_Close(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	sut := NewSimChannel[int](clock, time.Second, 0)
+	sut.Send(1)
+
+	// act
+	sut.Close()
+	sut.Close()
+	clock.AdvanceBy(time.Second)
+
+	// assert: undelivered values are discarded and the channel is closed
+	_, ok := <-sut.C
+	test.IsFalse(t, ok)
+	test.That(t, sut.InFlight()).Equals(0)
+}
+
+func TestSimChannel_SendWhenClosed(t *testing.T) {
+	// arrange
+	sut := NewSimChannel[int](NewMockClock(), time.Second, 0)
+	sut.Close()
+	defer test.ExpectPanic(ErrChannelClosed).Assert(t)
+
+	// act
+	sut.Send(1)
+}
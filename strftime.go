@@ -0,0 +1,232 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// strftimeLayouts maps strftime directives to equivalent time.Format layouts.
+var strftimeLayouts = map[byte]string{
+	'a': "Mon",
+	'A': "Monday",
+	'b': "Jan",
+	'B': "January",
+	'c': "Mon Jan _2 15:04:05 2006",
+	'd': "02",
+	'D': "01/02/06",
+	'e': "_2",
+	'F': "2006-01-02",
+	'h': "Jan",
+	'H': "15",
+	'I': "03",
+	'j': "002",
+	'm': "01",
+	'M': "04",
+	'p': "PM",
+	'r': "03:04:05 PM",
+	'R': "15:04",
+	'S': "05",
+	'T': "15:04:05",
+	'x': "01/02/06",
+	'X': "15:04:05",
+	'y': "06",
+	'Y': "2006",
+	'z': "-0700",
+	'Z': "MST",
+}
+
+// strftimeFuncs provides formatting for strftime directives which have no
+// equivalent time.Format layout; these directives are supported by Strftime
+// but not by Strptime.
+var strftimeFuncs = map[byte]func(time.Time) string{
+	'f': func(t time.Time) string { return fmt.Sprintf("%06d", t.Nanosecond()/1000) },
+	'G': func(t time.Time) string { y, _ := t.ISOWeek(); return strconv.Itoa(y) },
+	's': func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) },
+	'u': func(t time.Time) string { return strconv.Itoa(ISOWeekday(t)) },
+	'V': func(t time.Time) string { _, w := t.ISOWeek(); return fmt.Sprintf("%02d", w) },
+	'w': func(t time.Time) string { return strconv.Itoa(int(t.Weekday())) },
+}
+
+// strftimeLiterals maps strftime directives to the literal text they represent.
+var strftimeLiterals = map[byte]string{
+	'%': "%",
+	'n': "\n",
+	't': "\t",
+}
+
+// strftimeSegment is a segment of a compiled strftime format: a directive,
+// formatted using a layout or a function, or literal text.
+type strftimeSegment struct {
+	directive byte
+	layout    string
+	fn        func(time.Time) string
+	literal   string
+}
+
+var (
+	// compiled strftime formats, keyed by format
+	strftimeCache sync.Map // map[string][]strftimeSegment
+
+	// time.Parse layouts converted from strftime formats, keyed by format
+	strptimeCache sync.Map // map[string]strftimeConversion
+)
+
+type strftimeConversion struct {
+	layout string
+	err    error
+}
+
+// compileStrftime returns the segments of a strftime format.  Unrecognised
+// directives are treated as literal text.
+func compileStrftime(format string) []strftimeSegment {
+	if cached, ok := strftimeCache.Load(format); ok {
+		return cached.([]strftimeSegment)
+	}
+
+	var (
+		segments []strftimeSegment
+		literal  strings.Builder
+	)
+	flush := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, strftimeSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			literal.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		c := format[i]
+		if lit, ok := strftimeLiterals[c]; ok {
+			literal.WriteString(lit)
+			continue
+		}
+
+		flush()
+		switch {
+		case strftimeLayouts[c] != "":
+			segments = append(segments, strftimeSegment{directive: c, layout: strftimeLayouts[c]})
+		case strftimeFuncs[c] != nil:
+			segments = append(segments, strftimeSegment{directive: c, fn: strftimeFuncs[c]})
+		default:
+			segments = append(segments, strftimeSegment{directive: c, literal: "%" + string(c)})
+		}
+	}
+	flush()
+
+	strftimeCache.Store(format, segments)
+	return segments
+}
+
+// Strftime formats a time using a C-style strftime format, e.g. "%Y-%m-%d %H:%M:%S".
+//
+// The following directives are supported:
+//
+//	%a  abbreviated weekday name (Mon)      %A  full weekday name (Monday)
+//	%b  abbreviated month name (Jan)        %B  full month name (January)
+//	%c  date and time (Mon Jan  2 15:04:05 2006)
+//	%d  day of the month (02)               %D  equivalent to %m/%d/%y
+//	%e  space-padded day of the month ( 2)  %f  microseconds (000000)
+//	%F  equivalent to %Y-%m-%d              %G  ISO 8601 week-based year
+//	%h  equivalent to %b                    %H  hour, 24-hour clock (15)
+//	%I  hour, 12-hour clock (03)            %j  day of the year (002)
+//	%m  month (01)                          %M  minute (04)
+//	%n  newline                             %p  AM or PM
+//	%r  equivalent to %I:%M:%S %p           %R  equivalent to %H:%M
+//	%s  seconds since the Unix epoch        %S  second (05)
+//	%t  tab                                 %T  equivalent to %H:%M:%S
+//	%u  ISO 8601 day of the week (1-7)      %V  ISO 8601 week number (01-53)
+//	%w  day of the week (0-6, Sunday is 0)  %x  equivalent to %m/%d/%y
+//	%X  equivalent to %H:%M:%S              %y  year without century (06)
+//	%Y  year (2006)                         %z  numeric time zone offset (-0700)
+//	%Z  time zone abbreviation (MST)        %%  a literal %
+//
+// Unrecognised directives are copied to the result unchanged.  Formats are
+// compiled on first use and cached.
+func Strftime(t time.Time, format string) string {
+	sb := &strings.Builder{}
+	for _, seg := range compileStrftime(format) {
+		switch {
+		case seg.layout != "":
+			sb.WriteString(t.Format(seg.layout))
+		case seg.fn != nil:
+			sb.WriteString(seg.fn(t))
+		default:
+			sb.WriteString(seg.literal)
+		}
+	}
+	return sb.String()
+}
+
+// StrftimeLayout converts a C-style strftime format to an equivalent layout
+// for use with time.Parse or time.Format.
+//
+// An error wrapping ErrUnsupportedFormat is returned if the format contains a
+// directive with no equivalent layout element (%G, %s, %u, %V and %w, or %f
+// other than immediately following "%S." or "%S,"), an unrecognised directive,
+// or literal text which would be interpreted as a layout element (e.g. digits
+// or month or day names).
+//
+// Conversions are cached.
+func StrftimeLayout(format string) (string, error) {
+	if cached, ok := strptimeCache.Load(format); ok {
+		result := cached.(strftimeConversion)
+		return result.layout, result.err
+	}
+
+	layout, err := strftimeLayout(format)
+	strptimeCache.Store(format, strftimeConversion{layout, err})
+	return layout, err
+}
+
+// layoutTokens are substrings of literal text which would be interpreted as
+// elements of a layout.
+var layoutTokens = []string{"0", "1", "2", "3", "4", "5", "6", "7", "Jan", "Mon", "MST", "PM", "pm", "_2"}
+
+func strftimeLayout(format string) (string, error) {
+	sb := &strings.Builder{}
+	for _, seg := range compileStrftime(format) {
+		switch {
+		case seg.layout != "":
+			sb.WriteString(seg.layout)
+		case seg.directive == 'f':
+			// fractional seconds have a layout equivalent only when following
+			// seconds and a decimal point or comma
+			if s := sb.String(); !strings.HasSuffix(s, "05.") && !strings.HasSuffix(s, "05,") {
+				return "", fmt.Errorf("%w: %q: %%f must follow %%S and a decimal point or comma", ErrUnsupportedFormat, format)
+			}
+			sb.WriteString("000000")
+		case seg.directive != 0:
+			return "", fmt.Errorf("%w: %q: %%%c has no equivalent layout", ErrUnsupportedFormat, format, seg.directive)
+		default:
+			for _, token := range layoutTokens {
+				if strings.Contains(seg.literal, token) {
+					return "", fmt.Errorf("%w: %q: literal %q cannot be represented in a layout", ErrUnsupportedFormat, format, seg.literal)
+				}
+			}
+			sb.WriteString(seg.literal)
+		}
+	}
+	return sb.String(), nil
+}
+
+// Strptime parses a string using a C-style strftime format, e.g. "%Y-%m-%d %H:%M:%S".
+// The format is converted to an equivalent layout using StrftimeLayout; if the
+// format cannot be converted, an error wrapping ErrUnsupportedFormat is returned.
+//
+// As for time.Parse, in the absence of a time zone the time is parsed as UTC.
+func Strptime(s, format string) (time.Time, error) {
+	layout, err := StrftimeLayout(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(layout, s)
+}
@@ -0,0 +1,137 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestStrftime(t *testing.T) {
+	// arrange
+	sut := time.Date(2024, time.March, 5, 14, 7, 9, 123456789, time.UTC)
+
+	testcases := []struct {
+		format string
+		want   string
+	}{
+		{format: "%Y-%m-%d %H:%M:%S", want: "2024-03-05 14:07:09"},
+		{format: "%y%m%d", want: "240305"},
+		{format: "%a %A %b %B %h", want: "Tue Tuesday Mar March Mar"},
+		{format: "%e|%j", want: " 5|065"},
+		{format: "%I:%M %p", want: "02:07 PM"},
+		{format: "%D %F %R %T", want: "03/05/24 2024-03-05 14:07 14:07:09"},
+		{format: "%c", want: "Tue Mar  5 14:07:09 2024"},
+		{format: "%S.%f", want: "09.123456"},
+		{format: "%G-W%V-%u", want: "2024-W10-2"},
+		{format: "%w %s", want: "2 1709647629"},
+		{format: "%z %Z", want: "+0000 UTC"},
+		{format: "100%% %n%t", want: "100% \n\t"},
+		{format: "literal 2006 Jan", want: "literal 2006 Jan"},
+		{format: "%Q unknown", want: "%Q unknown"},
+		{format: "trailing %", want: "trailing %"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.format, func(t *testing.T) {
+			// act
+			result := Strftime(sut, tc.format)
+
+			// assert
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestStrftimeLayout(t *testing.T) {
+	testcases := []struct {
+		format string
+		want   string
+		err    error
+	}{
+		{format: "%Y-%m-%dT%H:%M:%S%z", want: "2006-01-02T15:04:05-0700"},
+		{format: "%S.%f", want: "05.000000"},
+		{format: "%S,%f", want: "05,000000"},
+		{format: "%H%%", want: "15%"},
+		{format: "%f", err: ErrUnsupportedFormat},
+		{format: "%s", err: ErrUnsupportedFormat},
+		{format: "%Q", err: ErrUnsupportedFormat},
+		{format: "%Y year 1", err: ErrUnsupportedFormat},
+		{format: "%d Monday", err: ErrUnsupportedFormat},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.format, func(t *testing.T) {
+			// act
+			result, err := StrftimeLayout(tc.format)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+// Tests that a converted layout is cached, including any error.
+func TestStrftimeLayout_Cached(t *testing.T) {
+	// arrange
+	_, _ = StrftimeLayout("%H:%M")
+	_, _ = StrftimeLayout("%s")
+
+	// act
+	ok, cached := strptimeCache.Load("%H:%M")
+	failed, _ := strptimeCache.Load("%s")
+
+	// assert
+	test.IsTrue(t, cached)
+	test.That(t, ok.(strftimeConversion).layout).Equals("15:04")
+	test.Error(t, failed.(strftimeConversion).err).Is(ErrUnsupportedFormat)
+}
+
+func TestStrptime(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		input    string
+		format   string
+		want     time.Time
+		err      error
+	}{
+		{scenario: "date and time",
+			input:  "2024-03-05 14:07:09",
+			format: "%Y-%m-%d %H:%M:%S",
+			want:   time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC),
+		},
+		{scenario: "fractional seconds and offset",
+			input:  "05/Mar/2024:14:07:09.123456 +0100",
+			format: "%d/%b/%Y:%H:%M:%S.%f %z",
+			want:   time.Date(2024, time.March, 5, 13, 7, 9, 123456000, time.UTC),
+		},
+		{scenario: "12-hour clock",
+			input:  "March 5, 2024 02:07 PM",
+			format: "%B %e, %Y %I:%M %p",
+			want:   time.Date(2024, time.March, 5, 14, 7, 0, 0, time.UTC),
+		},
+		{scenario: "unsupported format",
+			input:  "1709647629",
+			format: "%s",
+			err:    ErrUnsupportedFormat,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			result, err := Strptime(tc.input, tc.format)
+
+			// assert
+			test.Error(t, err).Is(tc.err)
+			test.IsTrue(t, result.Equal(tc.want))
+		})
+	}
+}
+
+// Tests that a value not matching the format returns the time.Parse error.
+func TestStrptime_NoMatch(t *testing.T) {
+	// act
+	_, err := Strptime("not a date", "%Y-%m-%d")
+
+	// assert
+	test.IsNotNil(t, err)
+}
@@ -2,6 +2,7 @@ package time
 
 import (
 	"slices"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -27,13 +28,27 @@ func (state tickerState) String() string {
 }
 
 // tickable is an interface that represents a mock timer or ticker.
-// It provides methods to get the id, change state, get the next tick time,
-// and perform the tick action.
+// It provides methods to get the id, description and current state info,
+// change state, get the next tick time, and perform the tick action.
 type tickable interface {
 	id() int
+	describe() string
+	info() TimerInfo
 	enterState(state tickerState)
 	nextTick() time.Time
 	tick(time.Time) bool
+
+	// postponed returns true if the next tick has been considered for
+	// postponement (see: WithChaos)
+	postponed() bool
+
+	// postpone delays the next tick by a given duration (which may be zero),
+	// marking the next tick as having been considered for postponement
+	postpone(time.Duration)
+
+	// abandoned returns true if the Timer or Ticker wrapping the tickable
+	// is no longer reachable (see: mockClock.abandon)
+	abandoned() bool
 }
 
 // tickables represents a list of mock tickables; it supports sorting by
@@ -54,6 +69,14 @@ func (a tickables) get(id int) tickable {
 	return nil
 }
 
+// insert returns the tickables with a tickable inserted in order of next tick
+// time, after any tickables with the same next tick time.
+func (a tickables) insert(t tickable) tickables {
+	next := t.nextTick()
+	idx := sort.Search(len(a), func(i int) bool { return a[i].nextTick().After(next) })
+	return slices.Insert(a, idx, t)
+}
+
 // take if a tickable with the given id is present in the tickers, a new tickers is
 // returned with the tickable.  The returned slice has the MockTimer removed.
 //
@@ -2,6 +2,7 @@ package time
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -57,6 +58,54 @@ func (t *Ticker) Reset(d time.Duration) {
 	t.Ticker.Reset(d)
 }
 
+// Skipped returns the number of tick intervals that have been skipped since
+// Skipped was last called (or since the ticker was created), and resets the
+// count.
+//
+// Intervals are skipped only by a ticker obtained from a mock clock created
+// with the CoalescesTicks option, when the clock is advanced by more than one
+// interval of the ticker; the skipped intervals are counted before the
+// coalesced tick is sent, so a reader calling Skipped after receiving a tick
+// obtains the number of intervals skipped before that tick.
+//
+// For a Ticker obtained from the system clock, Skipped always returns 0.
+func (t *Ticker) Skipped() int {
+	if !t.isMocked() {
+		return 0
+	}
+	return int(atomic.SwapInt32(&t.ticker.skipped, 0))
+}
+
+// Dropped returns the number of ticks that have been dropped since Dropped
+// was last called (or since the ticker was created), and resets the count.
+//
+// Ticks are dropped only by a ticker obtained from a mock clock with a
+// TickerPolicy that limits the number of ticks that may be queued (see:
+// WhenFull).
+//
+// For a Ticker obtained from the system clock, Dropped always returns 0.
+func (t *Ticker) Dropped() int {
+	if !t.isMocked() {
+		return 0
+	}
+	return int(atomic.SwapInt32(&t.ticker.dropped, 0))
+}
+
+// WhenFull sets the policy that determines what happens when the ticker ticks
+// while a previous tick has not been received from its channel, returning the
+// Ticker to allow the policy to be set when the ticker is created:
+//
+//	ticker := clock.NewTicker(time.Second).WhenFull(time.DropWhenFull)
+//
+// The policy applies only to a ticker obtained from a mock clock; a Ticker
+// obtained from the system clock always drops ticks that cannot be delivered.
+func (t *Ticker) WhenFull(p TickerPolicy) *Ticker {
+	if t.isMocked() {
+		t.clock.withLock(func(*mockClock) { t.ticker.policy = p })
+	}
+	return t
+}
+
 // Stop stops the ticker and prevents any further ticks from being sent to
 // the channel; the channel is not closed.
 func (t *Ticker) Stop() {
@@ -70,18 +119,111 @@ func (t *Ticker) Stop() {
 // ticker implements the behaviour of a Ticker using a mock clock.
 type ticker struct {
 	tickerId int
+	name     string
+	stack    string
 	c        chan time.Time
 	d        time.Duration
 	next     time.Time
 	state    tickerState
 	clock    *mockClock
+
+	// late is the duration by which the next tick has been postponed and
+	// isPostponed indicates whether the next tick has been considered for
+	// postponement (see: WithChaos)
+	late        time.Duration
+	isPostponed bool
+
+	// isAbandoned indicates whether the Ticker wrapping the ticker is no
+	// longer reachable (see: mockClock.abandon)
+	isAbandoned bool
+
+	// pending is the number of ticks that have been sent by the ticker
+	// but not yet received from the channel (accessed atomically)
+	pending int32
+
+	// skipped is the number of intervals skipped by coalesced ticks since
+	// the count was last reset by Skipped (accessed atomically)
+	skipped int32
+
+	// policy determines the delivery of a tick when a previous tick has not
+	// been received and dropped is the number of ticks dropped as a result
+	// since the count was last reset by Dropped (accessed atomically)
+	policy  TickerPolicy
+	dropped int32
+}
+
+// TickerPolicy determines what happens when a mock ticker ticks while a
+// previous tick has not been received from the channel of the ticker (see:
+// Ticker.WhenFull).
+//
+// The zero value delivers every tick, in order, however many ticks have not
+// yet been received.
+type TickerPolicy struct {
+	// blocks indicates that each tick is sent by the goroutine advancing the
+	// clock, blocking the advance until the channel is able to receive it
+	blocks bool
+
+	// bounded indicates that no more than limit ticks may be queued in
+	// addition to a tick buffered by the channel; further ticks are dropped
+	bounded bool
+	limit   int32
+}
+
+var (
+	// BlockWhenFull is a TickerPolicy that blocks the advance of the clock
+	// until a tick that cannot be buffered by the channel is received.
+	BlockWhenFull = TickerPolicy{blocks: true}
+
+	// DropWhenFull is a TickerPolicy that drops a tick that cannot be
+	// buffered by the channel, as for a ticker from the standard library.
+	DropWhenFull = TickerPolicy{bounded: true}
+)
+
+// QueueWhenFull returns a TickerPolicy that queues up to n ticks that cannot
+// be buffered by the channel, dropping any ticks beyond that.
+//
+// The function panics if n is negative.
+func QueueWhenFull(n int) TickerPolicy {
+	if n < 0 {
+		panic(fmt.Errorf("%w: %d ticks for QueueWhenFull", errNegativeQueueLength, n))
+	}
+	return TickerPolicy{bounded: true, limit: int32(n)}
 }
 
 // id returns the id of the ticker.
-func (mock ticker) id() int {
+func (mock *ticker) id() int {
 	return mock.tickerId
 }
 
+// describe returns a description of the ticker for use in diagnostics,
+// identifying the ticker by name (if it has one) or by id.
+func (mock *ticker) describe() string {
+	if mock.name != "" {
+		return fmt.Sprintf("Ticker %q", mock.name)
+	}
+	return fmt.Sprintf("Ticker #%d", mock.tickerId)
+}
+
+// info returns a description of the current state of the ticker.
+func (mock *ticker) info() TimerInfo {
+	return TimerInfo{
+		ID:       mock.tickerId,
+		Name:     mock.name,
+		Stack:    mock.stack,
+		Kind:     "Ticker",
+		State:    mock.state.String(),
+		Next:     mock.nextTick(),
+		Interval: mock.d,
+		Pending:  int(atomic.LoadInt32(&mock.pending)),
+	}
+}
+
+// abandoned returns true if the Ticker wrapping the ticker is no longer
+// reachable.
+func (mock *ticker) abandoned() bool {
+	return mock.isAbandoned
+}
+
 // enterState handles the transition of the ticker to a new state.
 // It will panic if the transition is invalid or if the state is not
 // supported by the ticker.
@@ -97,15 +239,29 @@ func (mock *ticker) enterState(state tickerState) {
 	case tsStopped:
 		mock.clock.disableTicker(mock.tickerId)
 	case tsExpired:
-		panic(fmt.Errorf("%w: %s is not supported by a ticker", errInvalidTransition, state))
+		panic(fmt.Errorf("%w: %s is not supported by %s", errInvalidTransition, state, mock.describe()))
 	default:
-		panic(fmt.Errorf("%w: %s", errInvalidState, state))
+		panic(fmt.Errorf("%w: %s: %s", errInvalidState, mock.describe(), state))
 	}
 }
 
-// nextTick returns the next tick time for the ticker.
-func (mock ticker) nextTick() time.Time {
-	return mock.next
+// nextTick returns the next tick time for the ticker, including any
+// postponement of the tick.
+func (mock *ticker) nextTick() time.Time {
+	return mock.next.Add(mock.late)
+}
+
+// postponed returns true if the next tick has been considered for postponement.
+func (mock *ticker) postponed() bool {
+	return mock.isPostponed
+}
+
+// postpone delays the next tick by a given duration.  A tick is not delayed
+// beyond the interval of the ticker, so that ticks remain in order; postponing
+// a tick does not change the times of any subsequent ticks.
+func (mock *ticker) postpone(d time.Duration) {
+	mock.late = max(min(d, mock.d-1), 0)
+	mock.isPostponed = true
 }
 
 // reset resets the ticker to the specified duration.
@@ -114,7 +270,7 @@ func (mock ticker) nextTick() time.Time {
 // of the time.Ticker type in the standard library.
 func (t *ticker) reset(d time.Duration) {
 	if d <= 0 {
-		panic(fmt.Errorf("%w for Ticker", errNonPositiveInterval))
+		panic(fmt.Errorf("%w for %s", errNonPositiveInterval, t.describe()))
 	}
 	t.clock.resetTicker(t, d)
 }
@@ -122,6 +278,8 @@ func (t *ticker) reset(d time.Duration) {
 // stop stops the ticker and prevents any further ticks from being sent to
 func (t *ticker) stop() {
 	if t.state == tsActive {
+		t.clock.tracefNow("stop: %s", t.describe())
+		t.clock.recordNow("stop", t.info(), 0)
 		t.enterState(tsStopped)
 	}
 }
@@ -129,27 +287,68 @@ func (t *ticker) stop() {
 // tick is called to tick the ticker at the given time
 // it returns true if the ticker should tick, false otherwise.
 func (t *ticker) tick(now time.Time) bool {
-	if t == nil || t.state != tsActive || t.next.After(now) {
+	if t == nil || t.state != tsActive || t.nextTick().After(now) {
 		return false
 	}
 
 	// record the next time at which the tick should occur and update
-	// the next tick time to be the next interval
-	at := t.next
-	t.next = t.next.Add(t.d)
-
-	// if the clock is dropping ticks then we skip forward to the final
-	// tick that occurs at/before now
-	if t.clock.dropsTicks {
-		for !t.next.After(now) {
-			at = t.next
-			t.next = t.next.Add(t.d)
+	// the next tick time to be the next interval (the next tick time is
+	// read by the clock to determine the next deadline, so is updated
+	// while the clock is locked)
+	var at time.Time
+	t.clock.withLock(func(*mockClock) {
+		at = t.next
+		t.next = t.next.Add(t.d)
+		t.late, t.isPostponed = 0, false
+
+		// if the clock is dropping (or coalescing) ticks then we skip forward
+		// to the final tick that occurs at/before now, counting any skipped
+		// intervals if coalescing (the clock may also coalesce ticks at random,
+		// if chaos is enabled)
+		coalesces := t.clock.coalescesTicks || (!t.next.After(now) && t.clock.chaos.coalesces())
+		if t.clock.dropsTicks || coalesces {
+			skipped := int32(0)
+			for !t.next.After(now) {
+				at = t.next
+				t.next = t.next.Add(t.d)
+				skipped++
+			}
+			if coalesces && skipped > 0 {
+				atomic.AddInt32(&t.skipped, skipped)
+			}
 		}
+	})
+
+	// if the number of ticks that may be queued (in addition to a tick
+	// buffered by the channel) is limited and has been reached, the tick
+	// is dropped
+	if t.policy.bounded && int32(len(t.c))+atomic.LoadInt32(&t.pending) > t.policy.limit {
+		atomic.AddInt32(&t.dropped, 1)
+		t.clock.tracef(at, "drop: %s", t.describe())
+		t.clock.record(at, "drop", t.info(), 0)
+		return true
 	}
 
+	t.clock.tracef(at, "tick: %s", t.describe())
+	t.clock.record(at, "tick", t.info(), 0)
+
 	// tick at the time that was determined and yield to allow any goroutines
 	// that may be waiting on the ticker channel to be scheduled
-	go func() { t.clock.withLock(func(c *mockClock) { c.now = at }); t.c <- at }()
+	send := func() {
+		defer atomic.AddInt32(&t.pending, -1)
+		t.clock.withLock(func(c *mockClock) {
+			c.advanceNowTo(at)
+			c.deliveries.Done()
+		})
+		t.c <- at
+	}
+	atomic.AddInt32(&t.pending, 1)
+	t.clock.deliveries.Add(1)
+	if t.policy.blocks {
+		send()
+	} else {
+		go send()
+	}
 	time.Sleep(t.clock.yield)
 
 	return true
@@ -86,3 +86,56 @@ func TestTicker_Tick_NextInTheFuture(t *testing.T) {
 	// assert: expect false
 	test.IsFalse(t, result)
 }
+
+func TestTicker_Reset_ZeroDuration_Named(t *testing.T) {
+	ticker := NewMockClock().NewTickerNamed(1*time.Millisecond, "rebalance")
+	defer func() {
+		err, _ := recover().(error)
+		test.Error(t, err).Is(errNonPositiveInterval)
+		test.String(t, err.Error()).Contains(`Ticker "rebalance"`)
+	}()
+
+	ticker.Reset(0)
+}
+
+func TestTicker_Describe(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      ticker
+		want     string
+	}{
+		{scenario: "anonymous", sut: ticker{tickerId: 3}, want: "Ticker #3"},
+		{scenario: "named", sut: ticker{tickerId: 3, name: "rebalance"}, want: `Ticker "rebalance"`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.That(t, tc.sut.describe()).Equals(tc.want)
+		})
+	}
+}
+
+func TestTicker_Skipped_SystemClock(t *testing.T) {
+	// arrange
+	ticker := SystemClock().NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	time.Sleep(5 * time.Millisecond)
+
+	// act
+	result := ticker.Skipped()
+
+	// assert
+	test.That(t, result).Equals(0)
+}
+
+func TestTicker_Dropped_SystemClock(t *testing.T) {
+	// arrange
+	ticker := SystemClock().NewTicker(time.Millisecond).WhenFull(QueueWhenFull(1))
+	defer ticker.Stop()
+	time.Sleep(5 * time.Millisecond)
+
+	// act
+	result := ticker.Dropped()
+
+	// assert
+	test.That(t, result).Equals(0)
+}
@@ -26,16 +26,34 @@ func NewTicker(ctx context.Context, d Duration) *Ticker {
 	return ClockFromContext(ctx).NewTicker(d)
 }
 
+// NewTickerNamed returns a new Ticker with a given name from the Clock in the given context.
+// The name identifies the Ticker in diagnostics provided by a mock clock.
+func NewTickerNamed(ctx context.Context, d Duration, name string) *Ticker {
+	return ClockFromContext(ctx).NewTickerNamed(d, name)
+}
+
 func NewTimer(ctx context.Context, d Duration) *Timer {
 	return ClockFromContext(ctx).NewTimer(d)
 }
 
+// NewTimerNamed returns a new Timer with a given name from the Clock in the given context.
+// The name identifies the Timer in diagnostics provided by a mock clock.
+func NewTimerNamed(ctx context.Context, d Duration, name string) *Timer {
+	return ClockFromContext(ctx).NewTimerNamed(d, name)
+}
+
 // Now returns the current time from the Clock in the given context. If there is no clock in the
-// context, the real-time clock will be used.
+// context, the default clock (see: Default) is used.
 func Now(ctx context.Context) Time {
 	return ClockFromContext(ctx).Now()
 }
 
+// NowIn returns the current time, in a given location, from the Clock in the given context. If
+// there is no clock in the context, the default clock (see: Default) is used.
+func NowIn(ctx context.Context, loc *Location) Time {
+	return ClockFromContext(ctx).Now().In(loc)
+}
+
 func Tick(ctx context.Context, d Duration) <-chan Time {
 	return ClockFromContext(ctx).Tick(d)
 }
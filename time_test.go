@@ -40,6 +40,24 @@ func TestNewTicker(t *testing.T) {
 	test.Value(t, ticks.Load()).Equals(5)
 }
 
+func TestNewTickerNamed(t *testing.T) {
+	ctx, clock := ContextWithMockClock(context.Background())
+	var ticks atomic.Int32
+
+	// act
+	ticker := NewTickerNamed(ctx, 10*time.Millisecond, "poll")
+	go func() {
+		for range ticker.C {
+			ticks.Add(1)
+		}
+	}()
+	clock.AdvanceBy(50 * time.Millisecond)
+
+	// assert
+	test.Value(t, ticker.name).Equals("poll")
+	test.Value(t, ticks.Load()).Equals(5)
+}
+
 func TestNewTimer(t *testing.T) {
 	ctx, clock := ContextWithMockClock(context.Background())
 	var ticked atomic.Bool
@@ -56,6 +74,23 @@ func TestNewTimer(t *testing.T) {
 	test.IsTrue(t, ticked.Load())
 }
 
+func TestNewTimerNamed(t *testing.T) {
+	ctx, clock := ContextWithMockClock(context.Background())
+	var ticked atomic.Bool
+
+	// act
+	timer := NewTimerNamed(ctx, 10*time.Millisecond, "rebalance")
+	go func() {
+		<-timer.C
+		ticked.Store(true)
+	}()
+	clock.AdvanceBy(10 * time.Millisecond)
+
+	// assert
+	test.Value(t, timer.name).Equals("rebalance")
+	test.IsTrue(t, ticked.Load())
+}
+
 func TestNow(t *testing.T) {
 	tm := time.Date(2023, 10, 1, 2, 3, 4, 5, time.UTC)
 	ctx, _ := ContextWithMockClock(context.Background(), AtTime(tm))
@@ -67,6 +102,18 @@ func TestNow(t *testing.T) {
 	test.Value(t, now).Equals(tm)
 }
 
+func TestNowIn(t *testing.T) {
+	tm := time.Date(2023, 10, 1, 2, 3, 4, 5, time.UTC)
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	ctx, _ := ContextWithMockClock(context.Background(), AtTime(tm))
+
+	// act
+	now := NowIn(ctx, loc)
+
+	// assert
+	test.Value(t, now).Equals(tm.In(loc))
+}
+
 func TestSleep(t *testing.T) {
 	var (
 		ctx, clock = ContextWithMockClock(context.Background())
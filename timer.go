@@ -2,6 +2,7 @@ package time
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,8 +24,20 @@ type Timer struct {
 	// non-nil only when timer is mocked
 	*timer
 
+	// non-nil only when timer is multiplexed (see: TimerMux)
+	mux *muxTimer
+
 	// indicates whether the timer has been initialized
 	initialised bool
+
+	// the time at which a standard library timer is due to expire; used to
+	// emulate Pause for a timer that is not mocked
+	deadline time.Time
+
+	// indicates whether the timer is paused and, if so, the duration that
+	// remained when it was paused
+	paused    bool
+	remaining time.Duration
 }
 
 // isMocked returns true if the timer is a mock timer, false if it is a
@@ -43,39 +56,149 @@ func (t *Timer) Reset(d time.Duration) bool {
 		panic(fmt.Errorf("%w Timer", errResetCalledOnUninitialized))
 	}
 
+	t.paused = false
+
 	// if the timer is mocked, use the mock's Reset method
 	if t.isMocked() {
 		return t.timer.reset(d)
 	}
+	if t.mux != nil {
+		return t.mux.reset(d)
+	}
 
+	t.deadline = time.Now().Add(d)
 	return t.Timer.Reset(d)
 }
 
 // Stop prevents the Timer from firing. It returns true if the call stops the
 // timer, false if the timer has already expired or been stopped.
+//
+// A paused Timer is stopped; it cannot be resumed once Stop has been called.
 func (t *Timer) Stop() bool {
+	t.paused = false
+
 	// if the timer is mocked, use the mock's Stop method
 	if t.isMocked() {
 		return t.timer.stop()
 	}
+	if t.mux != nil {
+		return t.mux.stop()
+	}
 	return t.Timer.Stop()
 }
 
+// Pause suspends the countdown of an active Timer, returning the duration that
+// remained before the Timer would have expired.  The countdown may be continued
+// by calling Resume.
+//
+// If the Timer is already paused, the duration that remained when it was paused
+// is returned.  If the Timer has expired or been stopped it cannot be paused and
+// zero is returned.
+//
+// For a Timer obtained from a mock clock the remaining duration is determined
+// by the time of the clock.  For other timers the remaining duration is that
+// since the Timer was created or last reset, according to the system clock.
+//
+// Pause and Resume must not be called concurrently with each other or with
+// Reset or Stop.
+func (t *Timer) Pause() time.Duration {
+	if t.paused {
+		return t.remaining
+	}
+
+	var (
+		remaining time.Duration
+		stopped   bool
+	)
+	switch {
+	case t.isMocked():
+		remaining, stopped = t.timer.pause()
+	case t.mux != nil:
+		remaining, stopped = t.mux.pause()
+	default:
+		remaining, stopped = time.Until(t.deadline), t.Timer.Stop()
+	}
+	if !stopped {
+		return 0
+	}
+
+	t.paused, t.remaining = true, max(remaining, 0)
+	return t.remaining
+}
+
+// Resume continues the countdown of a paused Timer, which will expire after
+// the duration that remained when it was paused.  It returns true if the Timer
+// was resumed, false if the Timer was not paused.
+func (t *Timer) Resume() bool {
+	if !t.paused {
+		return false
+	}
+	_ = t.Reset(t.remaining)
+	return true
+}
+
 // timer implements the behaviour of a Timer with a mock clock.
 type timer struct {
 	tickerId int
+	name     string
+	stack    string
 	c        chan time.Time
 	fn       func()
 	next     time.Time
 	state    tickerState
 	clock    *mockClock
+
+	// isPostponed indicates whether the expiry of the timer has been
+	// considered for postponement (see: WithChaos)
+	isPostponed bool
+
+	// isAbandoned indicates whether the Timer wrapping the timer is no
+	// longer reachable (see: mockClock.abandon)
+	isAbandoned bool
+
+	// pending is the number of times that the timer has fired without the
+	// time being received from the channel or the function returning
+	// (accessed atomically)
+	pending int32
 }
 
 // id returns the id of the timer.
-func (mock timer) id() int {
+func (mock *timer) id() int {
 	return mock.tickerId
 }
 
+// describe returns a description of the timer for use in diagnostics,
+// identifying the timer by name (if it has one) or by id.
+func (mock *timer) describe() string {
+	if mock.name != "" {
+		return fmt.Sprintf("Timer %q", mock.name)
+	}
+	return fmt.Sprintf("Timer #%d", mock.tickerId)
+}
+
+// info returns a description of the current state of the timer.
+func (mock *timer) info() TimerInfo {
+	kind := "Timer"
+	if mock.fn != nil {
+		kind = "AfterFunc"
+	}
+	return TimerInfo{
+		ID:      mock.tickerId,
+		Name:    mock.name,
+		Stack:   mock.stack,
+		Kind:    kind,
+		State:   mock.state.String(),
+		Next:    mock.next,
+		Pending: int(atomic.LoadInt32(&mock.pending)),
+	}
+}
+
+// abandoned returns true if the Timer wrapping the timer is no longer
+// reachable.
+func (mock *timer) abandoned() bool {
+	return mock.isAbandoned
+}
+
 // enterState handles the state transition of the timer.
 //
 // It will panic if the transition is invalid or if the state is not
@@ -95,15 +218,27 @@ func (mock *timer) enterState(state tickerState) {
 	case tsStopped:
 		mock.clock.disableTicker(mock.tickerId)
 	default:
-		panic(fmt.Errorf("%w: %s", errInvalidState, state))
+		panic(fmt.Errorf("%w: %s: %s", errInvalidState, mock.describe(), state))
 	}
 }
 
 // nextTick returns the next tick time for the timer.
-func (mock timer) nextTick() time.Time {
+func (mock *timer) nextTick() time.Time {
 	return mock.next
 }
 
+// postponed returns true if the expiry of the timer has been considered for
+// postponement.
+func (mock *timer) postponed() bool {
+	return mock.isPostponed
+}
+
+// postpone delays the expiry of the timer by a given duration.
+func (mock *timer) postpone(d time.Duration) {
+	mock.next = mock.next.Add(d)
+	mock.isPostponed = true
+}
+
 // reset modifies the timer to expire after duration d from the current time.
 // If the timer has already expired it is re-activated.
 // Returns true if the timer was already active, false if the timer had
@@ -121,24 +256,71 @@ func (t *timer) reset(d time.Duration) bool {
 func (t *timer) stop() bool {
 	wasActive := t.state == tsActive
 	if wasActive {
+		t.clock.tracefNow("stop: %s", t.describe())
+		t.clock.recordNow("stop", t.info(), 0)
 		t.enterState(tsStopped)
 	}
 
 	return wasActive
 }
 
+// pause stops the timer, returning the duration remaining before the timer
+// would have expired and true if the timer was active, otherwise false.
+func (t *timer) pause() (time.Duration, bool) {
+	if t.state != tsActive {
+		return 0, false
+	}
+
+	remaining := eval(t.clock, func() time.Duration { return t.next.Sub(t.clock.now) })
+	t.clock.tracefNow("pause: %s, remaining: %s", t.describe(), remaining)
+	t.clock.recordNow("pause", t.info(), remaining)
+	t.enterState(tsStopped)
+
+	return remaining, true
+}
+
 // tick is called to tick the timer at the given time.
 func (t *timer) tick(now time.Time) bool {
 	if t == nil || t.state != tsActive || t.next.After(now) {
 		return false
 	}
 	t.enterState(tsExpired)
+	t.clock.tracef(t.next, "fire: %s", t.describe())
+	t.clock.record(t.next, "fire", t.info(), 0)
+
+	// the timer may be reset before the goroutine delivering the expiry runs,
+	// so the time at which it expired is captured
+	at := t.next
 
 	switch {
 	case t.fn != nil:
-		go func() { t.clock.now = t.next; t.fn() }()
+		atomic.AddInt32(&t.pending, 1)
+		t.clock.deliveries.Add(1)
+		go func() {
+			defer atomic.AddInt32(&t.pending, -1)
+
+			// the panic recovery of the clock is captured while the clock is
+			// locked, since the clock may be reset while the function runs
+			var recovery *panicRecovery
+			t.clock.withLock(func(c *mockClock) {
+				c.advanceNowTo(at)
+				c.deliveries.Done()
+				recovery = c.panics
+			})
+			defer t.clock.recoverPanic(t, at, recovery)
+			t.fn()
+		}()
 	case t.c != nil:
-		go func() { t.clock.now = t.next; t.c <- t.next }()
+		atomic.AddInt32(&t.pending, 1)
+		t.clock.deliveries.Add(1)
+		go func() {
+			defer atomic.AddInt32(&t.pending, -1)
+			t.clock.withLock(func(c *mockClock) {
+				c.advanceNowTo(at)
+				c.deliveries.Done()
+			})
+			t.c <- at
+		}()
 	}
 	time.Sleep(t.clock.yield)
 
@@ -60,3 +60,101 @@ func TestTimer_Tick_Premature(t *testing.T) {
 	// assert: expect false
 	test.IsFalse(t, result)
 }
+
+func TestTimer_Describe(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		sut      timer
+		want     string
+	}{
+		{scenario: "anonymous", sut: timer{tickerId: 3}, want: "Timer #3"},
+		{scenario: "named", sut: timer{tickerId: 3, name: "rebalance"}, want: `Timer "rebalance"`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			test.That(t, tc.sut.describe()).Equals(tc.want)
+		})
+	}
+}
+
+func TestTimer_PauseResume(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	timer := clock.NewTimer(10 * time.Second)
+	clock.AdvanceBy(4 * time.Second)
+
+	// act: pause the timer and advance beyond the original expiry
+	remaining := timer.Pause()
+	clock.AdvanceBy(time.Minute)
+
+	// assert: the timer has not expired
+	test.That(t, remaining).Equals(6 * time.Second)
+	test.That(t, timer.Pause()).Equals(6*time.Second, "when already paused")
+	test.That(t, clock.Timers()[0].State).Equals("stopped")
+
+	// act: resume the timer
+	resumed := timer.Resume()
+	clock.AdvanceBy(6 * time.Second)
+
+	// assert: the timer expires after the remaining duration
+	test.IsTrue(t, resumed)
+	test.That(t, <-timer.C).Equals(time.Unix(70, 0).UTC())
+	test.IsFalse(t, timer.Resume(), "when not paused")
+}
+
+func TestTimer_Pause_WhenExpired(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	timer := clock.AfterFunc(time.Second, func() {})
+	clock.AdvanceBy(time.Second)
+
+	// act
+	remaining := timer.Pause()
+
+	// assert
+	test.That(t, remaining).Equals(time.Duration(0))
+	test.IsFalse(t, timer.Resume())
+}
+
+// Tests that a paused timer cannot be resumed once stopped.
+func TestTimer_Pause_ThenStop(t *testing.T) {
+	// arrange
+	clock := NewMockClock()
+	timer := clock.NewTimer(time.Second)
+	_ = timer.Pause()
+
+	// act
+	stopped := timer.Stop()
+
+	// assert
+	test.IsFalse(t, stopped)
+	test.IsFalse(t, timer.Resume())
+}
+
+func TestTimer_PauseResume_SystemClock(t *testing.T) {
+	// arrange
+	fired := make(chan struct{})
+	timer := SystemClock().AfterFunc(50*time.Millisecond, func() { close(fired) })
+
+	// act
+	remaining := timer.Pause()
+	time.Sleep(100 * time.Millisecond)
+
+	// assert: the timer did not fire while paused
+	test.IsTrue(t, remaining > 0 && remaining <= 50*time.Millisecond)
+	select {
+	case <-fired:
+		t.Fatal("timer fired while paused")
+	default:
+	}
+
+	// act: resume the timer
+	test.IsTrue(t, timer.Resume())
+
+	// assert: the timer fires
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("timer did not fire after being resumed")
+	}
+}
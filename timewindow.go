@@ -0,0 +1,148 @@
+package time
+
+import (
+	"strconv"
+	"time"
+)
+
+// ActiveBetween returns true if the current time of a clock is at or after a
+// given start time and before a given end time.  A zero start or end time is
+// unbounded.
+//
+// This is useful for feature flags and the like that are to be active only
+// for a period of time, so that the activation may be tested using a mock
+// clock.
+func ActiveBetween(clock Clock, start, end time.Time) bool {
+	now := clock.Now()
+	return (start.IsZero() || !now.Before(start)) && (end.IsZero() || now.Before(end))
+}
+
+// Recurrence determines whether (and how) a Window recurs.
+type Recurrence int
+
+const (
+	// NoRecurrence is a window that does not recur.
+	NoRecurrence Recurrence = iota
+
+	// Daily is a window that recurs at the same times of day every day.
+	Daily
+
+	// Weekly is a window that recurs at the same times of day on the same day
+	// every week.
+	Weekly
+)
+
+// String returns the name of the recurrence.
+func (r Recurrence) String() string {
+	switch r {
+	case NoRecurrence:
+		return "NoRecurrence"
+	case Daily:
+		return "Daily"
+	case Weekly:
+		return "Weekly"
+	}
+	return "<invalid Recurrence(" + strconv.Itoa(int(r)) + ")>"
+}
+
+// days returns the number of days between occurrences of a recurring window.
+func (r Recurrence) days() int {
+	if r == Weekly {
+		return 7
+	}
+	return 1
+}
+
+// Window is a window of time, such as a nightly maintenance window, which may
+// recur daily or weekly from a first occurrence.
+//
+// A recurring window recurs at the same wall-clock times in the location of
+// its start and end times, so that (for example) a nightly window from 01:00
+// to 03:00 starts at 01:00 local time on each side of a daylight saving
+// transition.  The duration of a recurring window must be less than the
+// interval at which it recurs.
+//
+// Any recurrence other than those defined by this package is treated as Daily.
+type Window struct {
+	// Start is the start of the first occurrence of the window.
+	Start time.Time
+
+	// End is the end of the first occurrence of the window.
+	End time.Time
+
+	// Recurs determines whether the window recurs.
+	Recurs Recurrence
+}
+
+// IsActive returns true if the current time of a clock is within an
+// occurrence of the window.
+func (w Window) IsActive(clock Clock) bool {
+	return w.IsActiveAt(clock.Now())
+}
+
+// IsActiveAt returns true if a given time is within an occurrence of the window.
+func (w Window) IsActiveAt(t time.Time) bool {
+	k, ok := w.latest(t)
+	if !ok {
+		return false
+	}
+	_, end := w.occurrence(k)
+	return t.Before(end)
+}
+
+// NextTransition returns the time, after the current time of a clock, at
+// which the window next becomes active or inactive and whether the window is
+// active after that transition.  If there are no further transitions (the
+// window does not recur and has ended) the zero time is returned.
+func (w Window) NextTransition(clock Clock) (at time.Time, active bool) {
+	return w.NextTransitionAfter(clock.Now())
+}
+
+// NextTransitionAfter returns the time, after a given time, at which the
+// window next becomes active or inactive (see NextTransition).
+func (w Window) NextTransitionAfter(t time.Time) (at time.Time, active bool) {
+	k, ok := w.latest(t)
+	if !ok {
+		return w.Start, true
+	}
+	if _, end := w.occurrence(k); t.Before(end) {
+		return end, false
+	}
+	if w.Recurs == NoRecurrence {
+		return time.Time{}, false
+	}
+	start, _ := w.occurrence(k + 1)
+	return start, true
+}
+
+// occurrence returns the start and end of the k-th occurrence of the window,
+// where the first occurrence is occurrence 0.
+func (w Window) occurrence(k int) (start, end time.Time) {
+	days := k * w.Recurs.days()
+	return w.Start.AddDate(0, 0, days), w.End.AddDate(0, 0, days)
+}
+
+// latest returns the latest occurrence of the window starting at or before a
+// given time and true, or false if the first occurrence starts after that time.
+func (w Window) latest(t time.Time) (int, bool) {
+	if t.Before(w.Start) {
+		return 0, false
+	}
+	if w.Recurs == NoRecurrence {
+		return 0, true
+	}
+
+	// an estimate based on a fixed period is adjusted for any daylight saving
+	// transitions between the first occurrence and the given time
+	period := time.Duration(w.Recurs.days()) * 24 * time.Hour
+	start := func(k int) time.Time { s, _ := w.occurrence(k); return s }
+
+	k := int(t.Sub(w.Start) / period)
+	for k > 0 && start(k).After(t) {
+		k--
+	}
+	for !start(k + 1).After(t) {
+		k++
+	}
+	return k, true
+}
@@ -0,0 +1,102 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestActiveBetween(t *testing.T) {
+	var (
+		start = time.Unix(100, 0)
+		end   = time.Unix(200, 0)
+	)
+
+	testcases := []struct {
+		scenario string
+		now      time.Time
+		start    time.Time
+		end      time.Time
+		result   bool
+	}{
+		{scenario: "before start", now: time.Unix(99, 0), start: start, end: end},
+		{scenario: "at start", now: start, start: start, end: end, result: true},
+		{scenario: "at end", now: end, start: start, end: end},
+		{scenario: "unbounded start", now: time.Unix(0, 0), end: end, result: true},
+		{scenario: "unbounded end", now: time.Unix(1000, 0), start: start, result: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(tc.now))
+
+			// act
+			result := ActiveBetween(clock, tc.start, tc.end)
+
+			// assert
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	at := func(d, hh int) time.Time { return time.Date(2024, 1, d, hh, 0, 0, 0, time.UTC) }
+	nightly := Window{Start: at(1, 1), End: at(1, 3), Recurs: Daily}
+
+	testcases := []struct {
+		scenario string
+		sut      Window
+		now      time.Time
+		active   bool
+		next     time.Time
+		activate bool
+	}{
+		{scenario: "before first occurrence", sut: nightly, now: at(1, 0), next: at(1, 1), activate: true},
+		{scenario: "in first occurrence", sut: nightly, now: at(1, 1), active: true, next: at(1, 3)},
+		{scenario: "between occurrences", sut: nightly, now: at(1, 3), next: at(2, 1), activate: true},
+		{scenario: "in later occurrence", sut: nightly, now: at(20, 2), active: true, next: at(20, 3)},
+		{scenario: "weekly", sut: Window{Start: at(1, 1), End: at(1, 3), Recurs: Weekly}, now: at(2, 1), next: at(8, 1), activate: true},
+		{scenario: "one-off ended", sut: Window{Start: at(1, 1), End: at(1, 3)}, now: at(2, 1)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(tc.now))
+
+			// act
+			active := tc.sut.IsActive(clock)
+			next, activate := tc.sut.NextTransition(clock)
+
+			// assert
+			test.That(t, active).Equals(tc.active)
+			test.That(t, next).Equals(tc.next)
+			test.That(t, activate).Equals(tc.activate)
+		})
+	}
+}
+
+// Tests that a recurring window recurs at the same local times across a
+// daylight saving transition.
+func TestWindow_DST(t *testing.T) {
+	// arrange
+	loc, err := time.LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+	sut := Window{
+		Start:  time.Date(2024, 3, 1, 4, 0, 0, 0, loc),
+		End:    time.Date(2024, 3, 1, 5, 0, 0, 0, loc),
+		Recurs: Daily,
+	}
+
+	// act
+	next, _ := sut.NextTransitionAfter(time.Date(2024, 4, 1, 0, 0, 0, 0, loc))
+
+	// assert
+	test.That(t, next).Equals(time.Date(2024, 4, 1, 4, 0, 0, 0, loc))
+	test.IsTrue(t, sut.IsActiveAt(time.Date(2024, 4, 1, 4, 30, 0, 0, loc)))
+}
+
+func TestRecurrence_String(t *testing.T) {
+	test.That(t, Daily.String()).Equals("Daily")
+	test.That(t, Recurrence(-1).String()).Equals("<invalid Recurrence(-1)>")
+}
@@ -0,0 +1,102 @@
+package time
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// TOTPCounter returns the RFC 6238 time step counter for the current time of a
+// clock: the number of periods elapsed since the Unix epoch.  A period of 30
+// seconds is conventional; a period that is not positive is treated as 30
+// seconds.
+func TOTPCounter(clock Clock, period time.Duration) uint64 {
+	return totpCounter(clock.Now(), totpPeriod(period))
+}
+
+// TOTPStep returns the start and end of the RFC 6238 time step containing the
+// current time of a clock; a code for the step is valid from the start time
+// and until (but excluding) the end time.
+func TOTPStep(clock Clock, period time.Duration) (start, end time.Time) {
+	period = totpPeriod(period)
+	now := clock.Now()
+	start = time.Unix(0, 0).Add(time.Duration(totpCounter(now, period)) * period).In(now.Location())
+	return start, start.Add(period)
+}
+
+// ValidWindow returns the range of time step counters accepted at the current
+// time of a clock, allowing for a given number of steps of clock skew either
+// side of the current step.  A skew of 1 (accepting the previous and next
+// codes) is recommended by RFC 6238.
+func ValidWindow(clock Clock, period time.Duration, skew int) (first, last uint64) {
+	n := TOTPCounter(clock, period)
+	s := uint64(max(skew, 0))
+	return n - min(n, s), n + s
+}
+
+// HOTP returns the RFC 4226 HMAC-based one-time password for a given secret
+// and counter, with a given number of digits (from 6 to 10; other values are
+// treated as 6).
+func HOTP(secret []byte, counter uint64, digits int) string {
+	if digits < 6 || digits > 10 {
+		digits = 6
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	code := uint64(binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff)
+
+	mod := uint64(1)
+	for range digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, code%mod)
+}
+
+// TOTP returns the RFC 6238 time-based one-time password for a given secret at
+// the current time of a clock (see: TOTPCounter and HOTP).
+func TOTP(clock Clock, secret []byte, period time.Duration, digits int) string {
+	return HOTP(secret, TOTPCounter(clock, period), digits)
+}
+
+// VerifyTOTP returns true if a code is a valid time-based one-time password
+// for a given secret at the current time of a clock, accepting the codes of
+// the time steps within a given skew of the current step (see: ValidWindow).
+func VerifyTOTP(clock Clock, secret []byte, code string, period time.Duration, digits int, skew int) bool {
+	first, last := ValidWindow(clock, period, skew)
+	for n := first; n <= last; n++ {
+		if subtle.ConstantTimeCompare([]byte(HOTP(secret, n, digits)), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// totpPeriod returns a given period, or 30 seconds if the period is not
+// positive.
+func totpPeriod(period time.Duration) time.Duration {
+	if period <= 0 {
+		return 30 * time.Second
+	}
+	return period
+}
+
+// totpCounter returns the number of periods elapsed from the Unix epoch to a
+// given time (zero for a time before the epoch).
+func totpCounter(t time.Time, period time.Duration) uint64 {
+	d := t.Sub(time.Unix(0, 0))
+	if d < 0 {
+		return 0
+	}
+	return uint64(d / period)
+}
@@ -0,0 +1,97 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// the secret of the test vectors of RFC 4226 and RFC 6238 (SHA-1)
+var rfcSecret = []byte("12345678901234567890")
+
+func TestHOTP(t *testing.T) {
+	testcases := []struct {
+		counter uint64
+		want    string
+	}{
+		{counter: 0, want: "755224"},
+		{counter: 1, want: "287082"},
+		{counter: 9, want: "520489"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.want, func(t *testing.T) {
+			test.That(t, HOTP(rfcSecret, tc.counter, 6)).Equals(tc.want)
+		})
+	}
+}
+
+func TestTOTP(t *testing.T) {
+	testcases := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "94287082"},
+		{unix: 1111111109, want: "07081804"},
+		{unix: 1234567890, want: "89005924"},
+		{unix: 2000000000, want: "69279037"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.want, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(time.Unix(tc.unix, 0)))
+
+			// act
+			result := TOTP(clock, rfcSecret, 30*time.Second, 8)
+
+			// assert
+			test.That(t, result).Equals(tc.want)
+		})
+	}
+}
+
+func TestTOTPCounter(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Unix(59, 0)))
+
+	// act
+	counter := TOTPCounter(clock, 0)
+	start, end := TOTPStep(clock, 30*time.Second)
+
+	// assert
+	test.That(t, counter).Equals(uint64(1))
+	test.That(t, start).Equals(time.Unix(30, 0).UTC())
+	test.That(t, end).Equals(time.Unix(60, 0).UTC())
+}
+
+func TestValidWindow(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Unix(59, 0)))
+
+	// act
+	first, last := ValidWindow(clock, 30*time.Second, 2)
+
+	// assert: the window does not extend before the first step
+	test.That(t, first).Equals(uint64(0))
+	test.That(t, last).Equals(uint64(3))
+}
+
+// Tests that a code is accepted within the skew tolerance as the clock is
+// advanced, and rejected once the tolerance is exceeded.
+func TestVerifyTOTP(t *testing.T) {
+	// arrange
+	clock := NewMockClock(AtTime(time.Unix(1111111109, 0)))
+	code := TOTP(clock, rfcSecret, 30*time.Second, 6)
+
+	// act
+	current := VerifyTOTP(clock, rfcSecret, code, 30*time.Second, 6, 1)
+	clock.AdvanceBy(30 * time.Second)
+	next := VerifyTOTP(clock, rfcSecret, code, 30*time.Second, 6, 1)
+	clock.AdvanceBy(30 * time.Second)
+	expired := VerifyTOTP(clock, rfcSecret, code, 30*time.Second, 6, 1)
+
+	// assert
+	test.IsTrue(t, current)
+	test.IsTrue(t, next)
+	test.IsFalse(t, expired)
+}
@@ -0,0 +1,53 @@
+package time
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// localTZ holds the location set using WithTZ, or nil if the local time zone
+// of the process (time.Local) is used by the system clock.
+var localTZ atomic.Pointer[time.Location]
+
+// localLocation returns the location used by the system clock: the location
+// set using WithTZ, if any, or time.Local.
+func localLocation() *time.Location {
+	if loc := localTZ.Load(); loc != nil {
+		return loc
+	}
+	return time.Local
+}
+
+// WithTZ sets the local time zone used by the system clock of this package to a
+// named location (as for time.LoadLocation) for the duration of a test,
+// restoring the previous time zone when the test (and its subtests) complete.
+//
+// While set, the location is used by the Date and ParseInLocation methods of
+// the system clock, and the Now method returns the current time in that
+// location (without a monotonic clock reading).  This allows date-boundary bugs
+// to be reproduced regardless of the time zone of the machine running the
+// tests.  The local time zone of the standard library (time.Local, and so
+// time.Now, Time.Local and Local) is not changed; the TZ environment variable
+// is set to the name for the duration of the test, so that any child process
+// started by the test uses the location.
+//
+// Since the time zone is shared by all tests in the process, a test that sets
+// it must not run in parallel with other tests.  WithTZ panics if called from a
+// test that has called t.Parallel, and a test that calls t.Parallel after WithTZ
+// panics.  If the location cannot be loaded the test fails.
+func WithTZ(t testing.TB, name string) {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("WithTZ: %v", err)
+	}
+
+	// t.Setenv panics in (and prevents) parallel tests, guarding the time
+	// zone in the same way as the process environment
+	t.Setenv("TZ", name)
+
+	prev := localTZ.Swap(loc)
+	t.Cleanup(func() { localTZ.Store(prev) })
+}
@@ -0,0 +1,60 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestWithTZ(t *testing.T) {
+	// arrange
+	prev := time.Local.String()
+
+	t.Run("in test", func(t *testing.T) {
+		// act
+		WithTZ(t, "America/New_York")
+
+		// assert
+		test.That(t, SystemClock().Now().Location().String()).Equals("America/New_York")
+		test.That(t, SystemClock().Date(2024, 7, 1, 0, 0, 0, 0).UTC().Hour()).Equals(4)
+
+		parsed, err := SystemClock().ParseInLocation(time.DateTime, "2024-01-01 00:00:00")
+		test.Error(t, err).IsNil()
+		test.That(t, parsed.UTC().Hour()).Equals(5)
+
+		test.That(t, time.Local.String()).Equals(prev)
+		test.That(t, Local.String()).Equals(prev)
+	})
+
+	// assert
+	test.That(t, SystemClock().Now().Location()).Equals(time.Local)
+	test.That(t, SystemClock().Date(2024, 7, 1, 0, 0, 0, 0).Location()).Equals(time.Local)
+}
+
+// fatalTB is a fakeTB for which Fatalf records the failure and panics (in
+// place of stopping the goroutine of the test).
+type fatalTB struct {
+	fakeTB
+}
+
+func (tb *fatalTB) Fatalf(format string, args ...any) {
+	tb.Errorf(format, args...)
+	panic(tb)
+}
+
+func TestWithTZ_InvalidName(t *testing.T) {
+	// arrange
+	tb := &fatalTB{}
+	prev := time.Local.String()
+
+	// act
+	func() {
+		defer func() { _ = recover() }()
+		WithTZ(tb, "Not/A_Zone")
+	}()
+
+	// assert
+	test.That(t, len(tb.errors)).Equals(1)
+	test.That(t, SystemClock().Now().Location().String()).Equals(prev)
+}
@@ -0,0 +1,272 @@
+package time
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WeeklySchedule is a schedule of periods that recur weekly, such as opening
+// hours, in a given location.
+//
+// A schedule is parsed from a specification (see ParseWeeklySchedule) and
+// evaluated against the current time of a clock, so that code depending on
+// the schedule (such as the routing of alerts) may be tested using a mock
+// clock.
+type WeeklySchedule struct {
+	spec    string
+	loc     *time.Location
+	periods []weeklyPeriod
+}
+
+// weeklyPeriod is a period of a WeeklySchedule, starting on a given day at a
+// time of day (in minutes since midnight) and ending at a time of day on the
+// same day or, if the end is not after the start, the following day.
+type weeklyPeriod struct {
+	day        time.Weekday
+	start, end int
+}
+
+// ParseWeeklySchedule parses a weekly schedule in a given location (if nil,
+// UTC).  The specification is a list of rules, separated by semi-colons, each
+// comprising one or more days and one or more periods of the day:
+//
+//	Mon-Fri 09:00-17:30; Sat 10:00-14:00
+//	Mon,Wed,Fri 08:00-12:00,13:00-17:00
+//	Fri 22:00-02:00
+//
+// Days are identified by name or abbreviation (case insensitive); a range of
+// days may wrap around the end of the week (e.g. Fri-Mon).  A period of the day
+// is a start and end time (hh:mm, with 24:00 denoting the end of the day);
+// a period that ends at or before its start continues into the following day.
+//
+// If the specification is not valid an error wrapping ErrInvalidSchedule is
+// returned.
+func ParseWeeklySchedule(spec string, loc *time.Location) (WeeklySchedule, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	s := WeeklySchedule{spec: spec, loc: loc}
+
+	invalid := func(rule, reason string) error {
+		return fmt.Errorf("%w: %q: %s", ErrInvalidSchedule, rule, reason)
+	}
+
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		fields := strings.Fields(rule)
+		if len(fields) != 2 {
+			return WeeklySchedule{}, invalid(rule, "expected days and periods")
+		}
+
+		days, err := parseWeekdays(fields[0])
+		if err != nil {
+			return WeeklySchedule{}, invalid(rule, err.Error())
+		}
+
+		for _, period := range strings.Split(fields[1], ",") {
+			from, to, ok := strings.Cut(period, "-")
+			if !ok {
+				return WeeklySchedule{}, invalid(rule, "invalid period: "+period)
+			}
+			start, err := parseScheduleTime(from, false)
+			if err != nil {
+				return WeeklySchedule{}, invalid(rule, err.Error())
+			}
+			end, err := parseScheduleTime(to, true)
+			if err != nil {
+				return WeeklySchedule{}, invalid(rule, err.Error())
+			}
+			for _, day := range days {
+				s.periods = append(s.periods, weeklyPeriod{day: day, start: start, end: end})
+			}
+		}
+	}
+	return s, nil
+}
+
+// parseWeekdays parses a comma-separated list of days or ranges of days.
+func parseWeekdays(s string) ([]time.Weekday, error) {
+	day := func(name string) (time.Weekday, error) {
+		d, ok := relativeWeekdays[strings.ToLower(name)]
+		if !ok {
+			return 0, fmt.Errorf("invalid day: %s", name)
+		}
+		return d, nil
+	}
+
+	var result []time.Weekday
+	for _, item := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(item, "-")
+		first, err := day(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = day(to); err != nil {
+				return nil, err
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			result = append(result, d)
+			if d == last {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// parseScheduleTime parses a time of day (hh:mm) returning the number of minutes
+// since midnight.  If end is true, 24:00 is accepted.
+func parseScheduleTime(s string, end bool) (int, error) {
+	hs, ms, ok := strings.Cut(s, ":")
+	h, herr := strconv.Atoi(hs)
+	m, merr := strconv.Atoi(ms)
+	switch {
+	case !ok || len(hs) != 2 || len(ms) != 2 || herr != nil || merr != nil,
+		h < 0 || m < 0 || m > 59,
+		h > 24 || h == 24 && (m > 0 || !end):
+		return 0, fmt.Errorf("invalid time: %s", s)
+	}
+	return h*60 + m, nil
+}
+
+// String returns the specification from which the schedule was parsed.
+func (s WeeklySchedule) String() string {
+	return s.spec
+}
+
+// Location returns the location in which the schedule is evaluated.
+func (s WeeklySchedule) Location() *time.Location {
+	if s.loc == nil {
+		return time.UTC
+	}
+	return s.loc
+}
+
+// IsOpen returns true if the current time of a clock is within a period of
+// the schedule.
+func (s WeeklySchedule) IsOpen(clock Clock) bool {
+	return s.IsOpenAt(clock.Now())
+}
+
+// IsOpenAt returns true if a given time is within a period of the schedule.
+func (s WeeklySchedule) IsOpenAt(t time.Time) bool {
+	_, ok := s.containing(t)
+	return ok
+}
+
+// NextOpen returns the time at which the schedule next opens, after the
+// current time of a clock.  If the schedule is open, the current time is
+// returned; if the schedule has no periods, the zero time is returned.
+func (s WeeklySchedule) NextOpen(clock Clock) time.Time {
+	return s.NextOpenAfter(clock.Now())
+}
+
+// NextOpenAfter returns the time at which the schedule next opens after a
+// given time (see NextOpen).
+func (s WeeklySchedule) NextOpenAfter(t time.Time) time.Time {
+	if _, ok := s.containing(t); ok {
+		return t
+	}
+	for _, p := range s.intervals(t) {
+		if p.start.After(t) {
+			return p.start
+		}
+	}
+	return time.Time{}
+}
+
+// NextClose returns the time at which the schedule next closes, after the
+// current time of a clock.  If the schedule is closed, this is the time at
+// which the next period ends; if the schedule is always open (or has no
+// periods), the zero time is returned.
+func (s WeeklySchedule) NextClose(clock Clock) time.Time {
+	return s.NextCloseAfter(clock.Now())
+}
+
+// NextCloseAfter returns the time at which the schedule next closes after a
+// given time (see NextClose).
+func (s WeeklySchedule) NextCloseAfter(t time.Time) time.Time {
+	if p, ok := s.containing(t); ok {
+		if p.end.Sub(t) >= 7*24*time.Hour {
+			return time.Time{}
+		}
+		return p.end
+	}
+	for _, p := range s.intervals(t) {
+		if p.start.After(t) {
+			return p.end
+		}
+	}
+	return time.Time{}
+}
+
+// scheduleInterval is a period of a schedule resolved to absolute times.
+type scheduleInterval struct {
+	start, end time.Time
+}
+
+// containing returns the (merged) interval of the schedule containing a given
+// time and true, or false if the time is not within a period of the schedule.
+func (s WeeklySchedule) containing(t time.Time) (scheduleInterval, bool) {
+	for _, p := range s.intervals(t) {
+		if !t.Before(p.start) && t.Before(p.end) {
+			return p, true
+		}
+	}
+	return scheduleInterval{}, false
+}
+
+// intervals returns the periods of the schedule that start from the day before
+// a given time up to eight days after it, resolved to absolute times in the
+// location of the schedule, with overlapping or adjacent periods merged.
+//
+// Times of day that do not exist on a given day (due to a daylight saving
+// transition) are normalised in the same way as time.Date; e.g. a period
+// starting at 02:30 on a day on which clocks go forward at 02:00 by an hour
+// starts at 03:30.
+func (s WeeklySchedule) intervals(t time.Time) []scheduleInterval {
+	loc := s.Location()
+	t = t.In(loc)
+	y, m, d := t.Date()
+
+	var result []scheduleInterval
+	for offset := -1; offset <= 8; offset++ {
+		day := time.Date(y, m, d+offset, 0, 0, 0, 0, loc)
+		for _, p := range s.periods {
+			if p.day != day.Weekday() {
+				continue
+			}
+			dy, dm, dd := day.Date()
+			start := time.Date(dy, dm, dd, 0, p.start, 0, 0, loc)
+			end := time.Date(dy, dm, dd, 0, p.end, 0, 0, loc)
+			if p.end <= p.start {
+				end = time.Date(dy, dm, dd+1, 0, p.end, 0, 0, loc)
+			}
+			result = append(result, scheduleInterval{start: start, end: end})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b scheduleInterval) int { return a.start.Compare(b.start) })
+
+	merged := result[:0]
+	for _, p := range result {
+		if n := len(merged); n > 0 && !p.start.After(merged[n-1].end) {
+			if p.end.After(merged[n-1].end) {
+				merged[n-1].end = p.end
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+	return merged
+}
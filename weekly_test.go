@@ -0,0 +1,112 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+func TestParseWeeklySchedule_Invalid(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		spec     string
+	}{
+		{scenario: "missing periods", spec: "Mon-Fri"},
+		{scenario: "invalid day", spec: "Mun 09:00-17:00"},
+		{scenario: "invalid period", spec: "Mon 09:00"},
+		{scenario: "invalid time", spec: "Mon 9:00-17:00"},
+		{scenario: "hour out of range", spec: "Mon 09:00-25:00"},
+		{scenario: "start at end of day", spec: "Mon 24:00-02:00"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			_, err := ParseWeeklySchedule(tc.spec, nil)
+
+			// assert
+			test.Error(t, err).Is(ErrInvalidSchedule)
+		})
+	}
+}
+
+func TestWeeklySchedule(t *testing.T) {
+	at := func(d, hh, mm int) time.Time { return time.Date(2024, 1, d, hh, mm, 0, 0, time.UTC) }
+
+	testcases := []struct {
+		scenario  string
+		spec      string
+		now       time.Time
+		open      bool
+		nextOpen  time.Time
+		nextClose time.Time
+	}{
+		{scenario: "before opening",
+			spec: "Mon-Fri 09:00-17:30; Sat 10:00-14:00", now: at(1, 8, 0),
+			nextOpen: at(1, 9, 0), nextClose: at(1, 17, 30),
+		},
+		{scenario: "open",
+			spec: "Mon-Fri 09:00-17:30; Sat 10:00-14:00", now: at(1, 12, 0),
+			open: true, nextOpen: at(1, 12, 0), nextClose: at(1, 17, 30),
+		},
+		{scenario: "at closing",
+			spec: "Mon-Fri 09:00-17:30; Sat 10:00-14:00", now: at(5, 17, 30),
+			nextOpen: at(6, 10, 0), nextClose: at(6, 14, 0),
+		},
+		{scenario: "weekend",
+			spec: "Mon-Fri 09:00-17:30; Sat 10:00-14:00", now: at(6, 15, 0),
+			nextOpen: at(8, 9, 0), nextClose: at(8, 17, 30),
+		},
+		{scenario: "multiple periods",
+			spec: "Mon,Wed 08:00-12:00,13:00-17:00", now: at(3, 12, 30),
+			nextOpen: at(3, 13, 0), nextClose: at(3, 17, 0),
+		},
+		{scenario: "overnight",
+			spec: "Fri 22:00-02:00", now: at(6, 1, 0),
+			open: true, nextOpen: at(6, 1, 0), nextClose: at(6, 2, 0),
+		},
+		{scenario: "adjacent periods merged",
+			spec: "Mon 00:00-24:00; Tue 00:00-12:00", now: at(1, 23, 0),
+			open: true, nextOpen: at(1, 23, 0), nextClose: at(2, 12, 0),
+		},
+		{scenario: "always open",
+			spec: "Mon-Sun 00:00-24:00", now: at(1, 12, 0),
+			open: true, nextOpen: at(1, 12, 0),
+		},
+		{scenario: "no periods",
+			spec: "", now: at(1, 12, 0),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// arrange
+			clock := NewMockClock(AtTime(tc.now))
+			sut, err := ParseWeeklySchedule(tc.spec, nil)
+			test.Error(t, err).IsNil()
+
+			// act & assert
+			test.That(t, sut.IsOpen(clock)).Equals(tc.open)
+			test.That(t, sut.NextOpen(clock)).Equals(tc.nextOpen)
+			test.That(t, sut.NextClose(clock)).Equals(tc.nextClose)
+		})
+	}
+}
+
+// Tests that a period starting at a time that does not exist due to a
+// daylight saving transition starts at the normalised time.
+func TestWeeklySchedule_DST(t *testing.T) {
+	// arrange
+	loc, err := time.LoadLocation("Europe/London")
+	test.Error(t, err).IsNil()
+
+	clock := NewMockClock(AtTime(time.Date(2024, 3, 31, 0, 0, 0, 0, loc)))
+	sut, _ := ParseWeeklySchedule("Sun 01:30-03:00", loc)
+
+	// act
+	result := sut.NextOpen(clock)
+
+	// assert
+	test.That(t, result).Equals(time.Date(2024, 3, 31, 2, 30, 0, 0, loc))
+	test.That(t, sut.NextClose(clock).Sub(result)).Equals(30 * time.Minute)
+	test.That(t, sut.String()).Equals("Sun 01:30-03:00")
+}
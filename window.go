@@ -0,0 +1,215 @@
+package time
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RollingCounter counts events over a rolling window divided into a fixed
+// number of buckets of equal width.  As time passes (according to a clock)
+// the oldest bucket is discarded and a new, empty bucket is started, so that
+// the count reflects only the events in the most recent window (to a
+// resolution of one bucket).
+//
+// Buckets are rotated when the counter is accessed, so a RollingCounter
+// requires no goroutine or timer; a counter using a mock clock rotates as the
+// mock clock is advanced.
+type RollingCounter struct {
+	mu      sync.Mutex
+	clock   Clock
+	width   time.Duration
+	buckets []int64
+	head    int
+	start   time.Time
+}
+
+// NewRollingCounter returns a RollingCounter over a given window, divided
+// into a given number of buckets, using a given clock (or the system clock,
+// if nil).  The window must be greater than zero and the number of buckets
+// must be at least one and no greater than the window; otherwise
+// NewRollingCounter will panic.
+func NewRollingCounter(clock Clock, window time.Duration, buckets int) *RollingCounter {
+	if window <= 0 || buckets < 1 || time.Duration(buckets) > window {
+		panic(fmt.Errorf("%w for NewRollingCounter: window %s, buckets %d", errNonPositiveInterval, window, buckets))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	return &RollingCounter{
+		clock:   clock,
+		width:   window / time.Duration(buckets),
+		buckets: make([]int64, buckets),
+		start:   clock.Now(),
+	}
+}
+
+// Add adds n to the count in the current bucket.
+func (c *RollingCounter) Add(n int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rotate()
+	c.buckets[c.head] += n
+}
+
+// Inc adds one to the count in the current bucket.
+func (c *RollingCounter) Inc() {
+	c.Add(1)
+}
+
+// Buckets returns the counts in each bucket of the window, oldest first.
+func (c *RollingCounter) Buckets() []int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rotate()
+	n := len(c.buckets)
+	result := make([]int64, n)
+	for i := range n {
+		result[i] = c.buckets[(c.head+1+i)%n]
+	}
+	return result
+}
+
+// Sum returns the total count over the window.
+func (c *RollingCounter) Sum() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rotate()
+	var sum int64
+	for _, n := range c.buckets {
+		sum += n
+	}
+	return sum
+}
+
+// Rate returns the average number of events per second over the window.
+func (c *RollingCounter) Rate() float64 {
+	window := c.width * time.Duration(len(c.buckets))
+	return float64(c.Sum()) / window.Seconds()
+}
+
+// Reset discards all counts, starting a new window at the current time.
+func (c *RollingCounter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.buckets)
+	c.head = 0
+	c.start = c.clock.Now()
+}
+
+// rotate discards any buckets that have fallen out of the window at the
+// current time of the clock, starting a new, empty bucket for each.
+//
+// This method is not thread-safe and should only be called while the counter
+// is locked.
+func (c *RollingCounter) rotate() {
+	n := int(c.clock.Now().Sub(c.start) / c.width)
+	if n <= 0 {
+		return
+	}
+
+	c.start = c.start.Add(time.Duration(n) * c.width)
+	if n >= len(c.buckets) {
+		clear(c.buckets)
+		return
+	}
+	for range n {
+		c.head = (c.head + 1) % len(c.buckets)
+		c.buckets[c.head] = 0
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// SlidingWindow estimates the number of events in a sliding window using the
+// counts in the current and previous fixed windows, weighting the count in the
+// previous window by the proportion of it that overlaps the sliding window.
+//
+// This requires constant space regardless of the rate of events, at the cost
+// of assuming that events in the previous window were evenly distributed.
+// For a count with a finer resolution, use a RollingCounter.
+//
+// Windows are advanced when the SlidingWindow is accessed, so a SlidingWindow
+// requires no goroutine or timer; a window using a mock clock slides as the
+// mock clock is advanced.
+type SlidingWindow struct {
+	mu     sync.Mutex
+	clock  Clock
+	window time.Duration
+	start  time.Time
+	prev   int64
+	curr   int64
+}
+
+// NewSlidingWindow returns a SlidingWindow of a given duration using a given
+// clock (or the system clock, if nil).  The window must be greater than zero;
+// if window <= 0, NewSlidingWindow will panic.
+func NewSlidingWindow(clock Clock, window time.Duration) *SlidingWindow {
+	if window <= 0 {
+		panic(fmt.Errorf("%w for NewSlidingWindow", errNonPositiveInterval))
+	}
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	return &SlidingWindow{
+		clock:  clock,
+		window: window,
+		start:  clock.Now(),
+	}
+}
+
+// Add adds n to the count in the current window.
+func (w *SlidingWindow) Add(n int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.slide(w.clock.Now())
+	w.curr += n
+}
+
+// Inc adds one to the count in the current window.
+func (w *SlidingWindow) Inc() {
+	w.Add(1)
+}
+
+// Count returns the estimated number of events in the window ending at the
+// current time of the clock.
+func (w *SlidingWindow) Count() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	w.slide(now)
+	overlap := 1 - float64(now.Sub(w.start))/float64(w.window)
+	return float64(w.prev)*overlap + float64(w.curr)
+}
+
+// Rate returns the estimated number of events per second in the window ending
+// at the current time of the clock.
+func (w *SlidingWindow) Rate() float64 {
+	return w.Count() / w.window.Seconds()
+}
+
+// slide advances the current window to that containing a given time.
+//
+// This method is not thread-safe and should only be called while the window
+// is locked.
+func (w *SlidingWindow) slide(now time.Time) {
+	n := now.Sub(w.start) / w.window
+	switch {
+	case n <= 0:
+		return
+	case n == 1:
+		w.prev = w.curr
+	default:
+		w.prev = 0
+	}
+	w.curr = 0
+	w.start = w.start.Add(n * w.window)
+}
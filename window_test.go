@@ -0,0 +1,112 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that buckets are rotated out of a rolling counter as the clock advances.
+func TestRollingCounter(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = NewRollingCounter(clock, 3*time.Second, 3)
+	)
+
+	// act
+	sut.Add(5)
+	clock.AdvanceBy(time.Second)
+	sut.Inc()
+	clock.AdvanceBy(time.Second)
+	sut.Add(2)
+
+	// assert
+	test.That(t, sut.Buckets()).Equals([]int64{5, 1, 2})
+	test.That(t, sut.Sum()).Equals(int64(8))
+
+	clock.AdvanceBy(time.Second)
+	test.That(t, sut.Buckets()).Equals([]int64{1, 2, 0})
+	test.That(t, sut.Rate()).Equals(1.0)
+
+	clock.AdvanceBy(10 * time.Second)
+	test.That(t, sut.Sum()).Equals(int64(0))
+}
+
+// Tests that a reset rolling counter discards all counts.
+func TestRollingCounter_Reset(t *testing.T) {
+	// arrange
+	sut := NewRollingCounter(NewMockClock(), time.Second, 10)
+	sut.Add(3)
+
+	// act
+	sut.Reset()
+
+	// assert
+	test.That(t, sut.Sum()).Equals(int64(0))
+}
+
+// Tests that an invalid window or number of buckets panics.
+func TestNewRollingCounter_Invalid(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		window   time.Duration
+		buckets  int
+	}{
+		{scenario: "zero window", window: 0, buckets: 1},
+		{scenario: "no buckets", window: time.Second, buckets: 0},
+		{scenario: "too many buckets", window: 2, buckets: 3},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			defer test.ExpectPanic(errNonPositiveInterval).Assert(t)
+			_ = NewRollingCounter(NewMockClock(), tc.window, tc.buckets)
+		})
+	}
+}
+
+// Tests that the count in the previous window is weighted by its overlap with
+// the sliding window.
+func TestSlidingWindow(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = NewSlidingWindow(clock, 10*time.Second)
+	)
+	sut.Add(10)
+	clock.AdvanceBy(10 * time.Second)
+	sut.Add(4)
+
+	testcases := []struct {
+		scenario string
+		advance  time.Duration
+		result   float64
+	}{
+		{scenario: "start of window", advance: 0, result: 14},
+		{scenario: "part way through window", advance: 2500 * time.Millisecond, result: 11.5},
+		{scenario: "next window", advance: 10 * time.Second, result: 3},
+		{scenario: "after idle window", advance: 10 * time.Second, result: 0},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// act
+			clock.AdvanceBy(tc.advance)
+
+			// assert
+			test.That(t, sut.Count()).Equals(tc.result)
+		})
+	}
+}
+
+// Tests the rate of events in a sliding window.
+func TestSlidingWindow_Rate(t *testing.T) {
+	// arrange
+	sut := NewSlidingWindow(NewMockClock(), 2*time.Second)
+
+	// act
+	sut.Add(5)
+
+	// assert
+	test.That(t, sut.Rate()).Equals(2.5)
+}
@@ -0,0 +1,235 @@
+package time
+
+import (
+	"sync"
+	"time"
+)
+
+// DelayingQueue is a work queue, in the style of the workqueue package of the
+// Kubernetes client, to which items may be added immediately or after a delay
+// (as determined by a timer of a clock).
+//
+// An item is queued at most once: adding an item that is already queued has
+// no effect.  An item obtained by Get is being processed until Done is called
+// for it; an item added while it is being processed is queued when Done is
+// called, so that an item is never processed concurrently.
+//
+// Adding an item after a delay while it is already waiting to be added has
+// effect only if the new delay would add the item sooner.
+type DelayingQueue[T comparable] struct {
+	mu           sync.Mutex
+	cond         *sync.Cond
+	clock        Clock
+	queue        []T
+	dirty        map[T]struct{}
+	processing   map[T]struct{}
+	waiting      map[T]waitingItem
+	shuttingDown bool
+}
+
+// waitingItem records the timer that will add a delayed item to a queue and
+// the time at which it will be added.
+type waitingItem struct {
+	timer *Timer
+	ready time.Time
+}
+
+// NewDelayingQueue returns a DelayingQueue using a given clock (or the system
+// clock, if nil) for delays.
+func NewDelayingQueue[T comparable](clock Clock) *DelayingQueue[T] {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	q := &DelayingQueue[T]{
+		clock:      clock,
+		dirty:      map[T]struct{}{},
+		processing: map[T]struct{}{},
+		waiting:    map[T]waitingItem{},
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Add queues an item, unless it is already queued or the queue is shutting
+// down.
+func (q *DelayingQueue[T]) Add(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.add(item)
+}
+
+// AddAfter queues an item after a given delay.  If the delay is not positive
+// the item is queued immediately.
+func (q *DelayingQueue[T]) AddAfter(item T, d time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.shuttingDown {
+		return
+	}
+	if d <= 0 {
+		q.add(item)
+		return
+	}
+
+	ready := q.clock.Now().Add(d)
+	if w, ok := q.waiting[item]; ok {
+		if !ready.Before(w.ready) {
+			return
+		}
+		w.timer.Stop()
+	}
+
+	q.waiting[item] = waitingItem{
+		ready: ready,
+		timer: q.clock.AfterFunc(d, func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+
+			if w, ok := q.waiting[item]; ok && w.ready.Equal(ready) {
+				delete(q.waiting, item)
+				q.add(item)
+			}
+		}),
+	}
+}
+
+// Get blocks until an item is queued, returning the item and false, or until
+// the queue is shut down, returning the zero value and true.  The item is
+// being processed until Done is called for it.
+func (q *DelayingQueue[T]) Get() (item T, shutdown bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.queue) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.queue) == 0 {
+		return item, true
+	}
+
+	item, q.queue = q.queue[0], q.queue[1:]
+	q.processing[item] = struct{}{}
+	delete(q.dirty, item)
+	return item, false
+}
+
+// Done marks an item as processed, queueing it again if it was added while
+// being processed.
+func (q *DelayingQueue[T]) Done(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.processing, item)
+	if _, ok := q.dirty[item]; ok {
+		q.queue = append(q.queue, item)
+		q.cond.Signal()
+	}
+}
+
+// Len returns the number of items queued (excluding items being processed or
+// waiting to be added after a delay).
+func (q *DelayingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.queue)
+}
+
+// ShutDown shuts down the queue: items waiting to be added after a delay are
+// discarded, further items are not queued and Get returns once any queued
+// items have been obtained.
+func (q *DelayingQueue[T]) ShutDown() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.shuttingDown = true
+	for item, w := range q.waiting {
+		w.timer.Stop()
+		delete(q.waiting, item)
+	}
+	q.cond.Broadcast()
+}
+
+// ShuttingDown returns true if the queue has been shut down.
+func (q *DelayingQueue[T]) ShuttingDown() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.shuttingDown
+}
+
+// add queues an item, unless it is already queued or the queue is shutting
+// down.
+//
+// This method is not thread-safe and should only be called while the queue
+// is locked.
+func (q *DelayingQueue[T]) add(item T) {
+	if q.shuttingDown {
+		return
+	}
+	if _, ok := q.dirty[item]; ok {
+		return
+	}
+
+	q.dirty[item] = struct{}{}
+	if _, ok := q.processing[item]; ok {
+		return
+	}
+	q.queue = append(q.queue, item)
+	q.cond.Signal()
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// RateLimitedQueue is a DelayingQueue to which items may be requeued with a
+// delay determined by a Backoff policy, increasing with each requeue of the
+// item until it is forgotten.
+type RateLimitedQueue[T comparable] struct {
+	*DelayingQueue[T]
+	backoff  Backoff
+	requeues map[T]int
+	mu       sync.Mutex
+}
+
+// NewRateLimitedQueue returns a RateLimitedQueue using a given clock (or the
+// system clock, if nil) for delays, with delays between requeues determined
+// by a given Backoff policy.
+func NewRateLimitedQueue[T comparable](clock Clock, backoff Backoff) *RateLimitedQueue[T] {
+	return &RateLimitedQueue[T]{
+		DelayingQueue: NewDelayingQueue[T](clock),
+		backoff:       backoff,
+		requeues:      map[T]int{},
+	}
+}
+
+// AddRateLimited queues an item after the delay determined by the policy of
+// the queue for the number of times that the item has been requeued.
+func (q *RateLimitedQueue[T]) AddRateLimited(item T) {
+	q.mu.Lock()
+	n := q.requeues[item]
+	q.requeues[item] = n + 1
+	q.mu.Unlock()
+
+	q.AddAfter(item, q.backoff.Delay(n))
+}
+
+// Forget resets the number of times that an item has been requeued, so that
+// the next requeue of the item is not delayed by previous requeues.  It
+// should be called when an item has been processed successfully.
+func (q *RateLimitedQueue[T]) Forget(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.requeues, item)
+}
+
+// NumRequeues returns the number of times that an item has been requeued
+// since it was last forgotten.
+func (q *RateLimitedQueue[T]) NumRequeues(item T) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.requeues[item]
+}
@@ -0,0 +1,102 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// Tests that an item is queued at most once and is not processed concurrently.
+func TestDelayingQueue_Dedup(t *testing.T) {
+	// arrange
+	sut := NewDelayingQueue[string](NewMockClock())
+	sut.Add("a")
+	sut.Add("a")
+	sut.Add("b")
+
+	// act
+	first, _ := sut.Get()
+	sut.Add("a")
+	queued := sut.Len()
+	sut.Done(first)
+
+	// assert
+	test.That(t, first).Equals("a")
+	test.That(t, queued).Equals(1)
+	test.That(t, sut.Len()).Equals(2)
+}
+
+// Tests that an item added after a delay is queued when the delay has elapsed.
+func TestDelayingQueue_AddAfter(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = NewDelayingQueue[string](clock)
+	)
+	sut.AddAfter("a", 2*time.Second)
+	sut.AddAfter("a", time.Second)
+	sut.AddAfter("a", 3*time.Second)
+
+	// act
+	clock.AdvanceBy(999 * time.Millisecond)
+	early := sut.Len()
+	clock.AdvanceBy(time.Millisecond)
+	waitFor(func() bool { return sut.Len() > 0 })
+	clock.AdvanceBy(5 * time.Second)
+
+	// assert
+	test.That(t, early).Equals(0)
+	test.That(t, sut.Len()).Equals(1)
+}
+
+// Tests that a shut down queue discards delayed items and ends Get.
+func TestDelayingQueue_ShutDown(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = NewDelayingQueue[string](clock)
+	)
+	sut.Add("a")
+	sut.AddAfter("b", time.Second)
+
+	// act
+	sut.ShutDown()
+	clock.AdvanceBy(time.Second)
+	sut.Add("c")
+
+	// assert
+	test.IsTrue(t, sut.ShuttingDown())
+	item, shutdown := sut.Get()
+	test.That(t, item).Equals("a")
+	test.IsFalse(t, shutdown)
+	_, shutdown = sut.Get()
+	test.IsTrue(t, shutdown)
+}
+
+// Tests that requeued items are delayed according to the backoff policy
+// until forgotten.
+func TestRateLimitedQueue(t *testing.T) {
+	// arrange
+	var (
+		clock = NewMockClock()
+		sut   = NewRateLimitedQueue[string](clock, Backoff{Initial: time.Second})
+	)
+
+	// act
+	sut.AddRateLimited("a")
+	sut.AddRateLimited("a")
+	requeues := sut.NumRequeues("a")
+	sut.Forget("a")
+
+	// assert
+	test.That(t, requeues).Equals(2)
+	test.That(t, sut.NumRequeues("a")).Equals(0)
+	test.That(t, clock.Timers()[0].Next).Equals(time.Unix(1, 0).UTC())
+	test.That(t, len(clock.Timers())).Equals(1)
+
+	clock.AdvanceBy(time.Second)
+	waitFor(func() bool { return sut.Len() > 0 })
+	item, _ := sut.Get()
+	test.That(t, item).Equals("a")
+}